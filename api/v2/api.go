@@ -1,14 +1,23 @@
 package v2
 
 import (
+	"context"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/quantsmithapp/datastation-backend/config"
 	"github.com/quantsmithapp/datastation-backend/infra"
 	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/repo"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
 	"github.com/quantsmithapp/datastation-backend/internal/core/service"
 	"github.com/quantsmithapp/datastation-backend/internal/middleware"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/internal/tradingview"
+	"github.com/quantsmithapp/datastation-backend/pkg/eventbus"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
 )
 
+const signalEventBuffer = 1024
+
 func BindApiV2(router fiber.Router) {
 	v2 := router.Group("/v2")
 
@@ -17,26 +26,62 @@ func BindApiV2(router fiber.Router) {
 	jwtService := service.NewJwtService(infra.FirebaseClient, &config, authRepo)
 	authMiddleware := middleware.AuthMiddleware(authRepo, jwtService)
 	authCRMMiddleware := middleware.AuthCRMMiddleware(authRepo, jwtService)
+	// One ticker service resolves tickers for every feature so alias edits apply everywhere
+	tickerService := service.NewTickerService(repo.NewTickerRepo(infra.PostgresDB))
+	if err := tickerService.Reload(context.Background()); err != nil {
+		logger.Errorf("ticker: failed to load assets and aliases: %v", err)
+	}
+	go tickerService.Start(context.Background())
+	// One price cache serves every price read, Timescale candles fill in the coins the TradingView screener lacks
+	priceProviders := []port.PriceProvider{repo.NewTradingViewPriceProvider()}
+	if timescaleDB, err := infra.GetTimescaleDBConnection(); err == nil {
+		priceProviders = append(priceProviders, repo.NewTimescalePriceProvider(repo.NewTimescaleRepo(timescaleDB)))
+	}
+	// One TradingView client shares its pooled chart sessions between historical reads, backfills and the price stream
+	tvClient, err := tradingview.NewTradingViewClient("", "")
+	if err != nil {
+		panic(err)
+	}
+	priceCache := service.NewPriceCache(tickerService, priceProviders...)
+	go priceCache.Start(context.Background())
+	// Ingestion publishes a SignalEvent per accepted record, trending and notifications subscribe to it
+	signalEvents := eventbus.New[model.SignalEvent](signalEventBuffer)
 	bindInitPageStatus(v2)
 
 	bindMarketSummaryAPI(v2, authMiddleware)
-	bindTwitterCryptoAPI(v2, authMiddleware)
-	bindSentimentCryptoAPI(v2, authMiddleware)
-	bindTradingViewAPI(v2, authMiddleware)
+	bindTwitterCryptoAPI(v2, authMiddleware, tickerService)
+	bindSentimentCryptoAPI(v2, authMiddleware, tickerService)
+	bindTradingViewAPI(v2, authMiddleware, tvClient, priceCache)
 	bindAuth(v2, authRepo, authMiddleware, &config)
 	bindGetTagAPI(v2)
 	bindTopRankAPI(v2)
 	bindWinRateAPI(v2, authMiddleware)
 	bindMarketCapAPI(v2, authMiddleware)
-	bindNewsSentimentCryptoAPI(v2)
+	bindNewsSentimentCryptoAPI(v2, tickerService)
 	bindSentimentAnalysisRouter(v2, authMiddleware)
-	bindMarketOverviewAPI(v2)
+	bindMarketOverviewAPI(v2, tickerService, priceCache)
 	bindCryptoUserRefcodeAPI(v2, authMiddleware)
 	bindFeatures(v2, authMiddleware)
 	bindCryptoCRMAPI(v2, authRepo, authCRMMiddleware, &config)
-	bindCryptoNotificationAPI(v2, authMiddleware, &config)
+	bindCryptoNotificationAPI(v2, authMiddleware, signalEvents, &config)
 	bindTelegramAPI(v2, &config)
 	bindDexAPI(v2, authMiddleware)
 	bindCexAPI(v2, authMiddleware)
 	bindPerformanceAPI(v2, authMiddleware)
+	bindTierEngineAPI(v2, authCRMMiddleware, &config)
+	bindAuthorHealthAPI(v2, authMiddleware, authCRMMiddleware, &config)
+	bindTrendingAPI(v2, authMiddleware, tickerService, signalEvents, &config)
+	bindDivergenceAPI(v2, tickerService)
+	bindConsensusAPI(v2, tickerService, &config)
+	bindTickerAPI(v2, tickerService, authCRMMiddleware)
+	bindAuthorNetworkAPI(v2)
+	bindIngestAPI(v2, tickerService, signalEvents, authCRMMiddleware)
+	bindSignalReviewAPI(v2, authMiddleware, authCRMMiddleware, tickerService)
+	bindPromptComparisonAPI(v2, authCRMMiddleware)
+	bindTweetDigestAPI(v2, authMiddleware, authCRMMiddleware, &config)
+	bindIndicatorAPI(v2, authMiddleware)
+	bindCorrelationAPI(v2, authMiddleware, tickerService)
+	bindOHLCVExportAPI(v2, authMiddleware)
+	bindOHLCVQualityAPI(v2, authCRMMiddleware, tvClient, &config)
+	bindPriceStreamAPI(v2, middleware.WebSocketAuthMiddleware(authRepo, jwtService), tvClient)
 }
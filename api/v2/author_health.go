@@ -0,0 +1,40 @@
+package v2
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/quantsmithapp/datastation-backend/config"
+	"github.com/quantsmithapp/datastation-backend/infra"
+	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/handler"
+	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/repo"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/core/service"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
+)
+
+func bindAuthorHealthAPI(router fiber.Router, authMiddleware fiber.Handler, authCRMMiddleware fiber.Handler, config *config.Config) {
+	authorHealthRepo := repo.NewAuthorHealthRepo(infra.CryptoDB)
+	notificationRepo := repo.NewCryptoNotificationRepo(infra.CryptoDB)
+
+	var telegramService port.TelegramService
+	if ts, err := service.NewTelegramService(config.Telegram.BotToken); err != nil {
+		logger.Errorf("author health: telegram alerts disabled: %v", err)
+	} else {
+		telegramService = ts
+	}
+
+	authorHealthService := service.NewAuthorHealthService(authorHealthRepo, notificationRepo, telegramService, config.AuthorHealth)
+	authorHealthHandler := handler.NewAuthorHealthHandler(authorHealthService)
+
+	if config.AuthorHealth.Enabled {
+		go authorHealthService.Start(context.Background())
+	}
+
+	router.Get("/author-health", authMiddleware, authorHealthHandler.GetAuthorHealth)
+	router.Get("/author-health/preference", authMiddleware, authorHealthHandler.GetPreference)
+	router.Post("/author-health/preference", authMiddleware, authorHealthHandler.UpdatePreference)
+	router.Get("/author-health/paused", authMiddleware, authorHealthHandler.GetPausedSubscriptions)
+	router.Post("/author-health/resume", authMiddleware, authorHealthHandler.ResumeSubscription)
+	router.Post("/crm/author-health/evaluate", authCRMMiddleware, authorHealthHandler.Evaluate)
+}
@@ -0,0 +1,21 @@
+package v2
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/quantsmithapp/datastation-backend/infra"
+	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/handler"
+	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/repo"
+	"github.com/quantsmithapp/datastation-backend/internal/core/service"
+)
+
+func bindAuthorNetworkAPI(router fiber.Router) {
+	db, err := infra.GetPostgresConnection()
+	if err != nil {
+		panic(err)
+	}
+
+	authorNetworkHandler := handler.NewAuthorNetworkHandler(service.NewAuthorNetworkService(repo.NewAuthorNetworkRepo(db)))
+
+	router.Get("/twitter-crypto/authors/clusters", authorNetworkHandler.GetClusters)
+	router.Get("/twitter-crypto/authors/:username/similar", authorNetworkHandler.GetSimilarAuthors)
+}
@@ -0,0 +1,30 @@
+package v2
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/quantsmithapp/datastation-backend/config"
+	"github.com/quantsmithapp/datastation-backend/infra"
+	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/handler"
+	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/repo"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/core/service"
+)
+
+func bindConsensusAPI(router fiber.Router, tickerService port.TickerService, config *config.Config) {
+	db, err := infra.GetPostgresConnection()
+	if err != nil {
+		panic(err)
+	}
+
+	consensusService := service.NewConsensusService(repo.NewConsensusRepo(infra.CryptoDB, db), tickerService, config.Consensus)
+	consensusHandler := handler.NewConsensusHandler(consensusService)
+
+	if config.Consensus.Enabled {
+		go consensusService.Start(context.Background())
+	}
+
+	router.Get("/twitter-crypto/consensus", consensusHandler.GetConsensus)
+	router.Get("/twitter-crypto/consensus/history", consensusHandler.GetConsensusHistory)
+}
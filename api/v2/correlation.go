@@ -0,0 +1,22 @@
+package v2
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/quantsmithapp/datastation-backend/infra"
+	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/handler"
+	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/repo"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/core/service"
+)
+
+func bindCorrelationAPI(router fiber.Router, authMiddleware fiber.Handler, tickerService port.TickerService) {
+	// Correlations are computed over Timescale OHLCV, the endpoint responds 503 when the connection is not initialized
+	var ohlcvRepo port.TimescaleRepo
+	if timescaleDB, err := infra.GetTimescaleDBConnection(); err == nil {
+		ohlcvRepo = repo.NewTimescaleRepo(timescaleDB)
+	}
+	marketCapRepo := repo.NewMarketCapRepo(infra.PostgresDB)
+	correlationHandler := handler.NewCorrelationHandler(service.NewCorrelationService(ohlcvRepo, marketCapRepo, tickerService))
+
+	router.Get("/timescale/crypto/correlation", authMiddleware, correlationHandler.GetCorrelation)
+}
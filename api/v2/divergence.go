@@ -0,0 +1,29 @@
+package v2
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/quantsmithapp/datastation-backend/infra"
+	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/handler"
+	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/repo"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/core/service"
+)
+
+func bindDivergenceAPI(router fiber.Router, tickerService port.TickerService) {
+	db, err := infra.GetPostgresConnection()
+	if err != nil {
+		panic(err)
+	}
+	twitterService := service.NewTwitterCryptoService(repo.NewTwitterCryptoRepo(db), tickerService)
+
+	// Prices come from Timescale, the endpoints respond 503 when the connection is not initialized
+	var ohlcvRepo port.TimescaleRepo
+	if timescaleDB, err := infra.GetTimescaleDBConnection(); err == nil {
+		ohlcvRepo = repo.NewTimescaleRepo(timescaleDB)
+	}
+
+	divergenceHandler := handler.NewDivergenceHandler(service.NewDivergenceService(twitterService, ohlcvRepo, tickerService))
+
+	router.Get("/twitter-crypto/divergence", divergenceHandler.GetTickerDivergence)
+	router.Get("/twitter-crypto/divergence/scan", divergenceHandler.ScanDivergences)
+}
@@ -0,0 +1,22 @@
+package v2
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/quantsmithapp/datastation-backend/infra"
+	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/handler"
+	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/repo"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/core/service"
+)
+
+func bindIndicatorAPI(router fiber.Router, authMiddleware fiber.Handler) {
+	// Indicators are computed over Timescale OHLCV, the endpoints respond 503 when the connection is not initialized
+	var ohlcvRepo port.TimescaleRepo
+	if timescaleDB, err := infra.GetTimescaleDBConnection(); err == nil {
+		ohlcvRepo = repo.NewTimescaleRepo(timescaleDB)
+	}
+	indicatorHandler := handler.NewIndicatorHandler(service.NewIndicatorService(ohlcvRepo))
+
+	router.Get("/timescale/crypto/indicators", authMiddleware, indicatorHandler.GetCryptoIndicators)
+	router.Get("/timescale/forex/indicators", authMiddleware, indicatorHandler.GetForexIndicators)
+}
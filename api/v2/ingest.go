@@ -0,0 +1,30 @@
+package v2
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/quantsmithapp/datastation-backend/infra"
+	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/handler"
+	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/repo"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/core/service"
+	"github.com/quantsmithapp/datastation-backend/internal/middleware"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
+
+func bindIngestAPI(router fiber.Router, tickerService port.TickerService, signalEvents port.SignalEventBus, authCRMMiddleware fiber.Handler) {
+	db, err := infra.GetPostgresConnection()
+	if err != nil {
+		panic(err)
+	}
+
+	ingestService := service.NewIngestService(repo.NewIngestRepo(db), tickerService, signalEvents)
+	ingestHandler := handler.NewIngestHandler(ingestService)
+
+	router.Post("/ingest/tweets", middleware.IngestAPIKeyMiddleware(ingestService, model.IngestScopeTweets), ingestHandler.IngestTweets)
+	router.Post("/ingest/sentiments", middleware.IngestAPIKeyMiddleware(ingestService, model.IngestScopeSentiments), ingestHandler.IngestSentiments)
+	router.Post("/ingest/signals", middleware.IngestAPIKeyMiddleware(ingestService, model.IngestScopeSignals), ingestHandler.IngestSignals)
+
+	router.Get("/crm/ingest-keys", authCRMMiddleware, ingestHandler.GetAPIKeys)
+	router.Post("/crm/ingest-keys", authCRMMiddleware, ingestHandler.CreateAPIKey)
+	router.Delete("/crm/ingest-keys/:id", authCRMMiddleware, ingestHandler.RevokeAPIKey)
+}
@@ -5,13 +5,14 @@ import (
 	"github.com/quantsmithapp/datastation-backend/infra"
 	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/handler"
 	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/repo"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
 	"github.com/quantsmithapp/datastation-backend/internal/core/service"
 )
 
 // func bindMarketOverviewAPI(router fiber.Router, authMiddleware fiber.Handler)
-func bindMarketOverviewAPI(router fiber.Router) {
+func bindMarketOverviewAPI(router fiber.Router, tickerService port.TickerService, priceCache port.PriceCache) {
 	marketOverviewRepo := repo.NewMarketOverviewRepo(infra.PostgresDB)
-	marketOverviewService := service.NewMarketOverviewService(marketOverviewRepo)
+	marketOverviewService := service.NewMarketOverviewService(marketOverviewRepo, tickerService, priceCache)
 	marketOverviewHandler := handler.NewMarketOverviewHandler(marketOverviewService)
 	// marketOverviewGroup := router.Group("/market-overview", authMiddleware)
 	marketOverviewGroup := router.Group("/market-overview")
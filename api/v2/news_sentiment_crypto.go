@@ -5,18 +5,19 @@ import (
 	"github.com/quantsmithapp/datastation-backend/infra"
 	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/handler"
 	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/repo"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
 	"github.com/quantsmithapp/datastation-backend/internal/core/service"
 )
 
 // func bindNewsSentimentCryptoAPI(router fiber.Router, authMiddleware fiber.Handler)
-func bindNewsSentimentCryptoAPI(router fiber.Router) {
+func bindNewsSentimentCryptoAPI(router fiber.Router, tickerService port.TickerService) {
 	db, err := infra.GetPostgresConnection()
 	if err != nil {
 		panic(err)
 	}
 
 	newsSentimentRepo := repo.NewNewsSentimentCryptoRepo(db)
-	newsSentimentService := service.NewNewsSentimentCryptoService(newsSentimentRepo)
+	newsSentimentService := service.NewNewsSentimentCryptoService(newsSentimentRepo, tickerService)
 	newsSentimentHandler := handler.NewNewsSentimentCryptoHandler(newsSentimentService)
 	newsSentiment := router.Group("/news-sentiment-crypto")
 	// newsSentiment := router.Group("/news-sentiment-crypto", authMiddleware)
@@ -24,4 +25,6 @@ func bindNewsSentimentCryptoAPI(router fiber.Router) {
 		return c.SendString("News Sentiment Crypto API is working!")
 	})
 	newsSentiment.Get("/news-sentiment", newsSentimentHandler.GetNewsSentiment)
+	newsSentiment.Get("/sentiment", newsSentimentHandler.QueryNewsSentiment)
+	newsSentiment.Get("/sources", newsSentimentHandler.GetNewsSources)
 }
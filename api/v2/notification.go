@@ -6,12 +6,23 @@ import (
 	"github.com/quantsmithapp/datastation-backend/infra"
 	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/handler"
 	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/repo"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
 	"github.com/quantsmithapp/datastation-backend/internal/core/service"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
 )
 
-func bindCryptoNotificationAPI(router fiber.Router, authMiddleware fiber.Handler, config *config.Config) {
+func bindCryptoNotificationAPI(router fiber.Router, authMiddleware fiber.Handler, signalEvents port.SignalEventBus, config *config.Config) {
 	notificationRepo := repo.NewCryptoNotificationRepo(infra.CryptoDB)
-	notificationService := service.NewCryptoNotificationService(notificationRepo)
+
+	var telegramService port.TelegramService
+	if ts, err := service.NewTelegramService(config.Telegram.BotToken); err != nil {
+		logger.Errorf("notification: telegram signal alerts disabled: %v", err)
+	} else {
+		telegramService = ts
+	}
+
+	notificationService := service.NewCryptoNotificationService(notificationRepo, telegramService)
+	signalEvents.Subscribe("notification", notificationService.NotifySignal)
 	notificationHandler := handler.NewCryptoNotificationHandler(notificationService, config)
 	router.Get("/notification/get-group", authMiddleware, notificationHandler.GetNotificationGroupList)
 	router.Post("/notification/update-group-name", authMiddleware, notificationHandler.UpdateGroupName)
@@ -0,0 +1,28 @@
+package v2
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/quantsmithapp/datastation-backend/infra"
+	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/handler"
+	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/repo"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/core/service"
+)
+
+func bindOHLCVExportAPI(router fiber.Router, authMiddleware fiber.Handler) {
+	// Exports read Timescale OHLCV, the endpoints respond 503 when the connection is not initialized
+	var ohlcvRepo port.TimescaleRepo
+	if timescaleDB, err := infra.GetTimescaleDBConnection(); err == nil {
+		ohlcvRepo = repo.NewTimescaleRepo(timescaleDB)
+	}
+	exportService := service.NewOHLCVExportService(ohlcvRepo)
+	go exportService.Start(context.Background())
+	exportHandler := handler.NewOHLCVExportHandler(exportService)
+
+	router.Get("/timescale/export", authMiddleware, exportHandler.ExportOHLCV)
+	router.Post("/timescale/exports", authMiddleware, exportHandler.CreateExportJob)
+	router.Get("/timescale/exports/:id", authMiddleware, exportHandler.GetExportJob)
+	router.Get("/timescale/exports/:id/download", authMiddleware, exportHandler.DownloadExport)
+}
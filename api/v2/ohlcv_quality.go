@@ -0,0 +1,33 @@
+package v2
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/quantsmithapp/datastation-backend/config"
+	"github.com/quantsmithapp/datastation-backend/infra"
+	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/handler"
+	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/repo"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/core/service"
+	"github.com/quantsmithapp/datastation-backend/internal/tradingview"
+)
+
+func bindOHLCVQualityAPI(router fiber.Router, authCRMMiddleware fiber.Handler, client *tradingview.TradingViewClient, config *config.Config) {
+	// Scans read and store in Timescale, the endpoints respond 503 when the connection is not initialized
+	var qualityRepo port.OHLCVQualityRepo
+	if timescaleDB, err := infra.GetTimescaleDBConnection(); err == nil {
+		qualityRepo = repo.NewOHLCVQualityRepo(timescaleDB)
+	}
+	backfill := repo.NewTradingViewBackfillSource(client)
+	qualityService := service.NewOHLCVQualityService(qualityRepo, backfill, config.OHLCVQuality)
+	qualityHandler := handler.NewOHLCVQualityHandler(qualityService)
+
+	if config.OHLCVQuality.Enabled && qualityRepo != nil {
+		go qualityService.Start(context.Background())
+	}
+
+	router.Get("/crm/ohlcv-quality", authCRMMiddleware, qualityHandler.GetReport)
+	router.Get("/crm/ohlcv-quality/runs", authCRMMiddleware, qualityHandler.GetRuns)
+	router.Post("/crm/ohlcv-quality/scan", authCRMMiddleware, qualityHandler.TriggerScan)
+}
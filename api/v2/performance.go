@@ -23,4 +23,5 @@ func bindPerformanceAPI(router fiber.Router, authMiddleware fiber.Handler) {
 	router.Get("/performance/get-multiholding-port-nav", performanceHandler.GetMultiholdingPortNavHandle)
 	router.Get("/performance/get-author-detail", performanceHandler.GetAuthorDetailHandle)
 	router.Get("/performance/get-author-multiholding-detail", performanceHandler.GetAuthorMultiholdingDetailHandle)
+	router.Get("/performance/get-author-return-stats", performanceHandler.GetAuthorReturnStatsHandle)
 }
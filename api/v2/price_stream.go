@@ -0,0 +1,25 @@
+package v2
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/websocket/v2"
+	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/handler"
+	"github.com/quantsmithapp/datastation-backend/internal/core/service"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/internal/tradingview"
+)
+
+func bindPriceStreamAPI(router fiber.Router, wsAuthMiddleware fiber.Handler, client *tradingview.TradingViewClient) {
+	// One TradingView quote session feeds every connection, it only connects while a symbol is subscribed
+	var hub *service.PriceHub
+	stream := client.NewQuoteStream(func(quote model.PriceQuote) {
+		hub.Publish(quote)
+	})
+	hub = service.NewPriceHub(stream)
+	go stream.Run(context.Background())
+
+	priceStreamHandler := handler.NewPriceStreamHandler(hub)
+	router.Get("/ws/prices", wsAuthMiddleware, priceStreamHandler.RequireUpgrade, websocket.New(priceStreamHandler.StreamPrices))
+}
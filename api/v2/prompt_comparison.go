@@ -0,0 +1,29 @@
+package v2
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/quantsmithapp/datastation-backend/infra"
+	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/handler"
+	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/repo"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/core/service"
+)
+
+func bindPromptComparisonAPI(router fiber.Router, authCRMMiddleware fiber.Handler) {
+	db, err := infra.GetPostgresConnection()
+	if err != nil {
+		panic(err)
+	}
+
+	// Hit rates need OHLCV from Timescale, comparisons leave them empty when the connection is not initialized
+	var ohlcvRepo port.TimescaleRepo
+	if tsdb, err := infra.GetTimescaleDBConnection(); err == nil {
+		ohlcvRepo = repo.NewTimescaleRepo(tsdb)
+	}
+	promptComparisonService := service.NewPromptComparisonService(repo.NewPromptComparisonRepo(db), ohlcvRepo)
+	promptComparisonHandler := handler.NewPromptComparisonHandler(promptComparisonService)
+
+	router.Get("/twitter-crypto/prompt-versions", promptComparisonHandler.GetPromptVersions)
+	router.Get("/twitter-crypto/prompt-versions/compare", promptComparisonHandler.ComparePromptVersions)
+	router.Post("/crm/prompt-versions/active", authCRMMiddleware, promptComparisonHandler.SetActivePromptVersion)
+}
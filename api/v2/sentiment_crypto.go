@@ -5,13 +5,14 @@ import (
 	"github.com/quantsmithapp/datastation-backend/infra"
 	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/handler"
 	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/repo"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
 	"github.com/quantsmithapp/datastation-backend/internal/core/service"
 	"github.com/quantsmithapp/datastation-backend/pkg/logger"
 )
 
-func bindSentimentCryptoAPI(router fiber.Router, authMiddleware fiber.Handler) {
+func bindSentimentCryptoAPI(router fiber.Router, authMiddleware fiber.Handler, tickerService port.TickerService) {
 	logger := logger.NewLogger()
-	sentimentRepo := repo.NewSentimentCryptoRepo(infra.StockDB, logger)
+	sentimentRepo := repo.NewSentimentCryptoRepo(infra.StockDB, tickerService, logger)
 	sentimentService := service.NewSentimentCryptoService(sentimentRepo, logger)
 	sentimentHandler := handler.NewSentimentCryptoHandler(sentimentService, logger)
 
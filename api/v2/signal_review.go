@@ -0,0 +1,28 @@
+package v2
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/quantsmithapp/datastation-backend/infra"
+	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/handler"
+	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/repo"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/core/service"
+)
+
+func bindSignalReviewAPI(router fiber.Router, authMiddleware fiber.Handler, authCRMMiddleware fiber.Handler, tickerService port.TickerService) {
+	db, err := infra.GetPostgresConnection()
+	if err != nil {
+		panic(err)
+	}
+
+	signalReviewHandler := handler.NewSignalReviewHandler(service.NewSignalReviewService(repo.NewSignalReviewRepo(db), tickerService))
+
+	router.Post("/twitter-crypto/signals/flag", authMiddleware, signalReviewHandler.FlagSignal)
+
+	router.Get("/crm/signal-reviews", authCRMMiddleware, signalReviewHandler.GetSignalReviews)
+	router.Get("/crm/signal-reviews/audit", authCRMMiddleware, signalReviewHandler.GetSignalReviewAudit)
+	router.Get("/crm/signal-reviews/export", authCRMMiddleware, signalReviewHandler.ExportSignalTrainingData)
+	router.Post("/crm/signal-reviews/correct", authCRMMiddleware, signalReviewHandler.CorrectSignal)
+	router.Post("/crm/signal-reviews/void", authCRMMiddleware, signalReviewHandler.VoidSignal)
+	router.Post("/crm/signal-reviews/confirm", authCRMMiddleware, signalReviewHandler.ConfirmSignal)
+}
@@ -0,0 +1,18 @@
+package v2
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/handler"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+)
+
+func bindTickerAPI(router fiber.Router, tickerService port.TickerService, authCRMMiddleware fiber.Handler) {
+	tickerHandler := handler.NewTickerHandler(tickerService)
+
+	router.Get("/tickers/resolve", tickerHandler.ResolveTickers)
+	router.Get("/crm/ticker-assets", authCRMMiddleware, tickerHandler.GetAssets)
+	router.Post("/crm/ticker-assets", authCRMMiddleware, tickerHandler.UpsertAsset)
+	router.Delete("/crm/ticker-assets/:id", authCRMMiddleware, tickerHandler.DeleteAsset)
+	router.Post("/crm/ticker-aliases", authCRMMiddleware, tickerHandler.UpsertAlias)
+	router.Delete("/crm/ticker-aliases/:alias", authCRMMiddleware, tickerHandler.DeleteAlias)
+}
@@ -0,0 +1,37 @@
+package v2
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/quantsmithapp/datastation-backend/config"
+	"github.com/quantsmithapp/datastation-backend/infra"
+	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/handler"
+	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/repo"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/core/service"
+)
+
+func bindTierEngineAPI(router fiber.Router, authCRMMiddleware fiber.Handler, config *config.Config) {
+	db, _ := infra.GetPostgresConnection()
+	authorTierRepo := repo.NewAuthorTierRepo(db)
+	authorTierService := service.NewAuthorTierService(authorTierRepo)
+	tierEngineRepo := repo.NewTierEngineRepo(infra.CryptoDB, db)
+	// Rolling winrates are evaluated against Timescale OHLCV, recomputes fail while the connection is not initialized
+	var ohlcvRepo port.TimescaleRepo
+	if timescaleDB, err := infra.GetTimescaleDBConnection(); err == nil {
+		ohlcvRepo = repo.NewTimescaleRepo(timescaleDB)
+	}
+	winRateService := service.NewWinRateService(repo.NewWinRateRepo(infra.PostgresDB), ohlcvRepo)
+	tierEngineService := service.NewTierEngineService(tierEngineRepo, authorTierService, winRateService, config.TierEngine)
+	tierEngineHandler := handler.NewTierEngineHandler(tierEngineService)
+
+	if config.TierEngine.Enabled {
+		go tierEngineService.Start(context.Background())
+	}
+
+	router.Get("/crm/author-tier/proposals", authCRMMiddleware, tierEngineHandler.GetProposals)
+	router.Post("/crm/author-tier/recompute", authCRMMiddleware, tierEngineHandler.Recompute)
+	router.Post("/crm/author-tier/proposals/approve", authCRMMiddleware, tierEngineHandler.ApproveProposal)
+	router.Post("/crm/author-tier/proposals/reject", authCRMMiddleware, tierEngineHandler.RejectProposal)
+}
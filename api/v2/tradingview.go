@@ -3,12 +3,14 @@ package v2
 import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/handler"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
 	"github.com/quantsmithapp/datastation-backend/internal/core/service"
+	"github.com/quantsmithapp/datastation-backend/internal/tradingview"
 )
 
-func bindTradingViewAPI(router fiber.Router, authMiddleware fiber.Handler) {
-	priceService := service.NewPriceService()
-	hdl := handler.NewTradingViewHandler(priceService)
+func bindTradingViewAPI(router fiber.Router, authMiddleware fiber.Handler, client *tradingview.TradingViewClient, priceCache port.PriceCache) {
+	priceService := service.NewPriceService(priceCache)
+	hdl := handler.NewTradingViewHandler(client, priceService)
 
 	_ = authMiddleware
 	tv := router.Group("/tradingview")
@@ -0,0 +1,44 @@
+package v2
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/quantsmithapp/datastation-backend/config"
+	"github.com/quantsmithapp/datastation-backend/infra"
+	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/handler"
+	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/repo"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/core/service"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
+)
+
+func bindTrendingAPI(router fiber.Router, authMiddleware fiber.Handler, tickerService port.TickerService, signalEvents port.SignalEventBus, config *config.Config) {
+	db, err := infra.GetPostgresConnection()
+	if err != nil {
+		panic(err)
+	}
+
+	trendingRepo := repo.NewTrendingRepo(infra.CryptoDB, db)
+	notificationRepo := repo.NewCryptoNotificationRepo(infra.CryptoDB)
+
+	var telegramService port.TelegramService
+	if ts, err := service.NewTelegramService(config.Telegram.BotToken); err != nil {
+		logger.Errorf("trending: telegram alerts disabled: %v", err)
+	} else {
+		telegramService = ts
+	}
+
+	trendingService := service.NewTrendingService(trendingRepo, notificationRepo, tickerService, telegramService, config.Trending)
+	trendingHandler := handler.NewTrendingHandler(trendingService)
+	signalEvents.Subscribe("trending", trendingService.HandleSignalEvent)
+
+	if config.Trending.Enabled {
+		go trendingService.Start(context.Background())
+	}
+
+	router.Get("/twitter-crypto/trending", trendingHandler.GetTrending)
+	router.Get("/twitter-crypto/watchlist", authMiddleware, trendingHandler.GetWatchlist)
+	router.Post("/twitter-crypto/watchlist", authMiddleware, trendingHandler.UpsertWatchlistTicker)
+	router.Delete("/twitter-crypto/watchlist/:ticker", authMiddleware, trendingHandler.DeleteWatchlistTicker)
+}
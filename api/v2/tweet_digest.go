@@ -0,0 +1,48 @@
+package v2
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/quantsmithapp/datastation-backend/config"
+	"github.com/quantsmithapp/datastation-backend/infra"
+	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/handler"
+	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/repo"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/core/service"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
+)
+
+func bindTweetDigestAPI(router fiber.Router, authMiddleware fiber.Handler, authCRMMiddleware fiber.Handler, config *config.Config) {
+	db, err := infra.GetPostgresConnection()
+	if err != nil {
+		panic(err)
+	}
+
+	var telegramService port.TelegramService
+	if ts, err := service.NewTelegramService(config.Telegram.BotToken); err != nil {
+		logger.Errorf("tweet digest: telegram delivery disabled: %v", err)
+	} else {
+		telegramService = ts
+	}
+	var emailService port.EmailService
+	if es, err := service.NewEmailService(config.Email); err != nil {
+		logger.Errorf("tweet digest: email delivery disabled: %v", err)
+	} else {
+		emailService = es
+	}
+
+	tweetDigestService := service.NewTweetDigestService(repo.NewTweetDigestRepo(infra.CryptoDB, db), telegramService, emailService, config.Digest)
+	tweetDigestHandler := handler.NewTweetDigestHandler(tweetDigestService)
+
+	if config.Digest.Enabled {
+		go tweetDigestService.Start(context.Background())
+	}
+
+	router.Get("/twitter-crypto/digests", tweetDigestHandler.GetDigests)
+	router.Get("/twitter-crypto/digests/latest", tweetDigestHandler.GetDigest)
+	router.Get("/twitter-crypto/digests/subscriptions", authMiddleware, tweetDigestHandler.GetSubscriptions)
+	router.Post("/twitter-crypto/digests/subscriptions", authMiddleware, tweetDigestHandler.Subscribe)
+	router.Delete("/twitter-crypto/digests/subscriptions/:period/:channel", authMiddleware, tweetDigestHandler.Unsubscribe)
+	router.Post("/crm/digests/build", authCRMMiddleware, tweetDigestHandler.BuildDigest)
+}
@@ -5,10 +5,11 @@ import (
 	"github.com/quantsmithapp/datastation-backend/infra"
 	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/handler"
 	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/repo"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
 	"github.com/quantsmithapp/datastation-backend/internal/core/service"
 )
 
-func bindTwitterCryptoAPI(router fiber.Router, authMiddleware fiber.Handler) {
+func bindTwitterCryptoAPI(router fiber.Router, authMiddleware fiber.Handler, tickerService port.TickerService) {
 	db, err := infra.GetPostgresConnection()
 	if err != nil {
 		panic(err)
@@ -16,7 +17,7 @@ func bindTwitterCryptoAPI(router fiber.Router, authMiddleware fiber.Handler) {
 
 	twitterCryptoRepo := repo.NewTwitterCryptoRepo(db)
 	authorTierRepo := repo.NewAuthorTierRepo(db)
-	twitterCryptoService := service.NewTwitterCryptoService(twitterCryptoRepo)
+	twitterCryptoService := service.NewTwitterCryptoService(twitterCryptoRepo, tickerService)
 	authorTierService := service.NewAuthorTierService(authorTierRepo)
 	twitterCryptoHandler := handler.NewTwitterCryptoHandler(twitterCryptoService, authorTierService)
 	// twitterCrypto := router.Group("/twitter-crypto", authMiddleware)
@@ -33,8 +34,10 @@ func bindTwitterCryptoAPI(router fiber.Router, authMiddleware fiber.Handler) {
 	twitterCrypto.Get("/tweets-with-sentiments", twitterCryptoHandler.GetTweetsWithSentiments)
 	twitterCrypto.Get("/tweets-with-sentiment-author-signal", twitterCryptoHandler.GetTweetsWithSentimentAuthorSignal)
 	twitterCrypto.Get("/tweets-with-sentiments-and-author", twitterCryptoHandler.GetTweetsWithSentimentsAndAuthor)
+	twitterCrypto.Get("/search", twitterCryptoHandler.SearchTweets)
 	twitterCrypto.Get("/tweets-summaries-1h", twitterCryptoHandler.GetSummaries)
 	twitterCrypto.Get("/bubble-chart-data", twitterCryptoHandler.GetBubbleSentiment)
+	twitterCrypto.Get("/sentiment-timeseries", twitterCryptoHandler.GetSentimentTimeSeries)
 	twitterCrypto.Get("/search-token-mention-symbols-by-authors", twitterCryptoHandler.SearchTokenMentionSymbolsByAuthors)
 	twitterCrypto.Get("/tiers", twitterCryptoHandler.GetAllTiers)
 	twitterCrypto.Get("/tweets-with-sentiments-and-tier", twitterCryptoHandler.GetTweetsWithSentimentsAndTier)
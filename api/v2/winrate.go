@@ -5,14 +5,22 @@ import (
 	"github.com/quantsmithapp/datastation-backend/infra"
 	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/handler"
 	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/repo"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
 	"github.com/quantsmithapp/datastation-backend/internal/core/service"
 )
 
 func bindWinRateAPI(router fiber.Router, authMiddleware fiber.Handler) {
 	winRateRepo := repo.NewWinRateRepo(infra.PostgresDB)
-	winRateService := service.NewWinRateService(winRateRepo)
+
+	// The calculator needs OHLCV from Timescale, it responds 503 when the connection is not initialized
+	var ohlcvRepo port.TimescaleRepo
+	if db, err := infra.GetTimescaleDBConnection(); err == nil {
+		ohlcvRepo = repo.NewTimescaleRepo(db)
+	}
+	winRateService := service.NewWinRateService(winRateRepo, ohlcvRepo)
 	winRateHandler := handler.NewWinRateHandler(winRateService)
 
 	winRateGroup := router.Group("/win-rate", authMiddleware)
 	winRateGroup.Get("/", winRateHandler.GetWinRate)
+	winRateGroup.Post("/calculate", winRateHandler.CalculateWinrate)
 }
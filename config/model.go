@@ -1,19 +1,26 @@
 package config
 
 type Config struct {
-	Application       ApplicationConfig `mapstructure:"app"`
-	StockDatabase     DatabaseConfig    `mapstructure:"stock_db"`
-	AppDatabase       DatabaseConfig    `mapstructure:"app_db"`
-	AnalyticDatabase  DatabaseConfig    `mapstructure:"analytic_db"`
-	TimescaleDatabase TimescaleConfig   `mapstructure:"timescale_db"`
-	PostgresDatabase  PostgresConfig    `mapstructure:"postgres_db"`
-	CryptoDatabase    PostgresConfig    `mapstructure:"crypto_db"`
-	Firebase          FirebaseConfig    `mapstructure:"firebase"`
-	PageShow          PageShowConfig    `mapstructure:"page_show"`
-	Jwt               JwtConfig         `mapstructure:"jwt"`
-	Telegram          TelegramConfig    `mapstructure:"telegram"`
-	Privy             PrivyConfig       `mapstructure:"privy"`
+	Application       ApplicationConfig      `mapstructure:"app"`
+	StockDatabase     DatabaseConfig         `mapstructure:"stock_db"`
+	AppDatabase       DatabaseConfig         `mapstructure:"app_db"`
+	AnalyticDatabase  DatabaseConfig         `mapstructure:"analytic_db"`
+	TimescaleDatabase TimescaleConfig        `mapstructure:"timescale_db"`
+	PostgresDatabase  PostgresConfig         `mapstructure:"postgres_db"`
+	CryptoDatabase    PostgresConfig         `mapstructure:"crypto_db"`
+	Firebase          FirebaseConfig         `mapstructure:"firebase"`
+	PageShow          PageShowConfig         `mapstructure:"page_show"`
+	Jwt               JwtConfig              `mapstructure:"jwt"`
+	Telegram          TelegramConfig         `mapstructure:"telegram"`
+	Privy             PrivyConfig            `mapstructure:"privy"`
 	CryptoTradingBot  CryptoTradingBotConfig `mapstructure:"crypto_trading_bot"`
+	TierEngine        TierEngineConfig       `mapstructure:"tier_engine"`
+	AuthorHealth      AuthorHealthConfig     `mapstructure:"author_health"`
+	Trending          TrendingConfig         `mapstructure:"trending"`
+	Consensus         ConsensusConfig        `mapstructure:"consensus"`
+	Digest            DigestConfig           `mapstructure:"digest"`
+	Email             EmailConfig            `mapstructure:"email"`
+	OHLCVQuality      OHLCVQualityConfig     `mapstructure:"ohlcv_quality"`
 }
 
 type ApplicationConfig struct {
@@ -81,3 +88,84 @@ type CryptoTradingBotConfig struct {
 	BaseURL string `mapstructure:"baseURL"`
 	Token   string `mapstructure:"token"`
 }
+
+type TierEngineConfig struct {
+	Enabled         bool `mapstructure:"enabled"`
+	IntervalMinutes int  `mapstructure:"interval_minutes"`
+	WindowDays      int  `mapstructure:"window_days"`
+	// WinratePeriod is the horizon signals of the window are evaluated at (1d to 30d), "overall" blends them with the
+	// weights of twitter_crypto_backtesting
+	WinratePeriod string           `mapstructure:"winrate_period"`
+	DefaultTier   string           `mapstructure:"default_tier"`
+	Rules         []TierRuleConfig `mapstructure:"rules"`
+}
+
+// TierRuleConfig is evaluated in order, the first rule an author satisfies decides the tier.
+type TierRuleConfig struct {
+	Tier           string  `mapstructure:"tier"`
+	MinWinrate     float64 `mapstructure:"min_winrate"`
+	MinSignals     int     `mapstructure:"min_signals"`
+	MaxDrawdown    float64 `mapstructure:"max_drawdown"`
+	MinConsistency float64 `mapstructure:"min_consistency"`
+}
+
+type AuthorHealthConfig struct {
+	Enabled         bool    `mapstructure:"enabled"`
+	IntervalMinutes int     `mapstructure:"interval_minutes"`
+	RecentDays      int     `mapstructure:"recent_days"`
+	BaselineDays    int     `mapstructure:"baseline_days"`
+	ZThreshold      float64 `mapstructure:"z_threshold"`
+}
+
+type TrendingConfig struct {
+	Enabled            bool    `mapstructure:"enabled"`
+	IntervalMinutes    int     `mapstructure:"interval_minutes"`
+	WindowHours        int     `mapstructure:"window_hours"`
+	BaselineWindows    int     `mapstructure:"baseline_windows"`
+	ZThreshold         float64 `mapstructure:"z_threshold"`
+	MinMentions        int     `mapstructure:"min_mentions"`
+	AlertCooldownHours int     `mapstructure:"alert_cooldown_hours"`
+	// TierWeights weights the mentions of an author by tier, authors of unlisted tiers weigh 1
+	TierWeights map[string]float64 `mapstructure:"tier_weights"`
+}
+
+type ConsensusConfig struct {
+	Enabled         bool `mapstructure:"enabled"`
+	IntervalMinutes int  `mapstructure:"interval_minutes"`
+	WindowHours     int  `mapstructure:"window_hours"`
+	// HalfLifeHours halves the weight of a signal every HalfLifeHours of age
+	HalfLifeHours float64 `mapstructure:"half_life_hours"`
+	// ConfidenceAuthors is the effective number of authors at which confidence is half of the agreement
+	ConfidenceAuthors float64 `mapstructure:"confidence_authors"`
+	// SnapshotTiers are snapshotted next to the consensus of all tracked authors
+	SnapshotTiers []string `mapstructure:"snapshot_tiers"`
+}
+
+type DigestConfig struct {
+	Enabled         bool `mapstructure:"enabled"`
+	IntervalMinutes int  `mapstructure:"interval_minutes"`
+	// TopLimit caps every ranked list of a digest
+	TopLimit int `mapstructure:"top_limit"`
+	// NewCallLookbackDays is how long an author must not have called a ticker for a call to count as new
+	NewCallLookbackDays int `mapstructure:"new_call_lookback_days"`
+}
+
+// EmailConfig is the SMTP relay emails are sent through, email delivery is disabled without a host
+type EmailConfig struct {
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+	From     string `mapstructure:"from"`
+}
+
+type OHLCVQualityConfig struct {
+	Enabled         bool `mapstructure:"enabled"`
+	IntervalMinutes int  `mapstructure:"interval_minutes"`
+	// LookbackDays is how far back each scan checks the candles
+	LookbackDays int `mapstructure:"lookback_days"`
+	// ZeroVolumeRun is how many candles in a row without volume are reported
+	ZeroVolumeRun int `mapstructure:"zero_volume_run"`
+	// Repair backfills the gaps of raw candle tables from TradingView
+	Repair bool `mapstructure:"repair"`
+}
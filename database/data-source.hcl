@@ -149,6 +149,180 @@ table "crypto_author_nav" {
     columns = [column.author_username, column.datetime, column.versioning]
   }
 }
+table "crypto_author_health" {
+  schema = schema.public
+  column "author_username" {
+    null = false
+    type = character_varying(100)
+  }
+  column "status" {
+    null = false
+    type = character_varying(20)
+  }
+  column "recent_positive_day_share" {
+    null    = false
+    type    = double_precision
+    comment = "Share of days with a positive NAV return in the recent window"
+  }
+  column "baseline_positive_day_share" {
+    null = false
+    type = double_precision
+  }
+  column "recent_roi" {
+    null = false
+    type = double_precision
+  }
+  column "baseline_roi" {
+    null = false
+    type = double_precision
+  }
+  column "recent_drawdown" {
+    null = false
+    type = double_precision
+  }
+  column "baseline_drawdown" {
+    null = false
+    type = double_precision
+  }
+  column "positive_day_z_score" {
+    null = false
+    type = double_precision
+  }
+  column "return_t_stat" {
+    null = false
+    type = double_precision
+  }
+  column "drawdown_z_score" {
+    null    = false
+    type    = double_precision
+    default = 0
+  }
+  column "evaluated_at" {
+    null    = false
+    type    = timestamp
+    default = sql("CURRENT_TIMESTAMP")
+  }
+  column "status_changed_at" {
+    null    = false
+    type    = timestamp
+    default = sql("CURRENT_TIMESTAMP")
+  }
+  column "alerted_at" {
+    null    = true
+    type    = timestamp
+    comment = "When subscribers were alerted of the current degraded status, NULL until every alert was sent"
+  }
+  primary_key {
+    columns = [column.author_username]
+  }
+}
+table "crypto_author_health_alert" {
+  schema  = schema.public
+  comment = "Degradation alerts sent to each subscriber, so a failed alert is retried without alerting the others again"
+  column "author_username" {
+    null = false
+    type = character_varying(100)
+  }
+  column "crypto_user_id" {
+    null = false
+    type = uuid
+  }
+  column "alerted_at" {
+    null = false
+    type = timestamp
+  }
+  primary_key {
+    columns = [column.author_username, column.crypto_user_id]
+  }
+}
+table "crypto_author_health_preference" {
+  schema = schema.public
+  column "crypto_user_id" {
+    null = false
+    type = uuid
+  }
+  column "auto_pause" {
+    null    = false
+    type    = boolean
+    default = false
+  }
+  column "updated_at" {
+    null    = false
+    type    = timestamp
+    default = sql("CURRENT_TIMESTAMP")
+  }
+  primary_key {
+    columns = [column.crypto_user_id]
+  }
+}
+table "crypto_author_tier_proposal" {
+  schema = schema.public
+  column "id" {
+    null    = false
+    type    = uuid
+    default = sql("gen_random_uuid()")
+  }
+  column "author_username" {
+    null = false
+    type = character_varying(100)
+  }
+  column "current_tier" {
+    null = true
+    type = character_varying(20)
+  }
+  column "proposed_tier" {
+    null = false
+    type = character_varying(20)
+  }
+  column "winrate" {
+    null = false
+    type = double_precision
+  }
+  column "signal_count" {
+    null = false
+    type = integer
+  }
+  column "max_drawdown" {
+    null = false
+    type = double_precision
+  }
+  column "consistency" {
+    null = false
+    type = double_precision
+  }
+  column "status" {
+    null    = false
+    type    = character_varying(20)
+    default = "pending"
+  }
+  column "reviewed_by" {
+    null = true
+    type = character_varying(255)
+  }
+  column "reviewed_at" {
+    null = true
+    type = timestamp
+  }
+  column "created_at" {
+    null    = false
+    type    = timestamp
+    default = sql("CURRENT_TIMESTAMP")
+  }
+  column "updated_at" {
+    null    = false
+    type    = timestamp
+    default = sql("CURRENT_TIMESTAMP")
+  }
+  primary_key {
+    columns = [column.id]
+  }
+  index "idx_crypto_author_tier_proposal_status" {
+    columns = [column.status, column.created_at]
+  }
+  index "idx_crypto_author_tier_proposal_author" {
+    columns = [column.author_username]
+  }
+}
 table "crypto_bot_wallet_history" {
   schema = schema.public
   column "id" {
@@ -276,6 +450,37 @@ table "crypto_copytrade_wallet" {
     columns = [column.wallet_address, column.private_key]
   }
 }
+table "crypto_copytrade_authors_paused" {
+  schema = schema.public
+  column "id" {
+    null    = false
+    type    = uuid
+    default = sql("gen_random_uuid()")
+  }
+  column "crypto_user_wallet_id_privy" {
+    null = false
+    type = uuid
+  }
+  column "author_username" {
+    null = false
+    type = character_varying(255)
+  }
+  column "reason" {
+    null = true
+    type = character_varying(255)
+  }
+  column "paused_at" {
+    null    = false
+    type    = timestamp
+    default = sql("CURRENT_TIMESTAMP")
+  }
+  primary_key {
+    columns = [column.id]
+  }
+  unique "uniq_crypto_copytrade_authors_paused" {
+    columns = [column.crypto_user_wallet_id_privy, column.author_username]
+  }
+}
 table "crypto_copytrade_authors_privy" {
   schema = schema.public
   column "id" {
@@ -858,6 +1063,93 @@ table "crypto_refferal_score" {
     columns = [column.crypto_user_id, column.date]
   }
 }
+table "crypto_ticker_consensus_snapshot" {
+  schema = schema.public
+  column "id" {
+    null    = false
+    type    = uuid
+    default = sql("gen_random_uuid()")
+  }
+  column "ticker" {
+    null = false
+    type = character_varying(32)
+  }
+  column "tier" {
+    null    = false
+    type    = character_varying(20)
+    default = ""
+  }
+  column "window_hours" {
+    null = false
+    type = integer
+  }
+  column "score" {
+    null = false
+    type = double_precision
+  }
+  column "confidence" {
+    null = false
+    type = double_precision
+  }
+  column "long_weight" {
+    null = false
+    type = double_precision
+  }
+  column "short_weight" {
+    null = false
+    type = double_precision
+  }
+  column "long_authors" {
+    null = false
+    type = integer
+  }
+  column "short_authors" {
+    null = false
+    type = integer
+  }
+  column "created_at" {
+    null    = false
+    type    = timestamp
+    default = sql("CURRENT_TIMESTAMP")
+  }
+  primary_key {
+    columns = [column.id]
+  }
+  index "idx_crypto_ticker_consensus_snapshot_ticker" {
+    columns = [column.ticker, column.tier, column.window_hours, column.created_at]
+  }
+}
+table "crypto_ticker_watchlist" {
+  schema = schema.public
+  column "crypto_user_id" {
+    null = false
+    type = uuid
+  }
+  column "ticker" {
+    null = false
+    type = character_varying(32)
+  }
+  column "trending_alert" {
+    null    = false
+    type    = boolean
+    default = false
+  }
+  column "last_alerted_at" {
+    null = true
+    type = timestamp
+  }
+  column "created_at" {
+    null    = false
+    type    = timestamp
+    default = sql("CURRENT_TIMESTAMP")
+  }
+  primary_key {
+    columns = [column.crypto_user_id, column.ticker]
+  }
+  index "idx_crypto_ticker_watchlist_ticker" {
+    columns = [column.ticker]
+  }
+}
 table "crypto_trading_bot" {
   schema = schema.public
   column "id" {
@@ -926,6 +1218,75 @@ table "crypto_trading_bot" {
     columns = [column.bot_name, column.created_at]
   }
 }
+table "crypto_tweet_digest" {
+  schema = schema.public
+  column "id" {
+    null    = false
+    type    = uuid
+    default = sql("gen_random_uuid()")
+  }
+  column "period" {
+    null = false
+    type = character_varying(10)
+  }
+  column "period_start" {
+    null = false
+    type = timestamp
+  }
+  column "period_end" {
+    null = false
+    type = timestamp
+  }
+  column "content" {
+    null = false
+    type = jsonb
+  }
+  column "created_at" {
+    null    = false
+    type    = timestamp
+    default = sql("CURRENT_TIMESTAMP")
+  }
+  primary_key {
+    columns = [column.id]
+  }
+  unique "uniq_crypto_tweet_digest_period" {
+    columns = [column.period, column.period_start]
+  }
+}
+table "crypto_tweet_digest_subscription" {
+  schema = schema.public
+  column "crypto_user_id" {
+    null = false
+    type = uuid
+  }
+  column "period" {
+    null = false
+    type = character_varying(10)
+  }
+  column "channel" {
+    null = false
+    type = character_varying(10)
+  }
+  column "last_sent_period_start" {
+    null = true
+    type = timestamp
+  }
+  column "last_sent_at" {
+    null = true
+    type = timestamp
+  }
+  column "created_at" {
+    null    = false
+    type    = timestamp
+    default = sql("CURRENT_TIMESTAMP")
+  }
+  primary_key {
+    columns = [column.crypto_user_id, column.period, column.channel]
+  }
+  index "idx_crypto_tweet_digest_subscription_period" {
+    columns = [column.period]
+  }
+}
 table "crypto_user" {
   schema = schema.public
   column "uuid" {
@@ -0,0 +1,8 @@
+-- Full-text search over tweet text (postgres_db, twitter_crypto_* tables).
+-- The generated column keeps the tsvector in sync with text without application writes.
+ALTER TABLE public.twitter_crypto_tweets_foxhole
+    ADD COLUMN IF NOT EXISTS text_search tsvector
+    GENERATED ALWAYS AS (to_tsvector('english', COALESCE(text, ''))) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_twitter_crypto_tweets_foxhole_text_search
+    ON public.twitter_crypto_tweets_foxhole USING GIN (text_search);
@@ -0,0 +1,73 @@
+-- Canonical crypto assets and the symbols that resolve to them (postgres_db, next to the twitter_crypto_* tables so
+-- queries can resolve tickers in SQL). Aliases are stored upper-cased: pair symbols (BTCUSDT, BTC-PERP), alternative
+-- symbols (XBT), names (BITCOIN) and exchange specific symbols (1000PEPE).
+CREATE TABLE IF NOT EXISTS public.crypto_asset (
+    id         varchar(32)  NOT NULL PRIMARY KEY,
+    name       varchar(128) NOT NULL DEFAULT '',
+    created_at timestamp    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp    NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS public.crypto_asset_alias (
+    alias      varchar(64) NOT NULL PRIMARY KEY,
+    asset_id   varchar(32) NOT NULL REFERENCES public.crypto_asset (id) ON DELETE CASCADE,
+    kind       varchar(20) NOT NULL DEFAULT 'symbol',
+    exchange   varchar(32) NOT NULL DEFAULT '',
+    created_at timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_crypto_asset_alias_asset_id ON public.crypto_asset_alias (asset_id);
+
+-- canonical_ticker mirrors TickerService.Resolve: strip cashtag and list punctuation and an exchange prefix, resolve
+-- an alias or asset ID, otherwise strip one quote suffix (BTCUSDT, BTC-PERP) and resolve again. Unknown tickers come
+-- back cleaned. Keep both in step.
+CREATE OR REPLACE FUNCTION public.canonical_ticker(raw text) RETURNS text
+    LANGUAGE sql STABLE AS
+$$
+    WITH cleaned AS (
+        SELECT regexp_replace(UPPER(btrim(raw, ' $[]''"')), '^[A-Z0-9]+:', '') AS symbol
+    ),
+    base AS (
+        SELECT regexp_replace(symbol, '^(.{2,}?)[-_/]?(USDT|USDC|BUSD|USD|PERP)$', '\1') AS symbol FROM cleaned
+    )
+    SELECT COALESCE(
+        (SELECT a.asset_id FROM public.crypto_asset_alias a, cleaned c WHERE a.alias = c.symbol),
+        (SELECT s.id FROM public.crypto_asset s, cleaned c WHERE s.id = c.symbol),
+        (SELECT a.asset_id FROM public.crypto_asset_alias a, base b WHERE a.alias = b.symbol),
+        (SELECT symbol FROM base)
+    )
+$$;
+
+INSERT INTO public.crypto_asset (id, name) VALUES
+    ('BTC', 'Bitcoin'),
+    ('ETH', 'Ethereum'),
+    ('SOL', 'Solana'),
+    ('XRP', 'XRP'),
+    ('BNB', 'BNB'),
+    ('DOGE', 'Dogecoin'),
+    ('ADA', 'Cardano'),
+    ('PEPE', 'Pepe'),
+    ('SHIB', 'Shiba Inu'),
+    ('BONK', 'Bonk'),
+    ('USDT', 'Tether'),
+    ('USDC', 'USDC'),
+    ('BUSD', 'BUSD'),
+    ('TUSD', 'TrueUSD'),
+    ('FDUSD', 'First Digital USD'),
+    ('DAI', 'Dai')
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO public.crypto_asset_alias (alias, asset_id, kind, exchange) VALUES
+    ('XBT', 'BTC', 'symbol', ''),
+    ('XBTUSD', 'BTC', 'exchange', 'bitmex'),
+    ('BITCOIN', 'BTC', 'name', ''),
+    ('ETHEREUM', 'ETH', 'name', ''),
+    ('ETHER', 'ETH', 'name', ''),
+    ('SOLANA', 'SOL', 'name', ''),
+    ('RIPPLE', 'XRP', 'name', ''),
+    ('DOGECOIN', 'DOGE', 'name', ''),
+    ('CARDANO', 'ADA', 'name', ''),
+    ('1000PEPE', 'PEPE', 'exchange', 'binance'),
+    ('1000SHIB', 'SHIB', 'exchange', 'binance'),
+    ('1000BONK', 'BONK', 'exchange', 'binance')
+ON CONFLICT (alias) DO NOTHING;
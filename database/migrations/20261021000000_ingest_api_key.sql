@@ -0,0 +1,18 @@
+-- Service-to-service ingestion (postgres_db, next to the twitter_crypto_* tables it writes). Only the SHA-256 hash of
+-- a key is stored, key_prefix lets operators tell keys apart.
+CREATE TABLE IF NOT EXISTS public.ingest_api_key (
+    id           uuid         NOT NULL DEFAULT gen_random_uuid() PRIMARY KEY,
+    name         varchar(128) NOT NULL,
+    key_prefix   varchar(16)  NOT NULL,
+    key_hash     char(64)     NOT NULL UNIQUE,
+    scopes       text[]       NOT NULL DEFAULT '{}',
+    created_by   varchar(128) NOT NULL DEFAULT '',
+    created_at   timestamp    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_used_at timestamp,
+    revoked_at   timestamp
+);
+
+-- Ingestion upserts tweets and sentiments by tweet ID and replaces the signals of a tweet
+CREATE UNIQUE INDEX IF NOT EXISTS idx_twitter_crypto_tweets_foxhole_id ON public.twitter_crypto_tweets_foxhole (id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_twitter_crypto_sentiment_id ON public.twitter_crypto_sentiment (id);
+CREATE INDEX IF NOT EXISTS idx_twitter_crypto_signal_tweet_id ON public.twitter_crypto_signal (tweet_id);
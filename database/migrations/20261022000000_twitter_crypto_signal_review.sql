@@ -0,0 +1,65 @@
+-- Review of LLM signal extractions (postgres_db, next to twitter_crypto_signal). Signals have no ID of their own, a
+-- signal is identified by tweet_id and ticker as stored, so reviews survive a re-extraction of the same ticker.
+CREATE TABLE IF NOT EXISTS public.twitter_crypto_signal_flag (
+    tweet_id       varchar(64)  NOT NULL,
+    ticker         varchar(64)  NOT NULL,
+    crypto_user_id varchar(128) NOT NULL,
+    reason         varchar(32)  NOT NULL,
+    comment        text         NOT NULL DEFAULT '',
+    created_at     timestamp    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (tweet_id, ticker, crypto_user_id)
+);
+
+-- One row per flagged or reviewed signal: pending until a reviewer corrects, voids or confirms it
+CREATE TABLE IF NOT EXISTS public.twitter_crypto_signal_review (
+    tweet_id            varchar(64)  NOT NULL,
+    ticker              varchar(64)  NOT NULL,
+    status              varchar(16)  NOT NULL DEFAULT 'pending',
+    corrected_ticker    varchar(64),
+    corrected_action    varchar(16),
+    corrected_sentiment varchar(16),
+    note                text         NOT NULL DEFAULT '',
+    reviewed_by         varchar(128),
+    reviewed_at         timestamp,
+    created_at          timestamp    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at          timestamp    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (tweet_id, ticker)
+);
+
+CREATE INDEX IF NOT EXISTS idx_twitter_crypto_signal_review_status
+    ON public.twitter_crypto_signal_review (status, updated_at);
+
+CREATE TABLE IF NOT EXISTS public.twitter_crypto_signal_review_audit (
+    id                  bigserial    PRIMARY KEY,
+    tweet_id            varchar(64)  NOT NULL,
+    ticker              varchar(64)  NOT NULL,
+    event               varchar(16)  NOT NULL,
+    actor               varchar(128) NOT NULL DEFAULT '',
+    reason              varchar(32)  NOT NULL DEFAULT '',
+    note                text         NOT NULL DEFAULT '',
+    corrected_ticker    varchar(64),
+    corrected_action    varchar(16),
+    corrected_sentiment varchar(16),
+    created_at          timestamp    NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_twitter_crypto_signal_review_audit_signal
+    ON public.twitter_crypto_signal_review_audit (tweet_id, ticker, created_at);
+
+-- Signals with reviews applied: corrected fields override the extraction and voided signals are left out. Winrate and
+-- the NAV backtest read signals through this view so reviewer corrections feed back into them.
+CREATE OR REPLACE VIEW public.twitter_crypto_signal_reviewed AS
+SELECT
+    s.tweet_id,
+    s.content,
+    CASE WHEN r.status = 'corrected' THEN COALESCE(r.corrected_ticker, s.ticker) ELSE s.ticker END AS ticker,
+    CASE WHEN r.status = 'corrected' THEN COALESCE(r.corrected_action, s.action) ELSE s.action END AS action,
+    s.score,
+    CASE WHEN r.status = 'corrected' THEN COALESCE(r.corrected_sentiment, s.sentiment) ELSE s.sentiment END AS sentiment,
+    s.prompt_version,
+    s.created_at,
+    s.updated_at,
+    COALESCE(r.status, '') AS review_status
+FROM public.twitter_crypto_signal s
+LEFT JOIN public.twitter_crypto_signal_review r ON r.tweet_id = s.tweet_id AND r.ticker = s.ticker
+WHERE r.status IS DISTINCT FROM 'voided';
@@ -0,0 +1,26 @@
+-- OHLCV data quality reports (timescale_db, next to the binance_* and forex_* candle tables). One row per scan, its
+-- findings are deleted with it once the scan is older than 30 days.
+CREATE TABLE IF NOT EXISTS public.ohlcv_quality_run (
+    run_at      timestamptz NOT NULL PRIMARY KEY,
+    finished_at timestamptz NOT NULL,
+    tables      integer     NOT NULL DEFAULT 0,
+    findings    integer     NOT NULL DEFAULT 0,
+    repaired    integer     NOT NULL DEFAULT 0
+);
+
+-- kind: gap, duplicate, non_monotonic, zero_volume, ohlc_inconsistent or stale. ticker is empty for a stale table.
+CREATE TABLE IF NOT EXISTS public.ohlcv_quality_finding (
+    id         bigserial    PRIMARY KEY,
+    run_at     timestamptz  NOT NULL REFERENCES public.ohlcv_quality_run (run_at) ON DELETE CASCADE,
+    table_name varchar(128) NOT NULL,
+    ticker     varchar(64)  NOT NULL DEFAULT '',
+    kind       varchar(32)  NOT NULL,
+    start_time timestamptz  NOT NULL,
+    end_time   timestamptz  NOT NULL,
+    candles    integer      NOT NULL DEFAULT 0,
+    repaired   integer      NOT NULL DEFAULT 0,
+    detail     text         NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_ohlcv_quality_finding_run
+    ON public.ohlcv_quality_finding (run_at, table_name, ticker, kind);
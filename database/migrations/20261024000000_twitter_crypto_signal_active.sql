@@ -0,0 +1,94 @@
+-- Active prompt version of signal extraction (postgres_db, next to twitter_crypto_signal). Ingestion keeps the signals
+-- of every prompt version side by side for comparison, readers go through twitter_crypto_signal_active so each tweet
+-- counts once. At most one row.
+CREATE TABLE IF NOT EXISTS public.twitter_crypto_prompt_version_active (
+    singleton      boolean      NOT NULL DEFAULT true PRIMARY KEY CHECK (singleton),
+    prompt_version varchar(64)  NOT NULL,
+    updated_by     varchar(128) NOT NULL DEFAULT '',
+    updated_at     timestamp    NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_twitter_crypto_signal_tweet_id_prompt_version
+    ON public.twitter_crypto_signal (tweet_id, prompt_version, created_at);
+
+-- The signals of one prompt version per tweet: the active version when it extracted the tweet, otherwise the version
+-- that extracted it last. Tweets stored before prompt versions existed have a NULL version, read as ''.
+CREATE OR REPLACE VIEW public.twitter_crypto_signal_active AS
+SELECT
+    s.tweet_id,
+    s.content,
+    s.ticker,
+    s.action,
+    s.score,
+    s.sentiment,
+    s.prompt_version,
+    s.created_at,
+    s.updated_at
+FROM public.twitter_crypto_signal s
+WHERE COALESCE(s.prompt_version, '') = (
+    SELECT COALESCE(v.prompt_version, '')
+    FROM public.twitter_crypto_signal v
+    WHERE v.tweet_id = s.tweet_id
+    ORDER BY
+        COALESCE(v.prompt_version, '') = (SELECT a.prompt_version FROM public.twitter_crypto_prompt_version_active a) DESC NULLS LAST,
+        v.created_at DESC
+    LIMIT 1
+);
+
+-- Reviews apply to the signal of one prompt version, a re-extraction by another version is reviewed separately
+ALTER TABLE public.twitter_crypto_signal_flag ADD COLUMN IF NOT EXISTS prompt_version varchar(64) NOT NULL DEFAULT '';
+ALTER TABLE public.twitter_crypto_signal_review ADD COLUMN IF NOT EXISTS prompt_version varchar(64) NOT NULL DEFAULT '';
+ALTER TABLE public.twitter_crypto_signal_review_audit ADD COLUMN IF NOT EXISTS prompt_version varchar(64) NOT NULL DEFAULT '';
+
+-- Reviews made before the key change belong to the version the signal was last extracted by
+UPDATE public.twitter_crypto_signal_flag f
+SET prompt_version = (
+    SELECT COALESCE(s.prompt_version, '') FROM public.twitter_crypto_signal s
+    WHERE s.tweet_id = f.tweet_id AND s.ticker = f.ticker
+    ORDER BY s.created_at DESC
+    LIMIT 1
+)
+WHERE f.prompt_version = ''
+AND EXISTS (SELECT 1 FROM public.twitter_crypto_signal s WHERE s.tweet_id = f.tweet_id AND s.ticker = f.ticker);
+
+UPDATE public.twitter_crypto_signal_review r
+SET prompt_version = (
+    SELECT COALESCE(s.prompt_version, '') FROM public.twitter_crypto_signal s
+    WHERE s.tweet_id = r.tweet_id AND s.ticker = r.ticker
+    ORDER BY s.created_at DESC
+    LIMIT 1
+)
+WHERE r.prompt_version = ''
+AND EXISTS (SELECT 1 FROM public.twitter_crypto_signal s WHERE s.tweet_id = r.tweet_id AND s.ticker = r.ticker);
+
+UPDATE public.twitter_crypto_signal_review_audit a
+SET prompt_version = r.prompt_version
+FROM public.twitter_crypto_signal_review r
+WHERE r.tweet_id = a.tweet_id AND r.ticker = a.ticker AND a.prompt_version = '';
+
+ALTER TABLE public.twitter_crypto_signal_flag DROP CONSTRAINT IF EXISTS twitter_crypto_signal_flag_pkey;
+ALTER TABLE public.twitter_crypto_signal_flag ADD PRIMARY KEY (tweet_id, ticker, prompt_version, crypto_user_id);
+ALTER TABLE public.twitter_crypto_signal_review DROP CONSTRAINT IF EXISTS twitter_crypto_signal_review_pkey;
+ALTER TABLE public.twitter_crypto_signal_review ADD PRIMARY KEY (tweet_id, ticker, prompt_version);
+
+DROP INDEX IF EXISTS public.idx_twitter_crypto_signal_review_audit_signal;
+CREATE INDEX IF NOT EXISTS idx_twitter_crypto_signal_review_audit_signal
+    ON public.twitter_crypto_signal_review_audit (tweet_id, ticker, prompt_version, created_at);
+
+-- Signals of the active prompt version with reviews applied
+CREATE OR REPLACE VIEW public.twitter_crypto_signal_reviewed AS
+SELECT
+    s.tweet_id,
+    s.content,
+    CASE WHEN r.status = 'corrected' THEN COALESCE(r.corrected_ticker, s.ticker) ELSE s.ticker END AS ticker,
+    CASE WHEN r.status = 'corrected' THEN COALESCE(r.corrected_action, s.action) ELSE s.action END AS action,
+    s.score,
+    CASE WHEN r.status = 'corrected' THEN COALESCE(r.corrected_sentiment, s.sentiment) ELSE s.sentiment END AS sentiment,
+    s.prompt_version,
+    s.created_at,
+    s.updated_at,
+    COALESCE(r.status, '') AS review_status
+FROM public.twitter_crypto_signal_active s
+LEFT JOIN public.twitter_crypto_signal_review r
+    ON r.tweet_id = s.tweet_id AND r.ticker = s.ticker AND r.prompt_version = COALESCE(s.prompt_version, '')
+WHERE r.status IS DISTINCT FROM 'voided';
@@ -0,0 +1,13 @@
+-- Stablecoins whose symbol ends in a quote currency. canonical_ticker strips one quote suffix from symbols that are no
+-- asset, so without a row PYUSD would resolve to PY and CRVUSD to CRV. TickerService also keeps symbols of the static
+-- ticker list whole, these rows make SQL agree with it.
+INSERT INTO public.crypto_asset (id, name) VALUES
+    ('PYUSD', 'PayPal USD'),
+    ('CRVUSD', 'crvUSD'),
+    ('LISUSD', 'Lista USD'),
+    ('DEUSD', 'Elixir deUSD'),
+    ('ALUSD', 'Alchemix USD'),
+    ('FXUSD', 'f(x) Protocol fxUSD'),
+    ('MKUSD', 'Prisma mkUSD'),
+    ('THUSD', 'Threshold USD')
+ON CONFLICT (id) DO NOTHING;
@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
+)
+
+type AuthorHealthHandler struct {
+	service port.AuthorHealthService
+}
+
+func NewAuthorHealthHandler(service port.AuthorHealthService) *AuthorHealthHandler {
+	return &AuthorHealthHandler{service: service}
+}
+
+// GetAuthorHealth godoc
+// @Summary      Get author health
+// @Description  Returns the latest health status of an author, or of all authors when author_username is omitted
+// @Tags         AuthorHealth
+// @Produce      json
+// @Param        author_username  query     string  false  "Author Username"
+// @Success      200              {array}   model.AuthorHealth
+// @Failure      500              {object}  map[string]string
+// @Router       /author-health [get]
+// @Security     BearerAuth
+func (h *AuthorHealthHandler) GetAuthorHealth(c *fiber.Ctx) error {
+	health, err := h.service.GetAuthorHealth(c.UserContext(), c.Query("author_username", ""))
+	if err != nil {
+		logger.Errorf("author health: failed to get health: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get author health"})
+	}
+	return c.JSON(health)
+}
+
+// Evaluate godoc
+// @Summary      Evaluate author health
+// @Description  Runs the author health watcher immediately, alerting and pausing subscribers of newly degraded authors
+// @Tags         CRM
+// @Produce      json
+// @Success      200  {array}   model.AuthorHealth
+// @Failure      500  {object}  ErrorResponse
+// @Router       /crm/author-health/evaluate [post]
+// @Security     BearerAuth
+func (h *AuthorHealthHandler) Evaluate(c *fiber.Ctx) error {
+	health, err := h.service.Evaluate(c.UserContext())
+	if err != nil {
+		logger.Errorf("author health: evaluation failed: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to evaluate author health"})
+	}
+	return c.JSON(health)
+}
+
+// GetPreference godoc
+// @Summary      Get author health preference
+// @Description  Returns whether subscriptions of the current user are paused automatically when an author degrades
+// @Tags         AuthorHealth
+// @Produce      json
+// @Success      200  {object}  model.AuthorHealthPreference
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /author-health/preference [get]
+// @Security     BearerAuth
+func (h *AuthorHealthHandler) GetPreference(c *fiber.Ctx) error {
+	uid, ok := c.Locals("uid").(string)
+	if !ok || uid == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	pref, err := h.service.GetPreference(c.UserContext(), uid)
+	if err != nil {
+		logger.Errorf("author health: failed to get preference for uid=%s: %v", uid, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get preference"})
+	}
+	return c.JSON(pref)
+}
+
+// UpdatePreference godoc
+// @Summary      Update author health preference
+// @Description  Opt in or out of automatically pausing subscriptions when an author degrades
+// @Tags         AuthorHealth
+// @Accept       json
+// @Produce      json
+// @Param        body  body      model.AuthorHealthPreference  true  "Preference"
+// @Success      200   {object}  model.AuthorHealthPreference
+// @Failure      400   {object}  map[string]string
+// @Failure      401   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /author-health/preference [post]
+// @Security     BearerAuth
+func (h *AuthorHealthHandler) UpdatePreference(c *fiber.Ctx) error {
+	uid, ok := c.Locals("uid").(string)
+	if !ok || uid == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	var req model.AuthorHealthPreference
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := h.service.UpdatePreference(c.UserContext(), uid, req.AutoPause); err != nil {
+		logger.Errorf("author health: failed to update preference for uid=%s: %v", uid, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to update preference"})
+	}
+	return c.JSON(req)
+}
+
+// GetPausedSubscriptions godoc
+// @Summary      List paused subscriptions
+// @Description  Returns the wallet subscriptions of the current user paused by the author health watcher
+// @Tags         AuthorHealth
+// @Produce      json
+// @Success      200  {array}   model.PausedSubscription
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /author-health/paused [get]
+// @Security     BearerAuth
+func (h *AuthorHealthHandler) GetPausedSubscriptions(c *fiber.Ctx) error {
+	uid, ok := c.Locals("uid").(string)
+	if !ok || uid == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	paused, err := h.service.GetPausedSubscriptions(c.UserContext(), uid)
+	if err != nil {
+		logger.Errorf("author health: failed to get paused subscriptions for uid=%s: %v", uid, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get paused subscriptions"})
+	}
+	return c.JSON(paused)
+}
+
+// ResumeSubscription godoc
+// @Summary      Resume a paused subscription
+// @Description  Subscribes the wallet to the author again after it was paused by the author health watcher
+// @Tags         AuthorHealth
+// @Accept       json
+// @Produce      json
+// @Param        body  body      model.ResumeSubscriptionRequest  true  "Paused subscription"
+// @Success      200   {object}  map[string]string
+// @Failure      400   {object}  map[string]string
+// @Failure      401   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /author-health/resume [post]
+// @Security     BearerAuth
+func (h *AuthorHealthHandler) ResumeSubscription(c *fiber.Ctx) error {
+	uid, ok := c.Locals("uid").(string)
+	if !ok || uid == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	var req model.ResumeSubscriptionRequest
+	if err := c.BodyParser(&req); err != nil || req.WalletID == "" || req.AuthorUsername == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "wallet_id and author_username are required"})
+	}
+
+	if err := h.service.ResumeSubscription(c.UserContext(), uid, req.WalletID, req.AuthorUsername); err != nil {
+		if errors.Is(err, model.ErrPausedSubscriptionNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+		}
+		logger.Errorf("author health: failed to resume wallet %s for uid=%s: %v", req.WalletID, uid, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to resume subscription"})
+	}
+	return c.JSON(fiber.Map{"message": "Subscription resumed"})
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
+)
+
+type AuthorNetworkHandler struct {
+	service port.AuthorNetworkService
+}
+
+func NewAuthorNetworkHandler(service port.AuthorNetworkService) *AuthorNetworkHandler {
+	return &AuthorNetworkHandler{service: service}
+}
+
+// GetSimilarAuthors godoc
+// @Summary      Get similar authors
+// @Description  Ranks the authors signaling the same tickers in the same direction as the given author. Authors that mostly take the opposite side have a negative similarity
+// @Tags         Author Network
+// @Produce      json
+// @Param        username     path      string  true   "Author username"
+// @Param        window_days  query     int     false  "Signals of the last window_days days"  default(30)
+// @Param        min_shared   query     int     false  "Minimum number of shared tickers"  default(3)
+// @Param        limit        query     int     false  "Maximum number of authors, at most 100"  default(20)
+// @Success      200          {object}  model.SimilarAuthorsResult
+// @Failure      400          {object}  map[string]string
+// @Failure      404          {object}  map[string]string
+// @Failure      500          {object}  map[string]string
+// @Router       /twitter-crypto/authors/{username}/similar [get]
+func (h *AuthorNetworkHandler) GetSimilarAuthors(c *fiber.Ctx) error {
+	result, err := h.service.GetSimilarAuthors(
+		c.UserContext(),
+		c.Params("username"),
+		c.QueryInt("window_days", 0),
+		c.QueryInt("min_shared", 0),
+		c.QueryInt("limit", 0),
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, model.ErrInvalidNetworkWindow):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		case errors.Is(err, model.ErrAuthorNotInNetwork):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+		}
+		logger.Errorf("author network: failed to get similar authors: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get similar authors"})
+	}
+	return c.JSON(result)
+}
+
+// GetClusters godoc
+// @Summary      Get author clusters
+// @Description  Groups the authors that signal the same tickers in the same direction
+// @Tags         Author Network
+// @Produce      json
+// @Param        window_days  query     int  false  "Signals of the last window_days days"  default(30)
+// @Param        min_shared   query     int  false  "Minimum number of shared tickers to link two authors"  default(3)
+// @Success      200          {object}  model.AuthorClusterResult
+// @Failure      400          {object}  map[string]string
+// @Failure      500          {object}  map[string]string
+// @Router       /twitter-crypto/authors/clusters [get]
+func (h *AuthorNetworkHandler) GetClusters(c *fiber.Ctx) error {
+	result, err := h.service.GetClusters(c.UserContext(), c.QueryInt("window_days", 0), c.QueryInt("min_shared", 0))
+	if err != nil {
+		if errors.Is(err, model.ErrInvalidNetworkWindow) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		logger.Errorf("author network: failed to get clusters: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get author clusters"})
+	}
+	return c.JSON(result)
+}
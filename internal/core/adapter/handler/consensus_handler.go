@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
+)
+
+type ConsensusHandler struct {
+	service port.ConsensusService
+}
+
+func NewConsensusHandler(service port.ConsensusService) *ConsensusHandler {
+	return &ConsensusHandler{service: service}
+}
+
+// GetConsensus godoc
+// @Summary      Get winrate-weighted ticker consensus
+// @Description  Aggregates the latest LONG/SHORT signal of each tracked author per ticker, weighted by winrate and recency
+// @Tags         Consensus
+// @Produce      json
+// @Param        ticker        query     string  false  "Ticker, e.g. BTC. Empty returns every ticker with a signal in the window"
+// @Param        window_hours  query     int     false  "Signals of the last window_hours hours"  default(24)
+// @Param        tier          query     string  false  "Only authors of this tier"
+// @Success      200           {array}   model.TickerConsensus
+// @Failure      400           {object}  map[string]string
+// @Failure      500           {object}  map[string]string
+// @Router       /twitter-crypto/consensus [get]
+func (h *ConsensusHandler) GetConsensus(c *fiber.Ctx) error {
+	consensus, err := h.service.GetConsensus(c.UserContext(), model.ConsensusQuery{
+		Ticker:      c.Query("ticker", ""),
+		WindowHours: c.QueryInt("window_hours", 0),
+		Tier:        c.Query("tier", ""),
+	})
+	if err != nil {
+		if errors.Is(err, model.ErrInvalidConsensusWindow) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		logger.Errorf("consensus: failed to get consensus: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get consensus"})
+	}
+	return c.JSON(consensus)
+}
+
+// GetConsensusHistory godoc
+// @Summary      Get ticker consensus history
+// @Description  Returns the stored consensus snapshots of a ticker for charting
+// @Tags         Consensus
+// @Produce      json
+// @Param        ticker        query     string  true   "Ticker, e.g. BTC"
+// @Param        window_hours  query     int     false  "Window the snapshots were computed over"  default(24)
+// @Param        tier          query     string  false  "Tier the snapshots were computed for, empty for all tracked authors"
+// @Param        from_date     query     string  false  "Start date (YYYY-MM-DD), defaults to 30 days ago"
+// @Param        to_date       query     string  false  "End date (YYYY-MM-DD), inclusive"
+// @Success      200           {array}   model.ConsensusSnapshot
+// @Failure      400           {object}  map[string]string
+// @Failure      500           {object}  map[string]string
+// @Router       /twitter-crypto/consensus/history [get]
+func (h *ConsensusHandler) GetConsensusHistory(c *fiber.Ctx) error {
+	// Default to the last 30 days
+	toDate := time.Now().UTC()
+	fromDate := toDate.AddDate(0, 0, -30)
+	if fromDateStr := c.Query("from_date", ""); fromDateStr != "" {
+		parsedFromDate, err := time.Parse("2006-01-02", fromDateStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid from_date format. Use YYYY-MM-DD",
+			})
+		}
+		fromDate = parsedFromDate
+	}
+	if toDateStr := c.Query("to_date", ""); toDateStr != "" {
+		parsedToDate, err := time.Parse("2006-01-02", toDateStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid to_date format. Use YYYY-MM-DD",
+			})
+		}
+		// Include the whole day
+		toDate = parsedToDate.Add(24 * time.Hour)
+	}
+
+	history, err := h.service.GetConsensusHistory(c.UserContext(), model.ConsensusQuery{
+		Ticker:      c.Query("ticker", ""),
+		WindowHours: c.QueryInt("window_hours", 0),
+		Tier:        c.Query("tier", ""),
+	}, fromDate, toDate)
+	if err != nil {
+		if errors.Is(err, model.ErrInvalidConsensusWindow) || errors.Is(err, model.ErrConsensusTickerRequired) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		logger.Errorf("consensus: failed to get consensus history: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get consensus history"})
+	}
+	return c.JSON(history)
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
+)
+
+type CorrelationHandler struct {
+	service port.CorrelationService
+}
+
+func NewCorrelationHandler(service port.CorrelationService) *CorrelationHandler {
+	return &CorrelationHandler{service: service}
+}
+
+// GetCorrelation godoc
+// @Summary      Get the correlation matrix of crypto pairs
+// @Description  Correlates the log returns of the pairs over the latest lookback candles, with the beta and the rolling correlation of each pair to BTCUSDT. Pass tickers, or top for the USDT pairs of the top assets by market cap. Pairs without candles are listed in missing
+// @Tags         Indicators
+// @Produce      json
+// @Param        tickers   query     string  false  "Comma separated pairs or assets, resolved to their USDT pairs, e.g. BTCUSDT,ETH,SOL"
+// @Param        top       query     int     false  "Number of top assets by market cap when tickers is omitted, at most 50"
+// @Param        tf        query     string  false  "Timeframe, e.g. 1h, 4h or 1d"  default(1d)
+// @Param        lookback  query     int     false  "Number of returns, 10 to 1000"  default(90)
+// @Param        window    query     int     false  "Number of returns of the rolling correlation and the minimum in common, at least 5"  default(30)
+// @Success      200       {object}  model.CorrelationResult
+// @Failure      400       {object}  map[string]string
+// @Failure      401       {object}  map[string]string
+// @Failure      500       {object}  map[string]string
+// @Failure      503       {object}  map[string]string
+// @Router       /timescale/crypto/correlation [get]
+// @Security     BearerAuth
+func (h *CorrelationHandler) GetCorrelation(c *fiber.Ctx) error {
+	req := model.CorrelationRequest{
+		Top:       c.QueryInt("top", 0),
+		TimeFrame: c.Query("tf", ""),
+		Lookback:  c.QueryInt("lookback", 0),
+		Window:    c.QueryInt("window", 0),
+	}
+	if tickers := c.Query("tickers", ""); tickers != "" {
+		req.Tickers = strings.Split(tickers, ",")
+	}
+
+	result, err := h.service.GetCorrelation(c.UserContext(), req)
+	if err != nil {
+		return correlationError(c, err)
+	}
+	return c.JSON(result)
+}
+
+func correlationError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, model.ErrOHLCVUnavailable):
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, model.ErrCorrelationTickersRequired),
+		errors.Is(err, model.ErrTooManyCorrelationTickers),
+		errors.Is(err, model.ErrInvalidCorrelationTimeFrame),
+		errors.Is(err, model.ErrInvalidCorrelationLookback),
+		errors.Is(err, model.ErrInvalidCorrelationWindow),
+		errors.Is(err, model.ErrUnsupportedTimeFrame):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	logger.Errorf("correlation: %v", err)
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to compute correlation"})
+}
@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
+)
+
+type DivergenceHandler struct {
+	service port.DivergenceService
+}
+
+func NewDivergenceHandler(service port.DivergenceService) *DivergenceHandler {
+	return &DivergenceHandler{service: service}
+}
+
+// GetTickerDivergence godoc
+// @Summary      Get sentiment-price divergences of a ticker
+// @Description  Aligns the tweet sentiment of a ticker with its price and returns the divergences with their strength and forward return
+// @Tags         Divergence
+// @Produce      json
+// @Param        ticker     query     string  true   "Ticker, e.g. BTC"
+// @Param        bucket     query     string  false  "Bucket: 1h, 4h or 1d"  default(1h)
+// @Param        from_date  query     string  false  "Start date (YYYY-MM-DD), defaults to 7 days ago"
+// @Param        to_date    query     string  false  "End date (YYYY-MM-DD), inclusive"
+// @Param        authors    query     string  false  "Comma separated authors"
+// @Param        lookback   query     int     false  "Buckets the price and sentiment trends are measured over"  default(12)
+// @Param        forward    query     int     false  "Buckets the forward return is measured over"  default(12)
+// @Success      200        {object}  model.TickerDivergence
+// @Failure      400        {object}  map[string]string
+// @Failure      500        {object}  map[string]string
+// @Failure      503        {object}  map[string]string
+// @Router       /twitter-crypto/divergence [get]
+func (h *DivergenceHandler) GetTickerDivergence(c *fiber.Ctx) error {
+	req, err := parseDivergenceRequest(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	req.Ticker = c.Query("ticker", "")
+
+	divergence, err := h.service.GetTickerDivergence(c.UserContext(), req)
+	if err != nil {
+		return divergenceError(c, err)
+	}
+	return c.JSON(divergence)
+}
+
+// ScanDivergences godoc
+// @Summary      Scan tickers for sentiment-price divergences
+// @Description  Runs the divergence detection over the most mentioned tickers of the range and returns the events, strongest first
+// @Tags         Divergence
+// @Produce      json
+// @Param        bucket       query     string  false  "Bucket: 1h, 4h or 1d"  default(1h)
+// @Param        from_date    query     string  false  "Start date (YYYY-MM-DD), defaults to 7 days ago"
+// @Param        to_date      query     string  false  "End date (YYYY-MM-DD), inclusive"
+// @Param        authors      query     string  false  "Comma separated authors"
+// @Param        lookback     query     int     false  "Buckets the price and sentiment trends are measured over"  default(12)
+// @Param        forward      query     int     false  "Buckets the forward return is measured over"  default(12)
+// @Param        tickers      query     int     false  "Number of most mentioned tickers to scan"  default(10)
+// @Param        active_only  query     bool    false  "Only events that ended within the last lookback"  default(true)
+// @Success      200          {array}   model.DivergenceEvent
+// @Failure      400          {object}  map[string]string
+// @Failure      500          {object}  map[string]string
+// @Failure      503          {object}  map[string]string
+// @Router       /twitter-crypto/divergence/scan [get]
+func (h *DivergenceHandler) ScanDivergences(c *fiber.Ctx) error {
+	req, err := parseDivergenceRequest(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	events, err := h.service.Scan(c.UserContext(), req, c.QueryInt("tickers", 0), c.QueryBool("active_only", true))
+	if err != nil {
+		return divergenceError(c, err)
+	}
+	return c.JSON(events)
+}
+
+func parseDivergenceRequest(c *fiber.Ctx) (model.DivergenceRequest, error) {
+	req := model.DivergenceRequest{
+		Bucket:   c.Query("bucket", "1h"),
+		Lookback: c.QueryInt("lookback", 0),
+		Forward:  c.QueryInt("forward", 0),
+	}
+
+	// Default to the last 7 days
+	req.To = time.Now().UTC()
+	req.From = req.To.AddDate(0, 0, -7)
+	if fromDateStr := c.Query("from_date", ""); fromDateStr != "" {
+		parsedFromDate, err := time.Parse("2006-01-02", fromDateStr)
+		if err != nil {
+			return req, errors.New("Invalid from_date format. Use YYYY-MM-DD")
+		}
+		req.From = parsedFromDate
+	}
+	if toDateStr := c.Query("to_date", ""); toDateStr != "" {
+		parsedToDate, err := time.Parse("2006-01-02", toDateStr)
+		if err != nil {
+			return req, errors.New("Invalid to_date format. Use YYYY-MM-DD")
+		}
+		// Include the whole day
+		req.To = parsedToDate.Add(24 * time.Hour)
+	}
+
+	if authorsParam := c.Query("authors", ""); authorsParam != "" {
+		for _, author := range strings.Split(authorsParam, ",") {
+			if author = strings.TrimSpace(author); author != "" {
+				req.Authors = append(req.Authors, author)
+			}
+		}
+	}
+	return req, nil
+}
+
+func divergenceError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, model.ErrOHLCVUnavailable):
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, model.ErrDivergenceTickerRequired),
+		errors.Is(err, model.ErrInvalidDivergenceWindow),
+		errors.Is(err, model.ErrInvalidSentimentBucket),
+		errors.Is(err, model.ErrSentimentRangeTooLarge),
+		errors.Is(err, model.ErrSentimentInvalidRange):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	logger.Errorf("divergence: %v", err)
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to detect divergences"})
+}
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
+)
+
+type IndicatorHandler struct {
+	service port.IndicatorService
+}
+
+func NewIndicatorHandler(service port.IndicatorService) *IndicatorHandler {
+	return &IndicatorHandler{service: service}
+}
+
+// GetCryptoIndicators godoc
+// @Summary      Get technical indicators of a crypto pair
+// @Description  Computes indicators over the OHLCV of a pair, warmed up on the candles before start_date. Each indicator is name:params with omitted parameters defaulted: sma:20, ema:20, rsi:14, macd:12:26:9, bb:20:2, atr:14, vwap:0 (0 resets daily), stoch:14:3:3 and volatility:20 (annualized)
+// @Tags         Indicators
+// @Produce      json
+// @Param        ticker      query     string  true   "Pair, e.g. BTCUSDT"
+// @Param        tf          query     string  true   "Timeframe, e.g. 15m, 4h, 1d or 1w"
+// @Param        indicators  query     string  true   "Comma separated indicators, e.g. ema:50,rsi,macd:12:26:9"
+// @Param        start_date  query     string  true   "Start date (YYYY-MM-DD)"
+// @Param        end_date    query     string  false  "End date (RFC3339 or YYYY-MM-DD), defaults to the latest candle"
+// @Param        timezone    query     string  false  "IANA timezone dates, candles and daily VWAP are aligned to"  default(UTC)
+// @Success      200         {object}  model.IndicatorResult
+// @Failure      400         {object}  map[string]string
+// @Failure      401         {object}  map[string]string
+// @Failure      500         {object}  map[string]string
+// @Failure      503         {object}  map[string]string
+// @Router       /timescale/crypto/indicators [get]
+// @Security     BearerAuth
+func (h *IndicatorHandler) GetCryptoIndicators(c *fiber.Ctx) error {
+	return h.getIndicators(c, model.IndicatorMarketCrypto)
+}
+
+// GetForexIndicators godoc
+// @Summary      Get technical indicators of a forex pair
+// @Description  Same as the crypto indicators over forex OHLCV
+// @Tags         Indicators
+// @Produce      json
+// @Param        ticker      query     string  true   "Pair, e.g. EURUSD"
+// @Param        tf          query     string  true   "Timeframe, e.g. 15m, 4h, 1d or 1w"
+// @Param        indicators  query     string  true   "Comma separated indicators, e.g. ema:50,rsi,macd:12:26:9"
+// @Param        start_date  query     string  true   "Start date (YYYY-MM-DD)"
+// @Param        end_date    query     string  false  "End date (RFC3339 or YYYY-MM-DD), defaults to the latest candle"
+// @Param        timezone    query     string  false  "IANA timezone dates, candles and daily VWAP are aligned to"  default(UTC)
+// @Success      200         {object}  model.IndicatorResult
+// @Failure      400         {object}  map[string]string
+// @Failure      401         {object}  map[string]string
+// @Failure      500         {object}  map[string]string
+// @Failure      503         {object}  map[string]string
+// @Router       /timescale/forex/indicators [get]
+// @Security     BearerAuth
+func (h *IndicatorHandler) GetForexIndicators(c *fiber.Ctx) error {
+	return h.getIndicators(c, model.IndicatorMarketForex)
+}
+
+func (h *IndicatorHandler) getIndicators(c *fiber.Ctx, market string) error {
+	req := model.IndicatorRequest{
+		Market: market,
+		OHLCVRequest: model.OHLCVRequest{
+			Ticker:    c.Query("ticker", ""),
+			TimeFrame: c.Query("tf", ""),
+			Timezone:  c.Query("timezone", ""),
+		},
+	}
+
+	// Dates are local to the timezone, an invalid one is rejected by the service
+	loc := time.UTC
+	if tz, err := time.LoadLocation(req.Timezone); err == nil {
+		loc = tz
+	}
+	startDate, err := time.ParseInLocation("2006-01-02", c.Query("start_date", ""), loc)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid start_date format. Use YYYY-MM-DD"})
+	}
+	req.StartDate = startDate
+	if endDateStr := c.Query("end_date", ""); endDateStr != "" {
+		endDate, err := time.Parse(time.RFC3339, endDateStr)
+		if err != nil {
+			if endDate, err = time.ParseInLocation("2006-01-02", endDateStr, loc); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid end_date format. Use RFC3339 or YYYY-MM-DD"})
+			}
+		}
+		req.EndDate = &endDate
+	}
+
+	req.Indicators, err = parseIndicatorSpecs(c.Query("indicators", ""))
+	if err != nil {
+		return indicatorError(c, err)
+	}
+
+	result, err := h.service.GetIndicators(c.UserContext(), req)
+	if err != nil {
+		return indicatorError(c, err)
+	}
+	return c.JSON(result)
+}
+
+// parseIndicatorSpecs parses comma separated indicators written name:param:param, such as macd:12:26:9
+func parseIndicatorSpecs(param string) ([]model.IndicatorSpec, error) {
+	var specs []model.IndicatorSpec
+	for _, item := range strings.Split(param, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		parts := strings.Split(item, ":")
+		spec := model.IndicatorSpec{Name: parts[0]}
+		for _, part := range parts[1:] {
+			value, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+			if err != nil {
+				return nil, model.ErrInvalidIndicatorParams
+			}
+			spec.Params = append(spec.Params, value)
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+func indicatorError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, model.ErrOHLCVUnavailable):
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, model.ErrInvalidIndicator),
+		errors.Is(err, model.ErrInvalidIndicatorParams),
+		errors.Is(err, model.ErrTooManyIndicators),
+		errors.Is(err, model.ErrIndicatorsRequired),
+		errors.Is(err, model.ErrIndicatorTickerRequired),
+		errors.Is(err, model.ErrInvalidIndicatorTimeFrame),
+		errors.Is(err, model.ErrInvalidIndicatorRange),
+		errors.Is(err, model.ErrIndicatorRangeTooLarge),
+		errors.Is(err, model.ErrInvalidOHLCVTimezone),
+		errors.Is(err, model.ErrUnsupportedTimeFrame):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	logger.Errorf("indicators: %v", err)
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to compute indicators"})
+}
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
+)
+
+type IngestHandler struct {
+	service port.IngestService
+}
+
+func NewIngestHandler(service port.IngestService) *IngestHandler {
+	return &IngestHandler{service: service}
+}
+
+// IngestTweets godoc
+// @Summary      Ingest tweets
+// @Description  Upserts tweets by ID. Invalid tweets are rejected one by one, the valid ones are stored in a single transaction. Requires an API key with the tweets:write scope
+// @Tags         Ingest
+// @Accept       json
+// @Produce      json
+// @Param        X-API-Key  header    string                     true  "Ingestion API key"
+// @Param        body       body      model.IngestTweetsRequest  true  "At most 500 tweets"
+// @Success      200        {object}  model.IngestResult
+// @Failure      400        {object}  map[string]string
+// @Failure      401        {object}  map[string]string
+// @Failure      403        {object}  map[string]string
+// @Failure      500        {object}  map[string]string
+// @Router       /ingest/tweets [post]
+func (h *IngestHandler) IngestTweets(c *fiber.Ctx) error {
+	var req model.IngestTweetsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	result, err := h.service.IngestTweets(c.UserContext(), req.Tweets)
+	if err != nil {
+		return ingestError(c, err, "Failed to ingest tweets")
+	}
+	return c.JSON(result)
+}
+
+// IngestSentiments godoc
+// @Summary      Ingest tweet sentiments
+// @Description  Upserts tweet sentiments by tweet ID. Requires an API key with the sentiments:write scope
+// @Tags         Ingest
+// @Accept       json
+// @Produce      json
+// @Param        X-API-Key  header    string                         true  "Ingestion API key"
+// @Param        body       body      model.IngestSentimentsRequest  true  "At most 500 sentiments"
+// @Success      200        {object}  model.IngestResult
+// @Failure      400        {object}  map[string]string
+// @Failure      401        {object}  map[string]string
+// @Failure      403        {object}  map[string]string
+// @Failure      500        {object}  map[string]string
+// @Router       /ingest/sentiments [post]
+func (h *IngestHandler) IngestSentiments(c *fiber.Ctx) error {
+	var req model.IngestSentimentsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	result, err := h.service.IngestSentiments(c.UserContext(), req.Sentiments)
+	if err != nil {
+		return ingestError(c, err, "Failed to ingest sentiments")
+	}
+	return c.JSON(result)
+}
+
+// IngestSignals godoc
+// @Summary      Ingest tweet signals
+// @Description  Replaces the signals of each tweet and prompt version with the given set and normalizes their tickers. The tweets must be ingested first. Requires an API key with the signals:write scope
+// @Tags         Ingest
+// @Accept       json
+// @Produce      json
+// @Param        X-API-Key  header    string                      true  "Ingestion API key"
+// @Param        body       body      model.IngestSignalsRequest  true  "At most 500 tweets"
+// @Success      200        {object}  model.IngestResult
+// @Failure      400        {object}  map[string]string
+// @Failure      401        {object}  map[string]string
+// @Failure      403        {object}  map[string]string
+// @Failure      500        {object}  map[string]string
+// @Router       /ingest/signals [post]
+func (h *IngestHandler) IngestSignals(c *fiber.Ctx) error {
+	var req model.IngestSignalsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	result, err := h.service.IngestSignals(c.UserContext(), req.Tweets)
+	if err != nil {
+		return ingestError(c, err, "Failed to ingest signals")
+	}
+	return c.JSON(result)
+}
+
+// GetAPIKeys godoc
+// @Summary      Get ingestion API keys
+// @Description  Lists every ingestion API key, revoked ones included. Keys are only shown by prefix
+// @Tags         Ingest
+// @Produce      json
+// @Success      200  {array}   model.IngestAPIKey
+// @Failure      500  {object}  map[string]string
+// @Router       /crm/ingest-keys [get]
+// @Security     BearerAuth
+func (h *IngestHandler) GetAPIKeys(c *fiber.Ctx) error {
+	keys, err := h.service.GetAPIKeys(c.UserContext())
+	if err != nil {
+		logger.Errorf("ingest: failed to get API keys: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get API keys"})
+	}
+	return c.JSON(keys)
+}
+
+// CreateAPIKey godoc
+// @Summary      Create an ingestion API key
+// @Description  The key is only returned in this response, store it right away
+// @Tags         Ingest
+// @Accept       json
+// @Produce      json
+// @Param        body  body      model.IngestAPIKeyRequest  true  "Name and scopes (tweets:write, sentiments:write, signals:write)"
+// @Success      201   {object}  model.CreatedIngestAPIKey
+// @Failure      400   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /crm/ingest-keys [post]
+// @Security     BearerAuth
+func (h *IngestHandler) CreateAPIKey(c *fiber.Ctx) error {
+	var req model.IngestAPIKeyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	createdBy, _ := c.Locals("username").(string)
+	key, err := h.service.CreateAPIKey(c.UserContext(), req, createdBy)
+	if err != nil {
+		return ingestError(c, err, "Failed to create API key")
+	}
+	return c.Status(fiber.StatusCreated).JSON(key)
+}
+
+// RevokeAPIKey godoc
+// @Summary      Revoke an ingestion API key
+// @Tags         Ingest
+// @Produce      json
+// @Param        id   path      string  true  "API key ID"
+// @Success      200  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /crm/ingest-keys/{id} [delete]
+// @Security     BearerAuth
+func (h *IngestHandler) RevokeAPIKey(c *fiber.Ctx) error {
+	if err := h.service.RevokeAPIKey(c.UserContext(), c.Params("id")); err != nil {
+		return ingestError(c, err, "Failed to revoke API key")
+	}
+	return c.JSON(fiber.Map{"message": "API key revoked"})
+}
+
+func ingestError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, model.ErrInvalidIngestBatch),
+		errors.Is(err, model.ErrInvalidIngestScope),
+		errors.Is(err, model.ErrIngestAPIKeyNameRequired):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, model.ErrIngestAPIKeyNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+	logger.Errorf("ingest: %s: %v", strings.ToLower(message), err)
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": message})
+}
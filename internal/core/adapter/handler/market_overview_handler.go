@@ -1,11 +1,7 @@
 package handler
 
 import (
-	"bytes"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"strconv"
 	"strings"
 
@@ -54,54 +50,7 @@ func (h *MarketOverviewHandler) GetMarketOverviewTable(c *fiber.Ctx) error {
 		})
 	}
 
-	payload := requestPayload{
-		Columns:             []string{"base_currency", "exchange", "currency", "close", "24h_close_change|5", "market_cap_calc", "24h_vol_cmc"},
-		Markets:             []string{"coin"},
-		IgnoreUnknownFields: false,
-		Range:               []int{0, 300},
-		Sort: struct {
-			SortBy    string `json:"sortBy"`
-			SortOrder string `json:"sortOrder"`
-		}{
-			SortBy:    "crypto_total_rank",
-			SortOrder: "asc",
-		},
-	}
-
-	requestBody, err := json.Marshal(payload)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": fmt.Sprintf("failed to encode request: %w", err),
-		})
-	}
-
-	response, err := http.Post(
-		"https://scanner.tradingview.com/coin/scan?label-product=screener-coin",
-		"application/json",
-		bytes.NewBuffer(requestBody),
-	)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": fmt.Sprintf("failed to make request: %w", err),
-		})
-	}
-	defer response.Body.Close()
-
-	body, err := io.ReadAll(response.Body)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": fmt.Sprintf("failed to read response body: %w", err),
-		})
-	}
-
-	var scannerResult map[string]interface{}
-	if err := json.Unmarshal(body, &scannerResult); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": fmt.Sprintf("failed to unmarshal response: %w", err),
-		})
-	}
-
-	enrichedData, err := h.marketOverviewService.GetEnrichedMarketOverviewTable(tier, timeframe, scannerResult)
+	enrichedData, err := h.marketOverviewService.GetEnrichedMarketOverviewTable(tier, timeframe)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
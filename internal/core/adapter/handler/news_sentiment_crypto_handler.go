@@ -1,10 +1,14 @@
 package handler
 
 import (
+	"errors"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
 )
 
 type NewsSentimentCryptoHandler struct {
@@ -24,3 +28,96 @@ func (h *NewsSentimentCryptoHandler) GetNewsSentiment(c *fiber.Ctx) error {
 		"bubble_sentiment": newsSentiment,
 	})
 }
+
+// QueryNewsSentiment godoc
+// @Summary      Query news sentiment
+// @Description  Counts the news mentioning each ticker by sentiment per time bucket, with per-source breakdowns and the headlines driving each bucket. Without tickers the most mentioned tickers are returned
+// @Tags         News Sentiment
+// @Produce      json
+// @Param        tickers    query     string  false  "Comma separated tickers, e.g. BTC,ETH"
+// @Param        sources    query     string  false  "Comma separated source names"
+// @Param        from       query     string  false  "Start date (YYYY-MM-DD) in the timezone, defaults to 7 days before to"
+// @Param        to         query     string  false  "End date (YYYY-MM-DD) in the timezone, inclusive, at most 90 days after from"
+// @Param        bucket     query     string  false  "Bucket size: 1h, 4h, 12h, 1d or 1w"  default(1d)
+// @Param        timezone   query     string  false  "IANA timezone of the dates and buckets"  default(UTC)
+// @Param        limit      query     int     false  "Maximum number of tickers, at most 100"  default(20)
+// @Param        headlines  query     int     false  "Headlines per bucket, at most 10"  default(3)
+// @Success      200        {object}  model.NewsSentimentResult
+// @Failure      400        {object}  map[string]string
+// @Failure      500        {object}  map[string]string
+// @Router       /news-sentiment-crypto/sentiment [get]
+func (h *NewsSentimentCryptoHandler) QueryNewsSentiment(c *fiber.Ctx) error {
+	req := model.NewsSentimentRequest{
+		Tickers:   splitQueryList(c.Query("tickers", "")),
+		Sources:   splitQueryList(c.Query("sources", "")),
+		Bucket:    c.Query("bucket", ""),
+		Timezone:  c.Query("timezone", "UTC"),
+		Limit:     c.QueryInt("limit", 0),
+		Headlines: c.QueryInt("headlines", 0),
+	}
+	// Dates are days in the requested timezone
+	loc, err := time.LoadLocation(req.Timezone)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": model.ErrInvalidNewsTimezone.Error()})
+	}
+	if fromStr := c.Query("from", ""); fromStr != "" {
+		parsedFrom, err := time.ParseInLocation("2006-01-02", fromStr, loc)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid from format. Use YYYY-MM-DD",
+			})
+		}
+		req.From = parsedFrom
+	}
+	if toStr := c.Query("to", ""); toStr != "" {
+		parsedTo, err := time.ParseInLocation("2006-01-02", toStr, loc)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid to format. Use YYYY-MM-DD",
+			})
+		}
+		// Include the whole day
+		req.To = parsedTo.AddDate(0, 0, 1)
+	}
+
+	result, err := h.service.QueryNewsSentiment(c.UserContext(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, model.ErrInvalidNewsBucket),
+			errors.Is(err, model.ErrInvalidNewsTimezone),
+			errors.Is(err, model.ErrInvalidNewsRange),
+			errors.Is(err, model.ErrInvalidNewsLimit):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		logger.Errorf("news sentiment: failed to query news sentiment: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to query news sentiment"})
+	}
+	return c.JSON(result)
+}
+
+// GetNewsSources godoc
+// @Summary      Get news sources
+// @Description  Lists the source names the news sentiment can be filtered by
+// @Tags         News Sentiment
+// @Produce      json
+// @Success      200  {array}   string
+// @Failure      500  {object}  map[string]string
+// @Router       /news-sentiment-crypto/sources [get]
+func (h *NewsSentimentCryptoHandler) GetNewsSources(c *fiber.Ctx) error {
+	sources, err := h.service.GetNewsSources(c.UserContext())
+	if err != nil {
+		logger.Errorf("news sentiment: failed to get news sources: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get news sources"})
+	}
+	return c.JSON(sources)
+}
+
+func splitQueryList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -1,12 +1,14 @@
 package handler
 
 import (
+	stderrors "errors"
 	"fmt"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/quantsmithapp/datastation-backend/internal/core/domain"
 	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/errors"
 	"github.com/quantsmithapp/datastation-backend/pkg/logger"
 	"github.com/quantsmithapp/datastation-backend/pkg/util"
 )
@@ -33,7 +35,7 @@ func (h *OHLCVDataHandler) GetCryptoOHLCV(c *fiber.Ctx) error {
 	result, err := h.serv.GetCryptoOHLCV(req)
 	if err != nil {
 		h.logger.Error(err)
-		return util.ResponseError(c, err)
+		return util.ResponseError(c, ohlcvError(err))
 	}
 
 	return util.ResponseOK(c, result)
@@ -49,7 +51,7 @@ func (h *OHLCVDataHandler) GetForexOHLCV(c *fiber.Ctx) error {
 	result, err := h.serv.GetForexOHLCV(req)
 	if err != nil {
 		h.logger.Error(err)
-		return util.ResponseError(c, err)
+		return util.ResponseError(c, ohlcvError(err))
 	}
 
 	return util.ResponseOK(c, result)
@@ -60,9 +62,16 @@ func parseOHLCVRequest(c *fiber.Ctx) (model.OHLCVRequest, error) {
 		Ticker:    c.Query("ticker"),
 		TimeFrame: c.Query("tf"),
 		AllPair:   c.QueryBool("all_pair", false),
+		Timezone:  c.Query("timezone"),
+	}
+
+	// Dates are local to the timezone, an invalid one is rejected by the repo
+	loc := time.UTC
+	if tz, err := time.LoadLocation(req.Timezone); err == nil {
+		loc = tz
 	}
 
-	startDate, err := time.Parse("2006-01-02", c.Query("start_date"))
+	startDate, err := time.ParseInLocation("2006-01-02", c.Query("start_date"), loc)
 	if err != nil {
 		return req, fmt.Errorf("invalid start_date format: %v", err)
 	}
@@ -72,7 +81,7 @@ func parseOHLCVRequest(c *fiber.Ctx) (model.OHLCVRequest, error) {
 		endDate, err := time.Parse(time.RFC3339, endDateStr)
 		if err != nil {
 			// Try parsing with the date-only format if RFC3339 fails
-			endDate, err = time.Parse("2006-01-02", endDateStr)
+			endDate, err = time.ParseInLocation("2006-01-02", endDateStr, loc)
 			if err != nil {
 				return req, fmt.Errorf("invalid end_date format: %v", err)
 			}
@@ -85,3 +94,11 @@ func parseOHLCVRequest(c *fiber.Ctx) (model.OHLCVRequest, error) {
 
 	return req, nil
 }
+
+// ohlcvError turns request errors from the timescale repo into bad requests
+func ohlcvError(err error) error {
+	if stderrors.Is(err, model.ErrUnsupportedTimeFrame) || stderrors.Is(err, model.ErrInvalidOHLCVTimezone) {
+		return errors.NewBadRequest("OHLCV400", err.Error())
+	}
+	return err
+}
@@ -0,0 +1,228 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
+)
+
+type OHLCVExportHandler struct {
+	service port.OHLCVExportService
+}
+
+func NewOHLCVExportHandler(service port.OHLCVExportService) *OHLCVExportHandler {
+	return &OHLCVExportHandler{service: service}
+}
+
+// ohlcvExportJobRequest is the body of an export job, dates are parsed like the query parameters of an export
+type ohlcvExportJobRequest struct {
+	Market     string   `json:"market"`
+	Tickers    []string `json:"tickers"`
+	TimeFrames []string `json:"timeframes"`
+	StartDate  string   `json:"start_date"`
+	EndDate    string   `json:"end_date"`
+	Timezone   string   `json:"timezone"`
+	Format     string   `json:"format"`
+}
+
+// ExportOHLCV godoc
+// @Summary      Export OHLCV
+// @Description  Streams the candles of the tickers for each timeframe as gzip CSV or Parquet, sorted by timeframe, time and ticker. Exports of all pairs or of more than 500000 candles must run as export jobs
+// @Tags         OHLCV Export
+// @Produce      application/gzip
+// @Produce      application/vnd.apache.parquet
+// @Param        market      query     string  false  "crypto or forex"  default(crypto)
+// @Param        tickers     query     string  true   "Comma separated pairs, e.g. BTCUSDT,ETHUSDT"
+// @Param        tf          query     string  true   "Comma separated timeframes, e.g. 1h,4h,1d"
+// @Param        start_date  query     string  true   "Start date (YYYY-MM-DD)"
+// @Param        end_date    query     string  false  "End date (RFC3339 or YYYY-MM-DD), defaults to the latest candle"
+// @Param        timezone    query     string  false  "IANA timezone dates and candles are aligned to"  default(UTC)
+// @Param        format      query     string  false  "csv or parquet"  default(csv)
+// @Success      200         {file}    file
+// @Failure      400         {object}  map[string]string
+// @Failure      401         {object}  map[string]string
+// @Failure      503         {object}  map[string]string
+// @Router       /timescale/export [get]
+// @Security     BearerAuth
+func (h *OHLCVExportHandler) ExportOHLCV(c *fiber.Ctx) error {
+	req, err := parseOHLCVExportRequest(ohlcvExportJobRequest{
+		Market:     c.Query("market", ""),
+		Tickers:    strings.Split(c.Query("tickers", ""), ","),
+		TimeFrames: strings.Split(c.Query("tf", ""), ","),
+		StartDate:  c.Query("start_date", ""),
+		EndDate:    c.Query("end_date", ""),
+		Timezone:   c.Query("timezone", ""),
+		Format:     c.Query("format", ""),
+	})
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if req, err = h.service.PrepareExport(req); err != nil {
+		return ohlcvExportError(c, err, "Failed to export OHLCV")
+	}
+
+	c.Set(fiber.HeaderContentType, ohlcvExportContentType(req.Format))
+	c.Attachment(req.FileName())
+	// The status is sent before the first row, an error while streaming can only end the file early
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if rows, err := h.service.WriteExport(context.Background(), req, w); err != nil {
+			logger.Errorf("ohlcv export: export stopped after %d rows: %v", rows, err)
+		}
+	})
+	return nil
+}
+
+// CreateExportJob godoc
+// @Summary      Create an OHLCV export job
+// @Description  Exports the candles in the background, poll the job until it completes and download the file from its download_url within a day. A user can have at most 3 jobs pending or running
+// @Tags         OHLCV Export
+// @Accept       json
+// @Produce      json
+// @Param        body  body      handler.ohlcvExportJobRequest  true  "Export, tickers defaults to all pairs"
+// @Success      202   {object}  model.OHLCVExportJob
+// @Failure      400   {object}  map[string]string
+// @Failure      401   {object}  map[string]string
+// @Failure      429   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Failure      503   {object}  map[string]string
+// @Router       /timescale/exports [post]
+// @Security     BearerAuth
+func (h *OHLCVExportHandler) CreateExportJob(c *fiber.Ctx) error {
+	uid, ok := c.Locals("uid").(string)
+	if !ok || uid == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	var body ohlcvExportJobRequest
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	req, err := parseOHLCVExportRequest(body)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	job, err := h.service.CreateJob(c.UserContext(), uid, req)
+	if err != nil {
+		return ohlcvExportError(c, err, "Failed to create export job")
+	}
+	return c.Status(fiber.StatusAccepted).JSON(job)
+}
+
+// GetExportJob godoc
+// @Summary      Get an OHLCV export job
+// @Tags         OHLCV Export
+// @Produce      json
+// @Param        id   path      string  true  "Job ID"
+// @Success      200  {object}  model.OHLCVExportJob
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Router       /timescale/exports/{id} [get]
+// @Security     BearerAuth
+func (h *OHLCVExportHandler) GetExportJob(c *fiber.Ctx) error {
+	uid, ok := c.Locals("uid").(string)
+	if !ok || uid == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	job, err := h.service.GetJob(uid, c.Params("id"))
+	if err != nil {
+		return ohlcvExportError(c, err, "Failed to get export job")
+	}
+	if job.Status == model.OHLCVExportCompleted {
+		job.DownloadURL = c.Path() + "/download"
+	}
+	return c.JSON(job)
+}
+
+// DownloadExport godoc
+// @Summary      Download an OHLCV export
+// @Tags         OHLCV Export
+// @Produce      application/gzip
+// @Produce      application/vnd.apache.parquet
+// @Param        id   path      string  true  "Job ID"
+// @Success      200  {file}    file
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Router       /timescale/exports/{id}/download [get]
+// @Security     BearerAuth
+func (h *OHLCVExportHandler) DownloadExport(c *fiber.Ctx) error {
+	uid, ok := c.Locals("uid").(string)
+	if !ok || uid == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	path, fileName, err := h.service.GetJobFile(uid, c.Params("id"))
+	if err != nil {
+		return ohlcvExportError(c, err, "Failed to download export")
+	}
+	return c.Download(path, fileName)
+}
+
+func parseOHLCVExportRequest(body ohlcvExportJobRequest) (model.OHLCVExportRequest, error) {
+	req := model.OHLCVExportRequest{
+		Market:     body.Market,
+		Tickers:    body.Tickers,
+		TimeFrames: body.TimeFrames,
+		Timezone:   body.Timezone,
+		Format:     body.Format,
+	}
+
+	// Dates are local to the timezone, an invalid one is rejected by the service
+	loc := time.UTC
+	if tz, err := time.LoadLocation(body.Timezone); err == nil {
+		loc = tz
+	}
+	startDate, err := time.ParseInLocation("2006-01-02", body.StartDate, loc)
+	if err != nil {
+		return req, errors.New("Invalid start_date format. Use YYYY-MM-DD")
+	}
+	req.StartDate = startDate
+	if body.EndDate != "" {
+		endDate, err := time.Parse(time.RFC3339, body.EndDate)
+		if err != nil {
+			if endDate, err = time.ParseInLocation("2006-01-02", body.EndDate, loc); err != nil {
+				return req, errors.New("Invalid end_date format. Use RFC3339 or YYYY-MM-DD")
+			}
+		}
+		req.EndDate = &endDate
+	}
+	return req, nil
+}
+
+func ohlcvExportContentType(format string) string {
+	if format == model.OHLCVExportParquet {
+		return "application/vnd.apache.parquet"
+	}
+	return "application/gzip"
+}
+
+func ohlcvExportError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, model.ErrOHLCVUnavailable):
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, model.ErrInvalidExportFormat),
+		errors.Is(err, model.ErrInvalidExportMarket),
+		errors.Is(err, model.ErrExportTimeFramesInvalid),
+		errors.Is(err, model.ErrExportTooManyTickers),
+		errors.Is(err, model.ErrInvalidExportRange),
+		errors.Is(err, model.ErrExportTooLarge),
+		errors.Is(err, model.ErrExportJobNotCompleted),
+		errors.Is(err, model.ErrInvalidOHLCVTimezone):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, model.ErrExportJobNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, model.ErrTooManyExportJobs):
+		return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{"error": err.Error()})
+	}
+	logger.Errorf("ohlcv export: %s: %v", strings.ToLower(message), err)
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": message})
+}
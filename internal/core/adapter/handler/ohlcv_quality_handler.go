@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
+)
+
+type OHLCVQualityHandler struct {
+	service port.OHLCVQualityService
+}
+
+func NewOHLCVQualityHandler(service port.OHLCVQualityService) *OHLCVQualityHandler {
+	return &OHLCVQualityHandler{service: service}
+}
+
+// GetReport godoc
+// @Summary      Get an OHLCV data quality report
+// @Description  Returns a data quality scan of the OHLCV tables with its findings counted by kind: gaps with the candles backfilled, duplicate buckets, non-monotonic timestamps, zero volume runs, OHLC inconsistencies and stale tickers
+// @Tags         CRM
+// @Produce      json
+// @Param        run_at  query     string  false  "Scan time (RFC3339), defaults to the latest scan"
+// @Param        table   query     string  false  "Table, e.g. binance_1h"
+// @Param        ticker  query     string  false  "Ticker, e.g. BTCUSDT"
+// @Param        kind    query     string  false  "gap, duplicate, non_monotonic, zero_volume, ohlc_inconsistent or stale"
+// @Param        limit   query     int     false  "Max findings (default 500, max 5000)"
+// @Success      200     {object}  model.OHLCVQualityReport
+// @Failure      400     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Failure      503     {object}  map[string]string
+// @Router       /crm/ohlcv-quality [get]
+// @Security     BearerAuth
+func (h *OHLCVQualityHandler) GetReport(c *fiber.Ctx) error {
+	filter := model.OHLCVQualityFilter{
+		Table:  c.Query("table", ""),
+		Ticker: strings.ToUpper(c.Query("ticker", "")),
+		Kind:   c.Query("kind", ""),
+		Limit:  c.QueryInt("limit", model.DefaultOHLCVQualityLimit),
+	}
+	if runAt := c.Query("run_at", ""); runAt != "" {
+		t, err := time.Parse(time.RFC3339Nano, runAt)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid run_at format. Use RFC3339"})
+		}
+		filter.RunAt = &t
+	}
+
+	report, err := h.service.GetReport(c.UserContext(), filter)
+	if err != nil {
+		return ohlcvQualityError(c, err, "Failed to get data quality report")
+	}
+	return c.JSON(report)
+}
+
+// GetRuns godoc
+// @Summary      List OHLCV data quality scans
+// @Description  Returns the data quality scans of the last 30 days, latest first
+// @Tags         CRM
+// @Produce      json
+// @Param        limit  query     int  false  "Max scans (default 500)"
+// @Success      200    {array}   model.OHLCVQualityRun
+// @Failure      500    {object}  map[string]string
+// @Failure      503    {object}  map[string]string
+// @Router       /crm/ohlcv-quality/runs [get]
+// @Security     BearerAuth
+func (h *OHLCVQualityHandler) GetRuns(c *fiber.Ctx) error {
+	runs, err := h.service.GetRuns(c.UserContext(), c.QueryInt("limit", model.DefaultOHLCVQualityLimit))
+	if err != nil {
+		return ohlcvQualityError(c, err, "Failed to get data quality scans")
+	}
+	return c.JSON(runs)
+}
+
+// TriggerScan godoc
+// @Summary      Scan OHLCV data quality
+// @Description  Starts a data quality scan of every OHLCV table in the background, its report is available once it appears in the scans
+// @Tags         CRM
+// @Produce      json
+// @Success      202  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      503  {object}  map[string]string
+// @Router       /crm/ohlcv-quality/scan [post]
+// @Security     BearerAuth
+func (h *OHLCVQualityHandler) TriggerScan(c *fiber.Ctx) error {
+	if err := h.service.TriggerScan(); err != nil {
+		return ohlcvQualityError(c, err, "Failed to start data quality scan")
+	}
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{"message": "Data quality scan started"})
+}
+
+func ohlcvQualityError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, model.ErrOHLCVUnavailable):
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, model.ErrInvalidOHLCVIssue):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, model.ErrOHLCVQualityRunNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, model.ErrOHLCVQualityScanRunning):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	}
+	logger.Errorf("ohlcv quality: %s: %v", strings.ToLower(message), err)
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": message})
+}
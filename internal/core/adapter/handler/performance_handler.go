@@ -1,9 +1,11 @@
 package handler
 
 import (
+	"errors"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/quantsmithapp/datastation-backend/internal/core/port"
-	_ "github.com/quantsmithapp/datastation-backend/internal/model" // Required for Swagger to find model definitions
+	"github.com/quantsmithapp/datastation-backend/internal/model"
 )
 
 type PerformanceHandler struct {
@@ -158,3 +160,39 @@ func (h *PerformanceHandler) GetAuthorMultiholdingDetailHandle(c *fiber.Ctx) err
 	}
 	return c.JSON(authorDetail)
 }
+
+// GetAuthorReturnStatsHandle godoc
+// @Summary      Get author monthly returns calendar and rolling statistics
+// @Description  Get the year x month returns grid and rolling 30D/90D return, volatility and max drawdown series from the author's full NAV history. Omit holding_period for the single-holding NAV, or pass it to use the multiholding portfolio NAV
+// @Tags         Performance
+// @Accept       json
+// @Produce      json
+// @Param        author_username query string true "Author Username" example("0xkyle__")
+// @Param        holding_period query string false "Holding Period (Hours)" enums(24,48,72,96,120,144,168) example("24")
+// @Success      200 {object} model.AuthorReturnStats
+// @Failure      400 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /performance/get-author-return-stats [get]
+// @Security     BearerAuth
+func (h *PerformanceHandler) GetAuthorReturnStatsHandle(c *fiber.Ctx) error {
+	authorUsername := c.Query("author_username", "")
+	if authorUsername == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Author Username is required",
+		})
+	}
+
+	holdingPeriod := c.Query("holding_period", "")
+	stats, err := h.service.GetAuthorReturnStats(c.UserContext(), authorUsername, holdingPeriod)
+	if err != nil {
+		if errors.Is(err, model.ErrInvalidHoldingPeriod) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get author return stats",
+		})
+	}
+	return c.JSON(stats)
+}
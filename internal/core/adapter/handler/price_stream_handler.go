@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/websocket/v2"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
+
+const (
+	priceStreamPingInterval = 30 * time.Second
+	// priceStreamPongWait is how long a client may stay silent, pongs to the pings count
+	priceStreamPongWait  = 2 * priceStreamPingInterval
+	priceStreamWriteWait = 10 * time.Second
+	priceStreamReadLimit = 4096
+)
+
+type PriceStreamHandler struct {
+	hub port.PriceHub
+}
+
+func NewPriceStreamHandler(hub port.PriceHub) *PriceStreamHandler {
+	return &PriceStreamHandler{hub: hub}
+}
+
+// RequireUpgrade rejects requests to the price stream that are not WebSocket upgrades
+func (h *PriceStreamHandler) RequireUpgrade(c *fiber.Ctx) error {
+	if websocket.IsWebSocketUpgrade(c) {
+		return c.Next()
+	}
+	return fiber.ErrUpgradeRequired
+}
+
+// StreamPrices godoc
+// @Summary      Stream live prices
+// @Description  WebSocket of live TradingView quotes. Send {"action":"subscribe","symbols":["BTCUSDT","BINANCE:ETHUSDT"]} or {"action":"unsubscribe",...}, symbols without an exchange are on BINANCE, at most 50 per connection. Events are {"type":"quote","quote":{...}}, subscribed, unsubscribed and error. Clients that fall behind are disconnected. Browsers pass the ID token in the token query parameter
+// @Tags         Price Stream
+// @Param        token  query  string  false  "Firebase ID token when the Authorization header cannot be set"
+// @Success      101
+// @Failure      401    {object}  map[string]string
+// @Failure      426    {object}  map[string]string
+// @Router       /ws/prices [get]
+// @Security     BearerAuth
+func (h *PriceStreamHandler) StreamPrices(conn *websocket.Conn) {
+	clientID, messages := h.hub.Register()
+	defer h.hub.Unregister(clientID)
+
+	// The connection is released once the handler returns, so stop the writer and wait for it to exit first
+	done := make(chan struct{})
+	var writer sync.WaitGroup
+	writer.Add(1)
+	go func() {
+		defer writer.Done()
+		h.writeLoop(conn, messages, done)
+	}()
+	defer func() {
+		close(done)
+		writer.Wait()
+	}()
+
+	conn.SetReadLimit(priceStreamReadLimit)
+	conn.SetReadDeadline(time.Now().Add(priceStreamPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(priceStreamPongWait))
+	})
+
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var req model.PriceStreamRequest
+		if err := json.Unmarshal(message, &req); err != nil {
+			h.hub.Send(clientID, model.PriceStreamEvent{Type: model.PriceEventError, Error: "Invalid message"})
+			continue
+		}
+		conn.SetReadDeadline(time.Now().Add(priceStreamPongWait))
+
+		switch req.Action {
+		case model.PriceStreamSubscribe:
+			symbols, err := h.hub.Subscribe(clientID, req.Symbols)
+			if err != nil {
+				h.hub.Send(clientID, model.PriceStreamEvent{Type: model.PriceEventError, Error: err.Error()})
+				continue
+			}
+			h.hub.Send(clientID, model.PriceStreamEvent{Type: model.PriceEventSubscribed, Symbols: symbols})
+		case model.PriceStreamUnsubscribe:
+			symbols := h.hub.Unsubscribe(clientID, req.Symbols)
+			h.hub.Send(clientID, model.PriceStreamEvent{Type: model.PriceEventUnsubscribed, Symbols: symbols})
+		default:
+			h.hub.Send(clientID, model.PriceStreamEvent{Type: model.PriceEventError, Error: model.ErrInvalidPriceStreamAction.Error()})
+		}
+	}
+}
+
+// writeLoop is the only writer of the connection. It sends the hub's messages and the heartbeat pings, and closes
+// the connection when the hub drops the client so the read loop ends too.
+func (h *PriceStreamHandler) writeLoop(conn *websocket.Conn, messages <-chan []byte, done <-chan struct{}) {
+	ticker := time.NewTicker(priceStreamPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case message, ok := <-messages:
+			if !ok {
+				// Closed by Unregister when the read loop already ended
+				select {
+				case <-done:
+					return
+				default:
+				}
+				conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "client too slow"),
+					time.Now().Add(priceStreamWriteWait))
+				conn.Close()
+				return
+			}
+			conn.SetWriteDeadline(time.Now().Add(priceStreamWriteWait))
+			if err := conn.WriteMessage(websocket.TextMessage, message); err != nil {
+				conn.Close()
+				return
+			}
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(priceStreamWriteWait)); err != nil {
+				conn.Close()
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
+)
+
+type PromptComparisonHandler struct {
+	service port.PromptComparisonService
+}
+
+func NewPromptComparisonHandler(service port.PromptComparisonService) *PromptComparisonHandler {
+	return &PromptComparisonHandler{service: service}
+}
+
+// GetPromptVersions godoc
+// @Summary      Get prompt versions
+// @Description  Lists the prompt versions that extracted signals, most recently used first. Active marks the version every reader uses
+// @Tags         Prompt Comparison
+// @Produce      json
+// @Param        from_date  query     string  false  "Only count signals since this date (YYYY-MM-DD), defaults to 90 days ago"
+// @Success      200        {array}   model.PromptVersionStats
+// @Failure      400        {object}  map[string]string
+// @Failure      500        {object}  map[string]string
+// @Router       /twitter-crypto/prompt-versions [get]
+func (h *PromptComparisonHandler) GetPromptVersions(c *fiber.Ctx) error {
+	since := time.Now().UTC().AddDate(0, 0, -90)
+	if fromDateStr := c.Query("from_date", ""); fromDateStr != "" {
+		parsedFromDate, err := time.Parse("2006-01-02", fromDateStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid from_date format. Use YYYY-MM-DD",
+			})
+		}
+		since = parsedFromDate
+	}
+
+	versions, err := h.service.GetPromptVersions(c.UserContext(), since)
+	if err != nil {
+		logger.Errorf("prompt comparison: failed to get prompt versions: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get prompt versions"})
+	}
+	return c.JSON(versions)
+}
+
+// ComparePromptVersions godoc
+// @Summary      Compare two prompt versions
+// @Description  Compares the signals two prompt versions extracted from the same tweets: agreement rate, per-ticker disagreements and the forward-return hit rate of each version's LONG/SHORT signals. Hit rates are empty when OHLCV is unavailable
+// @Tags         Prompt Comparison
+// @Produce      json
+// @Param        version_a      query     string  true   "First prompt version"
+// @Param        version_b      query     string  true   "Second prompt version"
+// @Param        from_date      query     string  false  "Tweets posted since (YYYY-MM-DD), defaults to 30 days before to_date"
+// @Param        to_date        query     string  false  "Tweets posted until (YYYY-MM-DD), inclusive, at most 90 days after from_date"
+// @Param        horizon_hours  query     int     false  "Forward-return horizon in hours, at most 720"  default(24)
+// @Success      200            {object}  model.PromptComparison
+// @Failure      400            {object}  map[string]string
+// @Failure      500            {object}  map[string]string
+// @Router       /twitter-crypto/prompt-versions/compare [get]
+func (h *PromptComparisonHandler) ComparePromptVersions(c *fiber.Ctx) error {
+	req := model.PromptComparisonRequest{
+		VersionA:     c.Query("version_a", ""),
+		VersionB:     c.Query("version_b", ""),
+		HorizonHours: c.QueryInt("horizon_hours", 0),
+	}
+	if fromDateStr := c.Query("from_date", ""); fromDateStr != "" {
+		parsedFromDate, err := time.Parse("2006-01-02", fromDateStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid from_date format. Use YYYY-MM-DD",
+			})
+		}
+		req.FromDate = parsedFromDate
+	}
+	if toDateStr := c.Query("to_date", ""); toDateStr != "" {
+		parsedToDate, err := time.Parse("2006-01-02", toDateStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid to_date format. Use YYYY-MM-DD",
+			})
+		}
+		// Include the whole day
+		req.ToDate = parsedToDate.Add(24 * time.Hour)
+	}
+
+	comparison, err := h.service.ComparePromptVersions(c.UserContext(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, model.ErrPromptVersionsRequired),
+			errors.Is(err, model.ErrInvalidPromptComparisonRange),
+			errors.Is(err, model.ErrInvalidPromptComparisonHorizon):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		logger.Errorf("prompt comparison: failed to compare prompt versions: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to compare prompt versions"})
+	}
+	return c.JSON(comparison)
+}
+
+// SetActivePromptVersion godoc
+// @Summary      Set the active prompt version
+// @Description  Switches feeds, winrate, trending, consensus, tiers and NAVs to the signals of the prompt version. Tweets it did not extract keep the signals of the version that extracted them last
+// @Tags         Prompt Comparison
+// @Accept       json
+// @Produce      json
+// @Param        body  body      model.ActivePromptVersionRequest  true  "Prompt version"
+// @Success      200   {object}  map[string]string
+// @Failure      400   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /crm/prompt-versions/active [post]
+// @Security     BearerAuth
+func (h *PromptComparisonHandler) SetActivePromptVersion(c *fiber.Ctx) error {
+	var req model.ActivePromptVersionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	updatedBy, _ := c.Locals("username").(string)
+
+	if err := h.service.SetActivePromptVersion(c.UserContext(), req.PromptVersion, updatedBy); err != nil {
+		switch {
+		case errors.Is(err, model.ErrPromptVersionRequired):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		case errors.Is(err, model.ErrPromptVersionNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+		}
+		logger.Errorf("prompt comparison: failed to set active prompt version: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to set active prompt version"})
+	}
+	return c.JSON(fiber.Map{"message": "Active prompt version updated"})
+}
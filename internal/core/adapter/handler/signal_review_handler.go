@@ -0,0 +1,240 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
+)
+
+type SignalReviewHandler struct {
+	service port.SignalReviewService
+}
+
+func NewSignalReviewHandler(service port.SignalReviewService) *SignalReviewHandler {
+	return &SignalReviewHandler{service: service}
+}
+
+// FlagSignal godoc
+// @Summary      Flag a wrong signal extraction
+// @Description  Reports a signal with a bad ticker, the wrong direction or that is not a call. Flagged signals are queued for CRM review
+// @Tags         Signal Review
+// @Accept       json
+// @Produce      json
+// @Param        body  body      model.SignalFlagRequest  true  "Signal and reason (bad_ticker, wrong_direction, not_a_call)"
+// @Success      200   {object}  map[string]string
+// @Failure      400   {object}  map[string]string
+// @Failure      401   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /twitter-crypto/signals/flag [post]
+// @Security     BearerAuth
+func (h *SignalReviewHandler) FlagSignal(c *fiber.Ctx) error {
+	uid, ok := c.Locals("uid").(string)
+	if !ok || uid == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	var req model.SignalFlagRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := h.service.FlagSignal(c.UserContext(), uid, req); err != nil {
+		return signalReviewError(c, err, "Failed to flag signal")
+	}
+	return c.JSON(fiber.Map{"message": "Signal flagged"})
+}
+
+// GetSignalReviews godoc
+// @Summary      Get the signal review queue
+// @Description  Returns flagged signals next to their tweet, most flagged first
+// @Tags         Signal Review
+// @Produce      json
+// @Param        status          query     string  false  "pending, corrected, voided, confirmed or all"  default(pending)
+// @Param        reason          query     string  false  "Only signals flagged for this reason"
+// @Param        prompt_version  query     string  false  "Only signals extracted by this prompt version"
+// @Param        page            query     int     false  "Page"  default(1)
+// @Param        limit           query     int     false  "Page size, at most 100"  default(20)
+// @Success      200             {object}  model.SignalReviewPage
+// @Failure      400             {object}  map[string]string
+// @Failure      500             {object}  map[string]string
+// @Router       /crm/signal-reviews [get]
+// @Security     BearerAuth
+func (h *SignalReviewHandler) GetSignalReviews(c *fiber.Ctx) error {
+	page, err := h.service.GetSignalReviews(c.UserContext(), model.SignalReviewQuery{
+		Status:        c.Query("status", ""),
+		Reason:        c.Query("reason", ""),
+		PromptVersion: c.Query("prompt_version", ""),
+		Page:          c.QueryInt("page", 1),
+		Limit:         c.QueryInt("limit", 0),
+	})
+	if err != nil {
+		return signalReviewError(c, err, "Failed to get signal reviews")
+	}
+	return c.JSON(page)
+}
+
+// CorrectSignal godoc
+// @Summary      Correct a signal
+// @Description  Overrides the ticker, action or sentiment of the extraction. Winrate and NAV use the corrected signal
+// @Tags         Signal Review
+// @Accept       json
+// @Produce      json
+// @Param        body  body      model.SignalCorrectionRequest  true  "Signal and corrected fields"
+// @Success      200   {object}  map[string]string
+// @Failure      400   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /crm/signal-reviews/correct [post]
+// @Security     BearerAuth
+func (h *SignalReviewHandler) CorrectSignal(c *fiber.Ctx) error {
+	var req model.SignalCorrectionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	reviewer, _ := c.Locals("username").(string)
+	if err := h.service.CorrectSignal(c.UserContext(), req, reviewer); err != nil {
+		return signalReviewError(c, err, "Failed to correct signal")
+	}
+	return c.JSON(fiber.Map{"message": "Signal corrected"})
+}
+
+// VoidSignal godoc
+// @Summary      Void a signal
+// @Description  Marks the signal as not a call, it no longer counts for winrate and NAV
+// @Tags         Signal Review
+// @Accept       json
+// @Produce      json
+// @Param        body  body      model.SignalDecisionRequest  true  "Signal"
+// @Success      200   {object}  map[string]string
+// @Failure      400   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /crm/signal-reviews/void [post]
+// @Security     BearerAuth
+func (h *SignalReviewHandler) VoidSignal(c *fiber.Ctx) error {
+	return h.decide(c, h.service.VoidSignal, "Signal voided", "Failed to void signal")
+}
+
+// ConfirmSignal godoc
+// @Summary      Confirm a signal
+// @Description  Keeps the extraction as is and closes the flags on it
+// @Tags         Signal Review
+// @Accept       json
+// @Produce      json
+// @Param        body  body      model.SignalDecisionRequest  true  "Signal"
+// @Success      200   {object}  map[string]string
+// @Failure      400   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /crm/signal-reviews/confirm [post]
+// @Security     BearerAuth
+func (h *SignalReviewHandler) ConfirmSignal(c *fiber.Ctx) error {
+	return h.decide(c, h.service.ConfirmSignal, "Signal confirmed", "Failed to confirm signal")
+}
+
+func (h *SignalReviewHandler) decide(c *fiber.Ctx, apply func(ctx context.Context, req model.SignalDecisionRequest, reviewer string) error, message, failure string) error {
+	var req model.SignalDecisionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	reviewer, _ := c.Locals("username").(string)
+	if err := apply(c.UserContext(), req, reviewer); err != nil {
+		return signalReviewError(c, err, failure)
+	}
+	return c.JSON(fiber.Map{"message": message})
+}
+
+// GetSignalReviewAudit godoc
+// @Summary      Get the review audit trail of a tweet's signals
+// @Tags         Signal Review
+// @Produce      json
+// @Param        tweet_id  query     string  true   "Tweet ID"
+// @Param        ticker    query     string  false  "Only this signal"
+// @Success      200       {array}   model.SignalReviewAudit
+// @Failure      400       {object}  map[string]string
+// @Failure      500       {object}  map[string]string
+// @Router       /crm/signal-reviews/audit [get]
+// @Security     BearerAuth
+func (h *SignalReviewHandler) GetSignalReviewAudit(c *fiber.Ctx) error {
+	audit, err := h.service.GetSignalReviewAudit(c.UserContext(), c.Query("tweet_id", ""), c.Query("ticker", ""))
+	if err != nil {
+		return signalReviewError(c, err, "Failed to get signal review audit")
+	}
+	return c.JSON(audit)
+}
+
+// ExportSignalTrainingData godoc
+// @Summary      Export reviewed signals as training data
+// @Description  Downloads the signals reviewed in the range as JSON lines, with the extraction, the label and the expected output
+// @Tags         Signal Review
+// @Produce      application/x-ndjson
+// @Param        from_date       query     string  false  "Start date (YYYY-MM-DD), defaults to 30 days ago"
+// @Param        to_date         query     string  false  "End date (YYYY-MM-DD), inclusive"
+// @Param        prompt_version  query     string  false  "Only signals extracted by this prompt version"
+// @Success      200             {array}   model.SignalTrainingExample
+// @Failure      400             {object}  map[string]string
+// @Failure      500             {object}  map[string]string
+// @Router       /crm/signal-reviews/export [get]
+// @Security     BearerAuth
+func (h *SignalReviewHandler) ExportSignalTrainingData(c *fiber.Ctx) error {
+	// Default to the last 30 days
+	toDate := time.Now().UTC()
+	fromDate := toDate.AddDate(0, 0, -30)
+	if fromDateStr := c.Query("from_date", ""); fromDateStr != "" {
+		parsedFromDate, err := time.Parse("2006-01-02", fromDateStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid from_date format. Use YYYY-MM-DD",
+			})
+		}
+		fromDate = parsedFromDate
+	}
+	if toDateStr := c.Query("to_date", ""); toDateStr != "" {
+		parsedToDate, err := time.Parse("2006-01-02", toDateStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid to_date format. Use YYYY-MM-DD",
+			})
+		}
+		// Include the whole day
+		toDate = parsedToDate.Add(24 * time.Hour)
+	}
+
+	examples, err := h.service.GetSignalTrainingExamples(c.UserContext(), fromDate, toDate, c.Query("prompt_version", ""))
+	if err != nil {
+		return signalReviewError(c, err, "Failed to export signal training data")
+	}
+
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, example := range examples {
+		if err := encoder.Encode(example); err != nil {
+			return signalReviewError(c, err, "Failed to export signal training data")
+		}
+	}
+	c.Set(fiber.HeaderContentType, "application/x-ndjson")
+	c.Attachment("signal-training-" + fromDate.Format("20060102") + "-" + toDate.AddDate(0, 0, -1).Format("20060102") + ".jsonl")
+	return c.Send(body.Bytes())
+}
+
+func signalReviewError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, model.ErrSignalKeyRequired),
+		errors.Is(err, model.ErrInvalidSignalFlagReason),
+		errors.Is(err, model.ErrInvalidSignalReviewStatus),
+		errors.Is(err, model.ErrInvalidSignalCorrection):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, model.ErrSignalNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+	logger.Errorf("signal review: %s: %v", strings.ToLower(message), err)
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": message})
+}
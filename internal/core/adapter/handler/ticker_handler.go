@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
+)
+
+const maxResolveTickers = 100
+
+type TickerHandler struct {
+	service port.TickerService
+}
+
+func NewTickerHandler(service port.TickerService) *TickerHandler {
+	return &TickerHandler{service: service}
+}
+
+// ResolveTickers godoc
+// @Summary      Resolve tickers to canonical assets
+// @Description  Maps cashtags, names, pair and exchange symbols (BTCUSDT, BTC-PERP, XBT) to their canonical asset ID
+// @Tags         Ticker
+// @Produce      json
+// @Param        tickers  query     string  true  "Comma separated tickers, at most 100"
+// @Success      200      {array}   model.ResolvedTicker
+// @Failure      400      {object}  map[string]string
+// @Router       /tickers/resolve [get]
+func (h *TickerHandler) ResolveTickers(c *fiber.Ctx) error {
+	var tickers []string
+	for _, ticker := range strings.Split(c.Query("tickers", ""), ",") {
+		if ticker = strings.TrimSpace(ticker); ticker != "" {
+			tickers = append(tickers, ticker)
+		}
+	}
+	if len(tickers) == 0 || len(tickers) > maxResolveTickers {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "tickers must list between 1 and 100 tickers"})
+	}
+
+	resolved := make([]model.ResolvedTicker, len(tickers))
+	for i, ticker := range tickers {
+		resolved[i] = h.service.ResolveTicker(ticker)
+	}
+	return c.JSON(resolved)
+}
+
+// GetAssets godoc
+// @Summary      Get canonical assets
+// @Description  Returns every canonical asset with its aliases
+// @Tags         Ticker
+// @Produce      json
+// @Success      200  {array}   model.CryptoAsset
+// @Failure      500  {object}  map[string]string
+// @Router       /crm/ticker-assets [get]
+// @Security     BearerAuth
+func (h *TickerHandler) GetAssets(c *fiber.Ctx) error {
+	assets, err := h.service.GetAssets(c.UserContext())
+	if err != nil {
+		logger.Errorf("ticker: failed to get assets: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get assets"})
+	}
+	return c.JSON(assets)
+}
+
+// UpsertAsset godoc
+// @Summary      Create or rename a canonical asset
+// @Tags         Ticker
+// @Accept       json
+// @Produce      json
+// @Param        body  body      model.CryptoAssetRequest  true  "Asset"
+// @Success      200   {object}  map[string]string
+// @Failure      400   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /crm/ticker-assets [post]
+// @Security     BearerAuth
+func (h *TickerHandler) UpsertAsset(c *fiber.Ctx) error {
+	var req model.CryptoAssetRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := h.service.UpsertAsset(c.UserContext(), req); err != nil {
+		return tickerError(c, err, "Failed to update asset")
+	}
+	return c.JSON(fiber.Map{"message": "Asset updated"})
+}
+
+// DeleteAsset godoc
+// @Summary      Delete a canonical asset and its aliases
+// @Tags         Ticker
+// @Produce      json
+// @Param        id   path      string  true  "Asset ID"
+// @Success      200  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /crm/ticker-assets/{id} [delete]
+// @Security     BearerAuth
+func (h *TickerHandler) DeleteAsset(c *fiber.Ctx) error {
+	if err := h.service.DeleteAsset(c.UserContext(), c.Params("id")); err != nil {
+		return tickerError(c, err, "Failed to delete asset")
+	}
+	return c.JSON(fiber.Map{"message": "Asset deleted"})
+}
+
+// UpsertAlias godoc
+// @Summary      Create or move a ticker alias
+// @Description  Maps an alias (pair, exchange symbol, name) to a canonical asset
+// @Tags         Ticker
+// @Accept       json
+// @Produce      json
+// @Param        body  body      model.TickerAliasRequest  true  "Alias"
+// @Success      200   {object}  map[string]string
+// @Failure      400   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /crm/ticker-aliases [post]
+// @Security     BearerAuth
+func (h *TickerHandler) UpsertAlias(c *fiber.Ctx) error {
+	var req model.TickerAliasRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := h.service.UpsertAlias(c.UserContext(), req); err != nil {
+		return tickerError(c, err, "Failed to update alias")
+	}
+	return c.JSON(fiber.Map{"message": "Alias updated"})
+}
+
+// DeleteAlias godoc
+// @Summary      Delete a ticker alias
+// @Tags         Ticker
+// @Produce      json
+// @Param        alias  path      string  true  "Alias"
+// @Success      200    {object}  map[string]string
+// @Failure      404    {object}  map[string]string
+// @Failure      500    {object}  map[string]string
+// @Router       /crm/ticker-aliases/{alias} [delete]
+// @Security     BearerAuth
+func (h *TickerHandler) DeleteAlias(c *fiber.Ctx) error {
+	if err := h.service.DeleteAlias(c.UserContext(), c.Params("alias")); err != nil {
+		return tickerError(c, err, "Failed to delete alias")
+	}
+	return c.JSON(fiber.Map{"message": "Alias deleted"})
+}
+
+func tickerError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, model.ErrInvalidAssetID),
+		errors.Is(err, model.ErrInvalidTickerAlias),
+		errors.Is(err, model.ErrInvalidTickerAliasKind):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, model.ErrAssetNotFound),
+		errors.Is(err, model.ErrTickerAliasNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+	logger.Errorf("ticker: %s: %v", strings.ToLower(message), err)
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": message})
+}
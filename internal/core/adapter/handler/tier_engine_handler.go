@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
+)
+
+type TierEngineHandler struct {
+	service port.TierEngineService
+}
+
+type TierProposalReviewRequest struct {
+	ID string `json:"id"`
+}
+
+func NewTierEngineHandler(service port.TierEngineService) *TierEngineHandler {
+	return &TierEngineHandler{service: service}
+}
+
+// GetProposals godoc
+// @Summary      List author tier proposals
+// @Description  Returns tier changes proposed by the tier engine, optionally filtered by status (pending, approved, rejected, superseded)
+// @Tags         CRM
+// @Produce      json
+// @Param        status  query     string  false  "Proposal status"
+// @Success      200     {array}   model.AuthorTierProposal
+// @Failure      500     {object}  ErrorResponse
+// @Router       /crm/author-tier/proposals [get]
+// @Security     BearerAuth
+func (h *TierEngineHandler) GetProposals(c *fiber.Ctx) error {
+	proposals, err := h.service.GetProposals(c.UserContext(), c.Query("status", ""))
+	if err != nil {
+		logger.Errorf("tier engine: failed to get proposals: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get tier proposals"})
+	}
+	return c.Status(fiber.StatusOK).JSON(proposals)
+}
+
+// Recompute godoc
+// @Summary      Recompute author tiers
+// @Description  Runs the tier engine immediately and returns the newly proposed tier changes
+// @Tags         CRM
+// @Produce      json
+// @Success      200  {array}   model.AuthorTierProposal
+// @Failure      500  {object}  ErrorResponse
+// @Router       /crm/author-tier/recompute [post]
+// @Security     BearerAuth
+func (h *TierEngineHandler) Recompute(c *fiber.Ctx) error {
+	proposals, err := h.service.Recompute(c.UserContext())
+	if err != nil {
+		logger.Errorf("tier engine: recompute failed: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to recompute author tiers"})
+	}
+	return c.Status(fiber.StatusOK).JSON(proposals)
+}
+
+// ApproveProposal godoc
+// @Summary      Approve author tier proposal
+// @Description  Applies the proposed tier to the author and marks the proposal approved
+// @Tags         CRM
+// @Accept       json
+// @Produce      json
+// @Param        body  body      TierProposalReviewRequest  true  "Proposal ID"
+// @Success      200   {object}  model.AuthorTierProposal
+// @Failure      400   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /crm/author-tier/proposals/approve [post]
+// @Security     BearerAuth
+func (h *TierEngineHandler) ApproveProposal(c *fiber.Ctx) error {
+	return h.review(c, h.service.ApproveProposal)
+}
+
+// RejectProposal godoc
+// @Summary      Reject author tier proposal
+// @Description  Marks the proposal rejected without changing the author tier
+// @Tags         CRM
+// @Accept       json
+// @Produce      json
+// @Param        body  body      TierProposalReviewRequest  true  "Proposal ID"
+// @Success      200   {object}  model.AuthorTierProposal
+// @Failure      400   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /crm/author-tier/proposals/reject [post]
+// @Security     BearerAuth
+func (h *TierEngineHandler) RejectProposal(c *fiber.Ctx) error {
+	return h.review(c, h.service.RejectProposal)
+}
+
+func (h *TierEngineHandler) review(c *fiber.Ctx, apply func(ctx context.Context, id, reviewedBy string) (model.AuthorTierProposal, error)) error {
+	var req TierProposalReviewRequest
+	if err := c.BodyParser(&req); err != nil || req.ID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	reviewer, _ := c.Locals("username").(string)
+
+	proposal, err := apply(c.UserContext(), req.ID, reviewer)
+	if err != nil {
+		switch {
+		case errors.Is(err, model.ErrTierProposalNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+		case errors.Is(err, model.ErrTierProposalNotPending):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+		default:
+			logger.Errorf("tier engine: failed to review proposal %s: %v", req.ID, err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to review tier proposal"})
+		}
+	}
+	return c.Status(fiber.StatusOK).JSON(proposal)
+}
@@ -1,12 +1,8 @@
 package handler
 
 import (
-	"bytes"
-	"encoding/json"
 	"fmt"
-	"io"
 	"log"
-	"net/http"
 	"strconv"
 	"strings"
 	"sync"
@@ -40,25 +36,7 @@ type SymbolRequest struct {
 	Exchange string `json:"exchange"`
 }
 
-type requestPayload struct {
-	Columns             []string `json:"columns"`
-	Markets             []string `json:"markets"`
-	Range               []int    `json:"range"`
-	IgnoreUnknownFields bool     `json:"ignore_unknown_fields"`
-	Sort                struct {
-		SortBy    string `json:"sortBy"`
-		SortOrder string `json:"sortOrder"`
-	} `json:"sort"`
-}
-
-func NewTradingViewHandler(priceService *service.PriceService) *TradingViewHandler {
-	// Initialize the client when creating the handler
-	client, err := tradingview.NewTradingViewClient("", "")
-	if err != nil {
-		// Handle error, perhaps panic or return nil
-		panic(err)
-	}
-
+func NewTradingViewHandler(client *tradingview.TradingViewClient, priceService *service.PriceService) *TradingViewHandler {
 	return &TradingViewHandler{
 		logger:       logger.NewLogger(),
 		client:       client,
@@ -198,19 +176,12 @@ func (h *TradingViewHandler) GetMultiHistoricalData(c *fiber.Ctx) error {
 			defer wg.Done()
 
 			var data []tradingview.HistoricalData
+			var err error
 
 			// Try up to 5 times
 			for attempts := 0; attempts < attemptsCount; attempts++ {
-				client, err := tradingview.NewTradingViewClient("", "")
-				if err != nil {
-					h.logger.Error(fmt.Errorf("attempt %d: failed to create client for %s:%s: %w",
-						attempts+1, sym.Symbol, sym.Exchange, err))
-					time.Sleep(time.Second)
-					continue
-				}
-
 				// Get historical data
-				data, err = client.GetHistoricalData(
+				data, err = h.client.GetHistoricalData(
 					sym.Symbol,
 					sym.Exchange,
 					interval,
@@ -241,17 +212,10 @@ func (h *TradingViewHandler) GetMultiHistoricalData(c *fiber.Ctx) error {
 
 			// last attempt
 			if data == nil {
-				client, err := tradingview.NewTradingViewClient("", "")
-				if err != nil {
-					h.logger.Error(fmt.Errorf("attempt %d: failed to create client for %s:%s: %w",
-						attemptsCount+1, sym.Symbol, sym.Exchange, err))
-					time.Sleep(time.Second)
-				}
-
 				log.Printf("Last attempt for %s:%s", fmt.Sprintf("%s.P", sym.Symbol), sym.Exchange)
 				// Get historical data
 				log.Println(sym.Symbol + ".P")
-				data, err = client.GetHistoricalData(
+				data, err = h.client.GetHistoricalData(
 					sym.Symbol+".P",
 					sym.Exchange,
 					interval,
@@ -334,62 +298,7 @@ func (h *TradingViewHandler) Get24HoursPriceChangeTradingView(c *fiber.Ctx) erro
 		})
 	}
 
-	payload := requestPayload{
-		Columns:             []string{"base_currency", "exchange", "currency", "24h_close_change|5"},
-		Markets:             []string{"coin"},
-		IgnoreUnknownFields: false,
-		Range:               []int{0, 150},
-		Sort: struct {
-			SortBy    string `json:"sortBy"`
-			SortOrder string `json:"sortOrder"`
-		}{
-			SortBy:    "crypto_total_rank",
-			SortOrder: "asc",
-		},
-	}
-
-	requestBody, err := json.Marshal(payload)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to encode request",
-		})
-	}
-
-	response, err := http.Post(
-		"https://scanner.tradingview.com/coin/scan?label-product=screener-coin",
-		"application/json",
-		bytes.NewBuffer(requestBody),
-	)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
-	}
-	defer response.Body.Close()
-
-	body, err := io.ReadAll(response.Body)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
-	}
-
-	var result map[string]interface{}
-	if err := json.Unmarshal(body, &result); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
-	}
-
-	var scannerResponse model.ScannerResponse
-
-	if err := json.Unmarshal(body, &scannerResponse); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to parse scanner response",
-		})
-	}
-
-	priceChangeResults := h.priceService.GetPriceChange(req.Symbols, scannerResponse.Data)
+	priceChangeResults := h.priceService.GetPriceChange(req.Symbols)
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"results": priceChangeResults,
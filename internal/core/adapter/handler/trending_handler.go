@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
+)
+
+type TrendingHandler struct {
+	service port.TrendingService
+}
+
+func NewTrendingHandler(service port.TrendingService) *TrendingHandler {
+	return &TrendingHandler{service: service}
+}
+
+// GetTrending godoc
+// @Summary      Get trending tickers
+// @Description  Ranks the tickers mentioned in the current window by the z-score of their mention velocity against the trailing baseline
+// @Tags         Trending
+// @Produce      json
+// @Param        weight  query     string  false  "Mention weight: none, tier or winrate"  default(tier)
+// @Param        limit   query     int     false  "Maximum number of tickers"  default(50)
+// @Success      200     {object}  model.TrendingResult
+// @Failure      400     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /twitter-crypto/trending [get]
+func (h *TrendingHandler) GetTrending(c *fiber.Ctx) error {
+	result, err := h.service.GetTrending(c.UserContext(), c.Query("weight", model.TrendingWeightTier), c.QueryInt("limit", 50))
+	if err != nil {
+		if errors.Is(err, model.ErrInvalidTrendingWeight) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		logger.Errorf("trending: failed to get trending tickers: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get trending tickers"})
+	}
+	return c.JSON(result)
+}
+
+// GetWatchlist godoc
+// @Summary      Get ticker watchlist
+// @Description  Returns the tickers on the watchlist of the current user
+// @Tags         Trending
+// @Produce      json
+// @Success      200  {array}   model.WatchlistTicker
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /twitter-crypto/watchlist [get]
+// @Security     BearerAuth
+func (h *TrendingHandler) GetWatchlist(c *fiber.Ctx) error {
+	uid, ok := c.Locals("uid").(string)
+	if !ok || uid == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	watchlist, err := h.service.GetWatchlist(c.UserContext(), uid)
+	if err != nil {
+		logger.Errorf("trending: failed to get watchlist for uid=%s: %v", uid, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get watchlist"})
+	}
+	return c.JSON(watchlist)
+}
+
+// UpsertWatchlistTicker godoc
+// @Summary      Add a ticker to the watchlist
+// @Description  Adds a ticker to the watchlist of the current user, or updates whether a Telegram alert is sent when it starts trending
+// @Tags         Trending
+// @Accept       json
+// @Produce      json
+// @Param        body  body      model.WatchlistTickerRequest  true  "Watchlist ticker"
+// @Success      200   {object}  map[string]string
+// @Failure      400   {object}  map[string]string
+// @Failure      401   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /twitter-crypto/watchlist [post]
+// @Security     BearerAuth
+func (h *TrendingHandler) UpsertWatchlistTicker(c *fiber.Ctx) error {
+	uid, ok := c.Locals("uid").(string)
+	if !ok || uid == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	var req model.WatchlistTickerRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := h.service.UpsertWatchlistTicker(c.UserContext(), uid, req.Ticker, req.TrendingAlert); err != nil {
+		if errors.Is(err, model.ErrInvalidWatchlistTicker) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		logger.Errorf("trending: failed to update watchlist for uid=%s: %v", uid, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to update watchlist"})
+	}
+	return c.JSON(fiber.Map{"message": "Watchlist updated"})
+}
+
+// DeleteWatchlistTicker godoc
+// @Summary      Remove a ticker from the watchlist
+// @Tags         Trending
+// @Produce      json
+// @Param        ticker  path      string  true  "Ticker"
+// @Success      200     {object}  map[string]string
+// @Failure      401     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /twitter-crypto/watchlist/{ticker} [delete]
+// @Security     BearerAuth
+func (h *TrendingHandler) DeleteWatchlistTicker(c *fiber.Ctx) error {
+	uid, ok := c.Locals("uid").(string)
+	if !ok || uid == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	if err := h.service.DeleteWatchlistTicker(c.UserContext(), uid, c.Params("ticker")); err != nil {
+		logger.Errorf("trending: failed to remove %s from watchlist for uid=%s: %v", c.Params("ticker"), uid, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to update watchlist"})
+	}
+	return c.JSON(fiber.Map{"message": "Watchlist updated"})
+}
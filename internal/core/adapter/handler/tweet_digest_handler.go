@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
+)
+
+type TweetDigestHandler struct {
+	service port.TweetDigestService
+}
+
+func NewTweetDigestHandler(service port.TweetDigestService) *TweetDigestHandler {
+	return &TweetDigestHandler{service: service}
+}
+
+// GetDigests godoc
+// @Summary      Get tweet digests
+// @Description  Returns the stored daily or weekly digests, latest first
+// @Tags         Tweet Digest
+// @Produce      json
+// @Param        period  query     string  false  "daily or weekly"  default(daily)
+// @Param        limit   query     int     false  "Maximum number of digests, at most 60"  default(10)
+// @Success      200     {array}   model.TweetDigest
+// @Failure      400     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /twitter-crypto/digests [get]
+func (h *TweetDigestHandler) GetDigests(c *fiber.Ctx) error {
+	digests, err := h.service.GetDigests(c.UserContext(), c.Query("period", model.DigestPeriodDaily), c.QueryInt("limit", 0))
+	if err != nil {
+		return tweetDigestError(c, err, "Failed to get digests")
+	}
+	return c.JSON(digests)
+}
+
+// GetDigest godoc
+// @Summary      Get a tweet digest
+// @Description  Returns the digest of the period starting at period_start, or the latest digest
+// @Tags         Tweet Digest
+// @Produce      json
+// @Param        period        query     string  false  "daily or weekly"  default(daily)
+// @Param        period_start  query     string  false  "First day of the period (YYYY-MM-DD, UTC), a Monday for weekly digests"
+// @Success      200           {object}  model.TweetDigest
+// @Failure      400           {object}  map[string]string
+// @Failure      404           {object}  map[string]string
+// @Failure      500           {object}  map[string]string
+// @Router       /twitter-crypto/digests/latest [get]
+func (h *TweetDigestHandler) GetDigest(c *fiber.Ctx) error {
+	var periodStart *time.Time
+	if periodStartStr := c.Query("period_start", ""); periodStartStr != "" {
+		parsed, err := time.Parse("2006-01-02", periodStartStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid period_start format. Use YYYY-MM-DD",
+			})
+		}
+		periodStart = &parsed
+	}
+
+	digest, err := h.service.GetDigest(c.UserContext(), c.Query("period", model.DigestPeriodDaily), periodStart)
+	if err != nil {
+		return tweetDigestError(c, err, "Failed to get digest")
+	}
+	return c.JSON(digest)
+}
+
+// GetSubscriptions godoc
+// @Summary      Get digest subscriptions
+// @Description  Returns the digests the current user receives and through which channel
+// @Tags         Tweet Digest
+// @Produce      json
+// @Success      200  {array}   model.DigestSubscription
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /twitter-crypto/digests/subscriptions [get]
+// @Security     BearerAuth
+func (h *TweetDigestHandler) GetSubscriptions(c *fiber.Ctx) error {
+	uid, ok := c.Locals("uid").(string)
+	if !ok || uid == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	subscriptions, err := h.service.GetSubscriptions(c.UserContext(), uid)
+	if err != nil {
+		return tweetDigestError(c, err, "Failed to get digest subscriptions")
+	}
+	return c.JSON(subscriptions)
+}
+
+// Subscribe godoc
+// @Summary      Subscribe to a digest
+// @Description  Sends the daily or weekly digest to the current user once the period ends, to their linked Telegram chat or account email
+// @Tags         Tweet Digest
+// @Accept       json
+// @Produce      json
+// @Param        body  body      model.DigestSubscriptionRequest  true  "Subscription"
+// @Success      200   {object}  map[string]string
+// @Failure      400   {object}  map[string]string
+// @Failure      401   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /twitter-crypto/digests/subscriptions [post]
+// @Security     BearerAuth
+func (h *TweetDigestHandler) Subscribe(c *fiber.Ctx) error {
+	uid, ok := c.Locals("uid").(string)
+	if !ok || uid == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	var req model.DigestSubscriptionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := h.service.Subscribe(c.UserContext(), uid, req); err != nil {
+		return tweetDigestError(c, err, "Failed to subscribe to digest")
+	}
+	return c.JSON(fiber.Map{"message": "Subscribed"})
+}
+
+// Unsubscribe godoc
+// @Summary      Unsubscribe from a digest
+// @Tags         Tweet Digest
+// @Produce      json
+// @Param        period   path      string  true  "daily or weekly"
+// @Param        channel  path      string  true  "telegram or email"
+// @Success      200      {object}  map[string]string
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /twitter-crypto/digests/subscriptions/{period}/{channel} [delete]
+// @Security     BearerAuth
+func (h *TweetDigestHandler) Unsubscribe(c *fiber.Ctx) error {
+	uid, ok := c.Locals("uid").(string)
+	if !ok || uid == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	req := model.DigestSubscriptionRequest{Period: c.Params("period"), Channel: c.Params("channel")}
+	if err := h.service.Unsubscribe(c.UserContext(), uid, req); err != nil {
+		return tweetDigestError(c, err, "Failed to unsubscribe from digest")
+	}
+	return c.JSON(fiber.Map{"message": "Unsubscribed"})
+}
+
+// BuildDigest godoc
+// @Summary      Build a tweet digest
+// @Description  Rebuilds and stores the digest of an ended period, the last ended period by default
+// @Tags         Tweet Digest
+// @Accept       json
+// @Produce      json
+// @Param        body  body      model.BuildDigestRequest  true  "Period"
+// @Success      200   {object}  model.TweetDigest
+// @Failure      400   {object}  map[string]string
+// @Failure      401   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /crm/digests/build [post]
+// @Security     BearerAuth
+func (h *TweetDigestHandler) BuildDigest(c *fiber.Ctx) error {
+	var req model.BuildDigestRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	digest, err := h.service.BuildDigest(c.UserContext(), req)
+	if err != nil {
+		return tweetDigestError(c, err, "Failed to build digest")
+	}
+	return c.JSON(digest)
+}
+
+func tweetDigestError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, model.ErrInvalidDigestPeriod),
+		errors.Is(err, model.ErrInvalidDigestChannel),
+		errors.Is(err, model.ErrDigestNotComplete):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, model.ErrDigestNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+	logger.Errorf("tweet digest: %s: %v", strings.ToLower(message), err)
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": message})
+}
@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"errors"
+	"slices"
 	"strings"
 	"time"
 
@@ -50,24 +52,23 @@ func (h *TwitterCryptoHandler) GetAuthorProfiles(c *fiber.Ctx) error {
 }
 
 func (h *TwitterCryptoHandler) GetPaginatedTweets(c *fiber.Ctx) error {
-	start := c.QueryInt("start", 0)
-	limit := c.QueryInt("limit", 100)
+	page, err := parseFeedPage(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
 	sortBy := c.Query("sort_by", "tweet_created_at")
 	sortOrder := c.Query("sort_order", "desc")
 
-	tweets, total, err := h.twitterService.GetPaginatedTweets(c.Context(), start, limit, sortBy, sortOrder)
+	tweets, next, total, err := h.twitterService.GetPaginatedTweets(c.Context(), page, sortBy, sortOrder)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return feedError(c, err)
 	}
 
-	return c.JSON(fiber.Map{
+	return c.JSON(feedResponse(fiber.Map{
 		"data":       tweets,
-		"total":      total,
-		"start":      start,
-		"limit":      limit,
 		"sort_by":    sortBy,
 		"sort_order": sortOrder,
-	})
+	}, page, next, total))
 }
 
 func (h *TwitterCryptoHandler) GetPaginatedSentiments(c *fiber.Ctx) error {
@@ -92,24 +93,23 @@ func (h *TwitterCryptoHandler) GetPaginatedSentiments(c *fiber.Ctx) error {
 }
 
 func (h *TwitterCryptoHandler) GetTweetsWithSentiments(c *fiber.Ctx) error {
-	start := c.QueryInt("start", 0)
-	limit := c.QueryInt("limit", 100)
+	page, err := parseFeedPage(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
 	sortBy := c.Query("sort_by", "tweet_created_at")
 	sortOrder := c.Query("sort_order", "desc")
 
-	tweets, total, err := h.twitterService.GetTweetsWithSentiments(c.Context(), start, limit, sortBy, sortOrder)
+	tweets, next, total, err := h.twitterService.GetTweetsWithSentiments(c.Context(), page, sortBy, sortOrder)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return feedError(c, err)
 	}
 
-	return c.JSON(fiber.Map{
+	return c.JSON(feedResponse(fiber.Map{
 		"data":       tweets,
-		"total":      total,
-		"start":      start,
-		"limit":      limit,
 		"sort_by":    sortBy,
 		"sort_order": sortOrder,
-	})
+	}, page, next, total))
 }
 
 func (h *TwitterCryptoHandler) GetTweetsWithSentimentAuthorSignal(c *fiber.Ctx) error {
@@ -184,8 +184,10 @@ func (h *TwitterCryptoHandler) GetTweetsWithSentimentAuthorSignal(c *fiber.Ctx)
 }
 
 func (h *TwitterCryptoHandler) GetTweetsWithSentimentsAndAuthor(c *fiber.Ctx) error {
-	start := c.QueryInt("start", 0)
-	limit := c.QueryInt("limit", 100)
+	page, err := parseFeedPage(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
 	sortBy := c.Query("sort_by", "tweet_created_at")
 	sortOrder := c.Query("sort_order", "desc")
 	searchTokenSymbolValue := c.Query("search", "")
@@ -227,10 +229,9 @@ func (h *TwitterCryptoHandler) GetTweetsWithSentimentsAndAuthor(c *fiber.Ctx) er
 		toDate = &parsedToDate
 	}
 
-	tweets, total, err := h.twitterService.GetTweetsWithSentimentsAndAuthor(
+	tweets, next, total, err := h.twitterService.GetTweetsWithSentimentsAndAuthor(
 		c.Context(),
-		start,
-		limit,
+		page,
 		sortBy,
 		sortOrder,
 		authors,
@@ -239,26 +240,116 @@ func (h *TwitterCryptoHandler) GetTweetsWithSentimentsAndAuthor(c *fiber.Ctx) er
 		searchTokenSymbolValue,
 	)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return feedError(c, err)
 	}
 
-	return c.JSON(fiber.Map{
+	return c.JSON(feedResponse(fiber.Map{
 		"data":       tweets,
-		"total":      total,
-		"start":      start,
-		"limit":      limit,
 		"sort_by":    sortBy,
 		"sort_order": sortOrder,
 		"authors":    authors,
 		"from_date":  fromDate,
 		"to_date":    toDate,
 		"search":     searchTokenSymbolValue,
-	})
+	}, page, next, total))
 }
 
-func (h *TwitterCryptoHandler) GetTweetsWithSentimentsAndTier(c *fiber.Ctx) error {
+func (h *TwitterCryptoHandler) SearchTweets(c *fiber.Ctx) error {
+	query := strings.TrimSpace(c.Query("q", ""))
+	if query == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "q is required"})
+	}
+
 	start := c.QueryInt("start", 0)
 	limit := c.QueryInt("limit", 100)
+	sortBy := c.Query("sort_by", "relevance")
+	if sortBy != "relevance" && sortBy != "recency" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid sort_by. Use relevance or recency",
+		})
+	}
+	sentiment := c.Query("sentiment", "")
+	searchTokenSymbolValue := c.Query("search", "")
+
+	tierParam := c.Query("tier", "")
+	authors, ok, err := h.queryAuthors(c)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	if !ok {
+		return c.JSON(fiber.Map{
+			"data":    []model.TwitterCryptoTweetSearchResult{},
+			"total":   0,
+			"start":   start,
+			"limit":   limit,
+			"sort_by": sortBy,
+			"q":       query,
+		})
+	}
+
+	// Parse date parameters
+	var fromDate, toDate *time.Time
+	fromDateStr := c.Query("from_date", "")
+	toDateStr := c.Query("to_date", "")
+
+	if fromDateStr != "" {
+		parsedFromDate, err := time.Parse("2006-01-02", fromDateStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid from_date format. Use YYYY-MM-DD",
+			})
+		}
+		fromDate = &parsedFromDate
+	}
+
+	if toDateStr != "" {
+		parsedToDate, err := time.Parse("2006-01-02", toDateStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid to_date format. Use YYYY-MM-DD",
+			})
+		}
+		// Set to end of day
+		parsedToDate = parsedToDate.Add(24*time.Hour - time.Second)
+		toDate = &parsedToDate
+	}
+
+	tweets, total, err := h.twitterService.SearchTweets(c.Context(), model.TweetSearchQuery{
+		Query:                  query,
+		Start:                  start,
+		Limit:                  limit,
+		SortBy:                 sortBy,
+		Authors:                authors,
+		Sentiment:              sentiment,
+		FromDate:               fromDate,
+		ToDate:                 toDate,
+		SearchTokenSymbolValue: searchTokenSymbolValue,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"data":      tweets,
+		"total":     total,
+		"start":     start,
+		"limit":     limit,
+		"sort_by":   sortBy,
+		"q":         query,
+		"authors":   authors,
+		"tier":      tierParam,
+		"sentiment": sentiment,
+		"from_date": fromDate,
+		"to_date":   toDate,
+		"search":    searchTokenSymbolValue,
+	})
+}
+
+func (h *TwitterCryptoHandler) GetTweetsWithSentimentsAndTier(c *fiber.Ctx) error {
+	page, err := parseFeedPage(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
 	sortBy := c.Query("sort_by", "tweet_created_at")
 	sortOrder := c.Query("sort_order", "desc")
 
@@ -304,10 +395,9 @@ func (h *TwitterCryptoHandler) GetTweetsWithSentimentsAndTier(c *fiber.Ctx) erro
 		toDate = &parsedToDate
 	}
 
-	tweets, total, err := h.twitterService.GetTweetsWithSentimentsAndTier(
+	tweets, next, total, err := h.twitterService.GetTweetsWithSentimentsAndTier(
 		c.Context(),
-		start,
-		limit,
+		page,
 		sortBy,
 		sortOrder,
 		authors,
@@ -315,25 +405,23 @@ func (h *TwitterCryptoHandler) GetTweetsWithSentimentsAndTier(c *fiber.Ctx) erro
 		toDate,
 	)
 	if err != nil {
-
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return feedError(c, err)
 	}
 
-	return c.JSON(fiber.Map{
+	return c.JSON(feedResponse(fiber.Map{
 		"data":       tweets,
-		"total":      total,
-		"start":      start,
-		"limit":      limit,
 		"sort_by":    sortBy,
 		"sort_order": sortOrder,
 		"from_date":  fromDate,
 		"to_date":    toDate,
-	})
+	}, page, next, total))
 }
 
 func (h *TwitterCryptoHandler) GetSummaries(c *fiber.Ctx) error {
-	start := c.QueryInt("start", 0)
-	limit := c.QueryInt("limit", 100)
+	page, err := parseFeedPage(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
 	sortBy := c.Query("sort_by", "date_time")
 	sortOrder := c.Query("sort_order", "desc")
 
@@ -364,21 +452,18 @@ func (h *TwitterCryptoHandler) GetSummaries(c *fiber.Ctx) error {
 		toDate = &parsedToDate
 	}
 
-	summaries, total, err := h.twitterService.GetSummaries(c.Context(), start, limit, sortBy, sortOrder, fromDate, toDate)
+	summaries, next, total, err := h.twitterService.GetSummaries(c.Context(), page, sortBy, sortOrder, fromDate, toDate)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return feedError(c, err)
 	}
 
-	return c.JSON(fiber.Map{
+	return c.JSON(feedResponse(fiber.Map{
 		"data":       summaries,
-		"total":      total,
-		"start":      start,
-		"limit":      limit,
 		"sort_by":    sortBy,
 		"sort_order": sortOrder,
 		"from_date":  fromDate,
 		"to_date":    toDate,
-	})
+	}, page, next, total))
 }
 
 func (h *TwitterCryptoHandler) GetBubbleSentiment(c *fiber.Ctx) error {
@@ -391,6 +476,76 @@ func (h *TwitterCryptoHandler) GetBubbleSentiment(c *fiber.Ctx) error {
 	})
 }
 
+func (h *TwitterCryptoHandler) GetSentimentTimeSeries(c *fiber.Ctx) error {
+	var tickers []string
+	for _, ticker := range strings.Split(c.Query("tickers", ""), ",") {
+		if ticker = strings.TrimSpace(ticker); ticker != "" {
+			tickers = append(tickers, ticker)
+		}
+	}
+	bucket := c.Query("bucket", "1h")
+
+	// Default to the last 7 days
+	toDate := time.Now().UTC()
+	fromDate := toDate.AddDate(0, 0, -7)
+	if fromDateStr := c.Query("from_date", ""); fromDateStr != "" {
+		parsedFromDate, err := time.Parse("2006-01-02", fromDateStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid from_date format. Use YYYY-MM-DD",
+			})
+		}
+		fromDate = parsedFromDate
+	}
+	if toDateStr := c.Query("to_date", ""); toDateStr != "" {
+		parsedToDate, err := time.Parse("2006-01-02", toDateStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid to_date format. Use YYYY-MM-DD",
+			})
+		}
+		// Include the whole day
+		toDate = parsedToDate.Add(24 * time.Hour)
+	}
+
+	authors, ok, err := h.queryAuthors(c)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	if !ok {
+		// Nobody matches both the authors and the tier, an impossible author keeps every bucket at zero
+		authors = []string{""}
+	}
+
+	series, err := h.twitterService.GetSentimentTimeSeries(c.Context(), model.SentimentTimeSeriesQuery{
+		Tickers: tickers,
+		Bucket:  bucket,
+		From:    fromDate,
+		To:      toDate,
+		Authors: authors,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, model.ErrInvalidSentimentBucket),
+			errors.Is(err, model.ErrSentimentTickersRequired),
+			errors.Is(err, model.ErrSentimentTooManyTickers),
+			errors.Is(err, model.ErrSentimentRangeTooLarge),
+			errors.Is(err, model.ErrSentimentInvalidRange):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"data":      series,
+		"bucket":    bucket,
+		"from_date": fromDate,
+		"to_date":   toDate,
+		"authors":   authors,
+		"tier":      c.Query("tier", ""),
+	})
+}
+
 func (h *TwitterCryptoHandler) SearchTokenMentionSymbolsByAuthors(c *fiber.Ctx) error {
 	symbol := c.Query("symbol", "")
 	authors := c.Query("authors", "")
@@ -427,6 +582,11 @@ func (h *TwitterCryptoHandler) SearchTokenMentionSymbolsByAuthors(c *fiber.Ctx)
 		authorsList,
 	)
 
+	if errors.Is(err, model.ErrInvalidFeedCursor) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid created_at cursor. Use the next_cursor of the previous page",
+		})
+	}
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
@@ -452,3 +612,73 @@ func (h *TwitterCryptoHandler) GetAllTiers(c *fiber.Ctx) error {
 	}
 	return c.JSON(tiers)
 }
+
+// queryAuthors reads the authors query parameter, narrowed to the authors of the tier parameter when one is given.
+// ok is false when a tier is given and none of its authors are left, so the caller can answer with an empty result.
+func (h *TwitterCryptoHandler) queryAuthors(c *fiber.Ctx) (authors []string, ok bool, err error) {
+	if authorsParam := c.Query("authors", ""); authorsParam != "" {
+		authors = strings.Split(authorsParam, ",")
+		for i, author := range authors {
+			authors[i] = strings.TrimSpace(author)
+		}
+	}
+
+	tierParam := c.Query("tier", "")
+	if tierParam == "" {
+		return authors, true, nil
+	}
+	authorList, err := h.authorTierService.GetAuthorsByTier(tierParam)
+	if err != nil {
+		return nil, false, err
+	}
+	tierAuthors := make([]string, 0, len(authorList))
+	for _, author := range authorList {
+		author = strings.TrimSpace(author)
+		if len(authors) == 0 || slices.Contains(authors, author) {
+			tierAuthors = append(tierAuthors, author)
+		}
+	}
+	return tierAuthors, len(tierAuthors) > 0, nil
+}
+
+// parseFeedPage reads offset (start) or keyset (cursor) pagination from the query string. Offset requests count the
+// total unless include_total=false, cursor requests only count it with include_total=true.
+func parseFeedPage(c *fiber.Ctx) (model.FeedPage, error) {
+	page := model.FeedPage{
+		Start: c.QueryInt("start", 0),
+		Limit: c.QueryInt("limit", 100),
+	}
+	if token := c.Query("cursor", ""); token != "" {
+		cursor, err := model.DecodeFeedCursor(token)
+		if err != nil {
+			return page, err
+		}
+		page.Cursor = cursor
+	}
+	page.WithTotal = c.QueryBool("include_total", page.Cursor == nil)
+	return page, nil
+}
+
+// feedResponse adds the pagination fields to a feed response. next_cursor is empty on the last page.
+func feedResponse(response fiber.Map, page model.FeedPage, next *model.FeedCursor, total int) fiber.Map {
+	if page.WithTotal {
+		response["total"] = total
+	}
+	if page.Cursor == nil {
+		response["start"] = page.Start
+	}
+	response["limit"] = page.Limit
+	response["next_cursor"] = ""
+	if next != nil {
+		response["next_cursor"] = next.Encode()
+	}
+	return response
+}
+
+func feedError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, model.ErrFeedCursorSort) || errors.Is(err, model.ErrFeedCursorSortMismatch) ||
+		errors.Is(err, model.ErrInvalidFeedCursor) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+}
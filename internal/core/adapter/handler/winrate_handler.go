@@ -1,8 +1,12 @@
 package handler
 
 import (
+	"errors"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
 )
 
 type winRateHandler struct {
@@ -22,3 +26,43 @@ func (h *winRateHandler) GetWinRate(c *fiber.Ctx) error {
 	}
 	return c.Status(fiber.StatusOK).JSON(winRate)
 }
+
+// CalculateWinrate godoc
+// @Summary      Calculate author winrates on demand
+// @Description  Evaluates author signals against OHLCV for any set of horizons and date window. rule is direction (close moved the signalled way), min_move (moved at least min_move_pct percent) or sl_tp (take_profit_pct touched before stop_loss_pct). weights blend the horizons and default to equal weights. Signal tickers without candles are listed in missing. Results are cached per parameter set.
+// @Tags         WinRate
+// @Accept       json
+// @Produce      json
+// @Param        body  body      model.WinrateCalcRequest  true  "Calculator parameters"
+// @Success      200   {object}  model.WinrateCalcResult
+// @Failure      400   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Failure      503   {object}  map[string]string
+// @Router       /win-rate/calculate [post]
+// @Security     BearerAuth
+func (h *winRateHandler) CalculateWinrate(c *fiber.Ctx) error {
+	var req model.WinrateCalcRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	result, err := h.winRateService.CalculateWinrate(c.UserContext(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, model.ErrOHLCVUnavailable):
+			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": err.Error()})
+		case errors.Is(err, model.ErrWinrateInvalidRule),
+			errors.Is(err, model.ErrWinrateInvalidHorizon),
+			errors.Is(err, model.ErrWinrateInvalidWeights),
+			errors.Is(err, model.ErrWinrateInvalidDateRange),
+			errors.Is(err, model.ErrWinrateInvalidThresholds):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		default:
+			logger.Errorf("winrate calculator: %v", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to calculate winrate"})
+		}
+	}
+	return c.Status(fiber.StatusOK).JSON(result)
+}
@@ -0,0 +1,272 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
+
+type AuthorHealthRepo struct {
+	db *sqlx.DB
+}
+
+func NewAuthorHealthRepo(db *sqlx.DB) *AuthorHealthRepo {
+	return &AuthorHealthRepo{db: db}
+}
+
+func (r *AuthorHealthRepo) GetAuthorDailyNav(ctx context.Context, since time.Time) (map[string][]model.Nav, error) {
+	return selectAuthorDailyNav(ctx, r.db, since)
+}
+
+// GetAuthorHealth returns the stored health of one author, or of every author when authorUsername is empty
+func (r *AuthorHealthRepo) GetAuthorHealth(ctx context.Context, authorUsername string) ([]model.AuthorHealth, error) {
+	query := `
+		SELECT author_username, status, recent_positive_day_share, baseline_positive_day_share, recent_roi, baseline_roi,
+			recent_drawdown, baseline_drawdown, positive_day_z_score, return_t_stat, drawdown_z_score, evaluated_at,
+			status_changed_at, alerted_at
+		FROM crypto_author_health
+		WHERE ($1 = '' OR author_username = $1)
+		ORDER BY author_username ASC
+	`
+	health := []model.AuthorHealth{}
+	if err := r.db.SelectContext(ctx, &health, query, authorUsername); err != nil {
+		return nil, fmt.Errorf("failed to get author health: %w", err)
+	}
+	return health, nil
+}
+
+func (r *AuthorHealthRepo) UpsertAuthorHealth(ctx context.Context, h model.AuthorHealth) error {
+	query := `
+		INSERT INTO crypto_author_health (
+			author_username, status, recent_positive_day_share, baseline_positive_day_share, recent_roi, baseline_roi,
+			recent_drawdown, baseline_drawdown, positive_day_z_score, return_t_stat, drawdown_z_score, evaluated_at,
+			status_changed_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $12)
+		ON CONFLICT (author_username) DO UPDATE SET
+			status = EXCLUDED.status,
+			recent_positive_day_share = EXCLUDED.recent_positive_day_share,
+			baseline_positive_day_share = EXCLUDED.baseline_positive_day_share,
+			recent_roi = EXCLUDED.recent_roi,
+			baseline_roi = EXCLUDED.baseline_roi,
+			recent_drawdown = EXCLUDED.recent_drawdown,
+			baseline_drawdown = EXCLUDED.baseline_drawdown,
+			positive_day_z_score = EXCLUDED.positive_day_z_score,
+			return_t_stat = EXCLUDED.return_t_stat,
+			drawdown_z_score = EXCLUDED.drawdown_z_score,
+			evaluated_at = EXCLUDED.evaluated_at,
+			status_changed_at = CASE
+				WHEN crypto_author_health.status = EXCLUDED.status THEN crypto_author_health.status_changed_at
+				ELSE EXCLUDED.evaluated_at
+			END,
+			alerted_at = CASE
+				WHEN crypto_author_health.status = EXCLUDED.status THEN crypto_author_health.alerted_at
+			END
+	`
+	if _, err := r.db.ExecContext(ctx, query,
+		h.AuthorUsername, h.Status, h.RecentPositiveDayShare, h.BaselinePositiveDayShare, h.RecentROI, h.BaselineROI,
+		h.RecentDrawdown, h.BaselineDrawdown, h.PositiveDayZScore, h.ReturnTStat, h.DrawdownZScore, h.EvaluatedAt,
+	); err != nil {
+		return &ErrDatabaseOperation{Operation: "upsert author health", Err: err}
+	}
+	return nil
+}
+
+// MarkAuthorHealthAlerted records that the subscribers were alerted of the author's current status
+func (r *AuthorHealthRepo) MarkAuthorHealthAlerted(ctx context.Context, authorUsername string, alertedAt time.Time) error {
+	query := `UPDATE crypto_author_health SET alerted_at = $2 WHERE author_username = $1`
+	if _, err := r.db.ExecContext(ctx, query, authorUsername, alertedAt); err != nil {
+		return &ErrDatabaseOperation{Operation: "mark author health alerted", Err: err}
+	}
+	return nil
+}
+
+// GetAlertedSubscribers returns the uuid of the users alerted about the author since the given time
+func (r *AuthorHealthRepo) GetAlertedSubscribers(ctx context.Context, authorUsername string, since time.Time) (map[string]bool, error) {
+	query := `
+		SELECT u.uuid
+		FROM crypto_author_health_alert a
+		INNER JOIN crypto_user u ON u.id = a.crypto_user_id
+		WHERE a.author_username = $1 AND a.alerted_at >= $2
+	`
+	var uids []string
+	if err := r.db.SelectContext(ctx, &uids, query, authorUsername, since); err != nil {
+		return nil, fmt.Errorf("failed to get alerted subscribers: %w", err)
+	}
+	alerted := make(map[string]bool, len(uids))
+	for _, uid := range uids {
+		alerted[uid] = true
+	}
+	return alerted, nil
+}
+
+// MarkSubscriberAlerted records that the user was alerted about the author
+func (r *AuthorHealthRepo) MarkSubscriberAlerted(ctx context.Context, authorUsername, uid string, alertedAt time.Time) error {
+	query := `
+		INSERT INTO crypto_author_health_alert (author_username, crypto_user_id, alerted_at)
+		VALUES ($1, (SELECT id FROM crypto_user WHERE uuid = $2), $3)
+		ON CONFLICT (author_username, crypto_user_id) DO UPDATE SET alerted_at = EXCLUDED.alerted_at
+	`
+	if _, err := r.db.ExecContext(ctx, query, authorUsername, uid, alertedAt); err != nil {
+		return &ErrDatabaseOperation{Operation: "mark subscriber alerted", Err: err}
+	}
+	return nil
+}
+
+// GetAuthorSubscribers returns every active privy, CEX and DEX wallet subscribed to the author
+func (r *AuthorHealthRepo) GetAuthorSubscribers(ctx context.Context, authorUsername string) ([]model.AuthorSubscriber, error) {
+	query := `
+		SELECT
+			u.uuid,
+			w.id::text AS wallet_id,
+			COALESCE(w.wallet_name, '') AS wallet_name,
+			COALESCE(p.auto_pause, false) AS auto_pause
+		FROM crypto_copytrade_authors_privy a
+		INNER JOIN (
+			SELECT id, crypto_user_id, wallet_name FROM crypto_copytrade_wallet_privy WHERE deleted_at IS NULL
+			UNION ALL
+			SELECT id, crypto_user_id, wallet_name FROM crypto_copytrade_wallet_cex WHERE deleted_at IS NULL
+			UNION ALL
+			SELECT id, crypto_user_id, wallet_name FROM crypto_copytrade_wallet_dex WHERE deleted_at IS NULL
+		) w ON w.id = a.crypto_user_wallet_id_privy
+		INNER JOIN crypto_user u ON u.id = w.crypto_user_id
+		LEFT JOIN crypto_author_health_preference p ON p.crypto_user_id = w.crypto_user_id
+		WHERE a.author_username = $1
+	`
+	var subscribers []model.AuthorSubscriber
+	if err := r.db.SelectContext(ctx, &subscribers, query, authorUsername); err != nil {
+		return nil, fmt.Errorf("failed to get author subscribers: %w", err)
+	}
+	return subscribers, nil
+}
+
+// PauseSubscription moves the wallet's subscription out of crypto_copytrade_authors_privy so it can be resumed later
+func (r *AuthorHealthRepo) PauseSubscription(ctx context.Context, walletID, authorUsername, reason string) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return &ErrDatabaseOperation{Operation: "begin pause subscription", Err: err}
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO crypto_copytrade_authors_paused (crypto_user_wallet_id_privy, author_username, reason)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (crypto_user_wallet_id_privy, author_username) DO NOTHING
+	`, walletID, authorUsername, reason); err != nil {
+		return &ErrDatabaseOperation{Operation: "insert paused subscription", Err: err}
+	}
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM crypto_copytrade_authors_privy
+		WHERE crypto_user_wallet_id_privy = $1
+		AND author_username = $2
+	`, walletID, authorUsername); err != nil {
+		return &ErrDatabaseOperation{Operation: "delete subscription", Err: err}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return &ErrDatabaseOperation{Operation: "commit pause subscription", Err: err}
+	}
+	return nil
+}
+
+func (r *AuthorHealthRepo) GetPausedSubscriptions(ctx context.Context, uid string) ([]model.PausedSubscription, error) {
+	query := `
+		SELECT p.crypto_user_wallet_id_privy::text AS wallet_id, p.author_username, p.paused_at
+		FROM crypto_copytrade_authors_paused p
+		INNER JOIN (
+			SELECT id, crypto_user_id FROM crypto_copytrade_wallet_privy WHERE deleted_at IS NULL
+			UNION ALL
+			SELECT id, crypto_user_id FROM crypto_copytrade_wallet_cex WHERE deleted_at IS NULL
+			UNION ALL
+			SELECT id, crypto_user_id FROM crypto_copytrade_wallet_dex WHERE deleted_at IS NULL
+		) w ON w.id = p.crypto_user_wallet_id_privy
+		WHERE w.crypto_user_id = (SELECT id FROM crypto_user WHERE uuid = $1)
+		ORDER BY p.paused_at DESC
+	`
+	paused := []model.PausedSubscription{}
+	if err := r.db.SelectContext(ctx, &paused, query, uid); err != nil {
+		return nil, fmt.Errorf("failed to get paused subscriptions: %w", err)
+	}
+	return paused, nil
+}
+
+// ResumeSubscription restores a paused subscription of a wallet owned by the user
+func (r *AuthorHealthRepo) ResumeSubscription(ctx context.Context, uid, walletID, authorUsername string) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return &ErrDatabaseOperation{Operation: "begin resume subscription", Err: err}
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		DELETE FROM crypto_copytrade_authors_paused p
+		WHERE p.crypto_user_wallet_id_privy = $1
+		AND p.author_username = $2
+		AND p.crypto_user_wallet_id_privy IN (
+			SELECT id FROM crypto_copytrade_wallet_privy WHERE crypto_user_id = (SELECT id FROM crypto_user WHERE uuid = $3)
+			UNION ALL
+			SELECT id FROM crypto_copytrade_wallet_cex WHERE crypto_user_id = (SELECT id FROM crypto_user WHERE uuid = $3)
+			UNION ALL
+			SELECT id FROM crypto_copytrade_wallet_dex WHERE crypto_user_id = (SELECT id FROM crypto_user WHERE uuid = $3)
+		)
+	`, walletID, authorUsername, uid)
+	if err != nil {
+		return &ErrDatabaseOperation{Operation: "delete paused subscription", Err: err}
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return &ErrDatabaseOperation{Operation: "delete paused subscription", Err: err}
+	}
+	if affected == 0 {
+		return model.ErrPausedSubscriptionNotFound
+	}
+
+	// The user may have subscribed to the author again while the subscription was paused
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO crypto_copytrade_authors_privy (crypto_user_wallet_id_privy, author_username)
+		VALUES ($1, $2)
+		ON CONFLICT (crypto_user_wallet_id_privy, author_username) DO NOTHING
+	`, walletID, authorUsername); err != nil {
+		return &ErrDatabaseOperation{Operation: "restore subscription", Err: err}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return &ErrDatabaseOperation{Operation: "commit resume subscription", Err: err}
+	}
+	return nil
+}
+
+func (r *AuthorHealthRepo) GetPreference(ctx context.Context, uid string) (model.AuthorHealthPreference, error) {
+	query := `
+		SELECT p.auto_pause
+		FROM crypto_author_health_preference p
+		WHERE p.crypto_user_id = (SELECT id FROM crypto_user WHERE uuid = $1)
+	`
+	var pref model.AuthorHealthPreference
+	if err := r.db.GetContext(ctx, &pref, query, uid); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return pref, nil
+		}
+		return pref, fmt.Errorf("failed to get author health preference: %w", err)
+	}
+	return pref, nil
+}
+
+func (r *AuthorHealthRepo) UpdatePreference(ctx context.Context, uid string, autoPause bool) error {
+	query := `
+		INSERT INTO crypto_author_health_preference (crypto_user_id, auto_pause)
+		VALUES ((SELECT id FROM crypto_user WHERE uuid = $1), $2)
+		ON CONFLICT (crypto_user_id) DO UPDATE SET
+			auto_pause = EXCLUDED.auto_pause,
+			updated_at = CURRENT_TIMESTAMP
+	`
+	if _, err := r.db.ExecContext(ctx, query, uid, autoPause); err != nil {
+		return &ErrDatabaseOperation{Operation: "update author health preference", Err: err}
+	}
+	return nil
+}
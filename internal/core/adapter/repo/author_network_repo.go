@@ -0,0 +1,45 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
+
+type AuthorNetworkRepo struct {
+	db *sqlx.DB
+}
+
+func NewAuthorNetworkRepo(db *sqlx.DB) *AuthorNetworkRepo {
+	return &AuthorNetworkRepo{db: db}
+}
+
+// GetAuthorTickerSignals counts the LONG and SHORT signals of every tracked author per canonical ticker since the
+// given time
+func (r *AuthorNetworkRepo) GetAuthorTickerSignals(ctx context.Context, since time.Time) ([]model.AuthorTickerSignal, error) {
+	query := `
+		SELECT
+			t.author_username,
+			COALESCE(p.author_tier, '') AS author_tier,
+			canonical_ticker(s.ticker) AS ticker,
+			COUNT(*) FILTER (WHERE s.action = 'LONG') AS longs,
+			COUNT(*) FILTER (WHERE s.action = 'SHORT') AS shorts
+		FROM twitter_crypto_signal_reviewed s
+		INNER JOIN twitter_crypto_tweets_foxhole t ON s.tweet_id = t.id
+		INNER JOIN twitter_crypto_author_profile p ON p.author_username = t.author_username
+		WHERE p.is_select = true
+		AND t.tweet_created_at >= $1
+		AND s.action IN ('LONG', 'SHORT')
+		AND s.ticker IS NOT NULL
+		AND s.ticker NOT IN ('', 'NONE', 'USDCUSDT')
+		GROUP BY 1, 2, 3
+	`
+	var signals []model.AuthorTickerSignal
+	if err := r.db.SelectContext(ctx, &signals, query, since); err != nil {
+		return nil, fmt.Errorf("failed to get author ticker signals: %w", err)
+	}
+	return signals, nil
+}
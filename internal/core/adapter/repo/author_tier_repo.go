@@ -1,6 +1,9 @@
 package repo
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 
 	"github.com/jmoiron/sqlx"
@@ -66,3 +69,64 @@ func (r *AuthorTierRepo) GetAuthorNameMap() (map[string]string, error) {
 
 	return authorMap, nil
 }
+
+func (r *AuthorTierRepo) UpdateAuthorTier(authorUsername, tier string) error {
+	query := `
+	UPDATE twitter_crypto_author_profile
+	SET author_tier = $1, updated_at = NOW()
+	WHERE author_username = $2
+	`
+	result, err := r.db.Exec(query, tier, authorUsername)
+	if err != nil {
+		return fmt.Errorf("failed to update author tier: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update author tier: %w", err)
+	}
+	if affected == 0 {
+		return &ErrRecordNotFound{Table: "twitter_crypto_author_profile", ID: authorUsername}
+	}
+	return nil
+}
+
+// ReplaceAuthorTier reads the tier it replaces in the same statement, so the previous tier is the one overwritten
+func (r *AuthorTierRepo) ReplaceAuthorTier(ctx context.Context, authorUsername string, tier *string) (*string, error) {
+	query := `
+	UPDATE twitter_crypto_author_profile p
+	SET author_tier = $1, updated_at = NOW()
+	FROM (
+		SELECT author_username, author_tier
+		FROM twitter_crypto_author_profile
+		WHERE author_username = $2
+		FOR UPDATE
+	) old
+	WHERE p.author_username = old.author_username
+	RETURNING old.author_tier
+	`
+	var previous *string
+	if err := r.db.GetContext(ctx, &previous, query, tier, authorUsername); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &ErrRecordNotFound{Table: "twitter_crypto_author_profile", ID: authorUsername}
+		}
+		return nil, fmt.Errorf("failed to replace author tier: %w", err)
+	}
+	return previous, nil
+}
+
+func (r *AuthorTierRepo) RestoreAuthorTier(ctx context.Context, authorUsername string, replaced, previous *string) (bool, error) {
+	query := `
+	UPDATE twitter_crypto_author_profile
+	SET author_tier = $1, updated_at = NOW()
+	WHERE author_username = $2 AND author_tier IS NOT DISTINCT FROM $3
+	`
+	result, err := r.db.ExecContext(ctx, query, previous, authorUsername, replaced)
+	if err != nil {
+		return false, fmt.Errorf("failed to restore author tier: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to restore author tier: %w", err)
+	}
+	return affected > 0, nil
+}
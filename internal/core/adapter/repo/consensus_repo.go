@@ -0,0 +1,122 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
+
+type ConsensusRepo struct {
+	cryptoDB   *sqlx.DB // For crypto_ticker_consensus_snapshot
+	postgresDB *sqlx.DB // For twitter_crypto_* tables
+}
+
+func NewConsensusRepo(cryptoDB *sqlx.DB, postgresDB *sqlx.DB) *ConsensusRepo {
+	return &ConsensusRepo{
+		cryptoDB:   cryptoDB,
+		postgresDB: postgresDB,
+	}
+}
+
+// GetLatestAuthorSignals returns the latest LONG or SHORT signal of every tracked author per ticker since the given
+// time. Empty ticker or tier match everything, tickers are resolved with canonical_ticker.
+func (r *ConsensusRepo) GetLatestAuthorSignals(ctx context.Context, ticker, tier string, since time.Time) ([]model.ConsensusSignal, error) {
+	winrate, err := winrateExpression("overall")
+	if err != nil {
+		return nil, err
+	}
+	query := fmt.Sprintf(`
+		WITH author_winrate AS (
+			SELECT
+				p.author_username,
+				COALESCE(p.author_tier, '') AS author_tier,
+				COALESCE(%s, 0) AS winrate
+			FROM twitter_crypto_author_profile p
+			LEFT JOIN twitter_crypto_backtesting b ON b.author_id = p.author_id
+			WHERE p.is_select = true
+		),
+		latest AS (
+			SELECT DISTINCT ON (t.author_username, 2)
+				t.author_username,
+				canonical_ticker(s.ticker) AS ticker,
+				s.action,
+				t.tweet_created_at AS signal_at,
+				t.id AS tweet_id
+			FROM twitter_crypto_signal_reviewed s
+			INNER JOIN twitter_crypto_tweets_foxhole t ON s.tweet_id = t.id
+			WHERE t.tweet_created_at >= $1
+			AND s.action IN ('LONG', 'SHORT')
+			AND s.ticker IS NOT NULL
+			AND s.ticker NOT IN ('', 'NONE', 'USDCUSDT')
+			AND ($2 = '' OR canonical_ticker(s.ticker) = $2)
+			AND t.author_username IN (SELECT author_username FROM author_winrate)
+			ORDER BY t.author_username, 2, t.tweet_created_at DESC
+		)
+		SELECT
+			l.author_username,
+			w.author_tier,
+			l.ticker,
+			l.action,
+			l.signal_at,
+			l.tweet_id,
+			w.winrate,
+			(SELECT COALESCE(AVG(winrate), 0) FROM author_winrate) AS mean_winrate
+		FROM latest l
+		INNER JOIN author_winrate w ON w.author_username = l.author_username
+		WHERE ($3 = '' OR w.author_tier = $3)
+	`, winrate)
+
+	var signals []model.ConsensusSignal
+	if err := r.postgresDB.SelectContext(ctx, &signals, query, since, ticker, tier); err != nil {
+		return nil, fmt.Errorf("failed to get latest author signals: %w", err)
+	}
+	return signals, nil
+}
+
+func (r *ConsensusRepo) InsertConsensusSnapshots(ctx context.Context, snapshots []model.ConsensusSnapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+
+	tx, err := r.cryptoDB.BeginTxx(ctx, nil)
+	if err != nil {
+		return &ErrDatabaseOperation{Operation: "begin consensus snapshot insert", Err: err}
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO crypto_ticker_consensus_snapshot
+			(ticker, tier, window_hours, score, confidence, long_weight, short_weight, long_authors, short_authors, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+	for _, s := range snapshots {
+		if _, err := tx.ExecContext(ctx, query,
+			s.Ticker, s.Tier, s.WindowHours, s.Score, s.Confidence, s.LongWeight, s.ShortWeight, s.LongAuthors, s.ShortAuthors, s.CreatedAt,
+		); err != nil {
+			return &ErrDatabaseOperation{Operation: "insert consensus snapshot", Err: err}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return &ErrDatabaseOperation{Operation: "commit consensus snapshot insert", Err: err}
+	}
+	return nil
+}
+
+func (r *ConsensusRepo) GetConsensusSnapshots(ctx context.Context, ticker, tier string, windowHours int, from, to time.Time) ([]model.ConsensusSnapshot, error) {
+	query := `
+		SELECT ticker, tier, window_hours, score, confidence, long_weight, short_weight, long_authors, short_authors, created_at
+		FROM crypto_ticker_consensus_snapshot
+		WHERE ticker = $1 AND tier = $2 AND window_hours = $3
+		AND created_at >= $4 AND created_at < $5
+		ORDER BY created_at ASC
+	`
+	snapshots := []model.ConsensusSnapshot{}
+	if err := r.cryptoDB.SelectContext(ctx, &snapshots, query, ticker, tier, windowHours, from, to); err != nil {
+		return nil, fmt.Errorf("failed to get consensus snapshots: %w", err)
+	}
+	return snapshots, nil
+}
@@ -0,0 +1,49 @@
+package repo
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
+
+// feedKeysetClause returns the condition continuing a feed sorted by (createdAtColumn, idColumn) after the cursor.
+// The cursor values are bound to $argCount and $argCount+1.
+func feedKeysetClause(createdAtColumn, idColumn, sortOrder string, argCount int) string {
+	op := "<"
+	if sortOrder == "asc" {
+		op = ">"
+	}
+	return fmt.Sprintf("(%s, %s) %s ($%d, $%d)", createdAtColumn, idColumn, op, argCount, argCount+1)
+}
+
+// feedOffset ignores the offset of cursor requests, the keyset condition already skips the previous pages
+func feedOffset(page model.FeedPage) int {
+	if page.Cursor != nil {
+		return 0
+	}
+	return page.Start
+}
+
+// checkFeedCursor rejects a cursor when the feed is not sorted by its creation time column or when the cursor was
+// issued for another sort, as its keyset would skip or repeat rows
+func checkFeedCursor(page model.FeedPage, timeColumn, sortBy, sortOrder string) error {
+	if page.Cursor == nil {
+		return nil
+	}
+	if sortBy != timeColumn {
+		return model.ErrFeedCursorSort
+	}
+	if page.Cursor.SortBy != sortBy || page.Cursor.SortOrder != sortOrder {
+		return model.ErrFeedCursorSortMismatch
+	}
+	return nil
+}
+
+// nextFeedCursor returns the cursor after the last row of a full page, or nil when the feed is exhausted
+func nextFeedCursor(rows, limit int, createdAt time.Time, id, sortBy, sortOrder string) *model.FeedCursor {
+	if rows == 0 || rows < limit {
+		return nil
+	}
+	return &model.FeedCursor{CreatedAt: createdAt, ID: id, SortBy: sortBy, SortOrder: sortOrder}
+}
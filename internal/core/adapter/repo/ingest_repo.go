@@ -0,0 +1,221 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
+
+type IngestRepo struct {
+	db *sqlx.DB // For ingest_api_key and the twitter_crypto_* tables
+}
+
+func NewIngestRepo(db *sqlx.DB) *IngestRepo {
+	return &IngestRepo{db: db}
+}
+
+func (r *IngestRepo) CreateAPIKey(ctx context.Context, key model.IngestAPIKey, keyHash string) (model.IngestAPIKey, error) {
+	query := `
+		INSERT INTO ingest_api_key (name, key_prefix, key_hash, scopes, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, name, key_prefix, scopes, created_by, created_at, last_used_at, revoked_at
+	`
+	var created model.IngestAPIKey
+	if err := r.db.GetContext(ctx, &created, query, key.Name, key.KeyPrefix, keyHash, key.Scopes, key.CreatedBy); err != nil {
+		return model.IngestAPIKey{}, &ErrDatabaseOperation{Operation: "create ingest API key", Err: err}
+	}
+	return created, nil
+}
+
+func (r *IngestRepo) GetAPIKeys(ctx context.Context) ([]model.IngestAPIKey, error) {
+	query := `
+		SELECT id, name, key_prefix, scopes, created_by, created_at, last_used_at, revoked_at
+		FROM ingest_api_key
+		ORDER BY created_at DESC
+	`
+	keys := []model.IngestAPIKey{}
+	if err := r.db.SelectContext(ctx, &keys, query); err != nil {
+		return nil, fmt.Errorf("failed to get ingest API keys: %w", err)
+	}
+	return keys, nil
+}
+
+func (r *IngestRepo) RevokeAPIKey(ctx context.Context, id string) error {
+	// Compare as text so a malformed ID is a miss rather than a cast error
+	result, err := r.db.ExecContext(ctx, `UPDATE ingest_api_key SET revoked_at = NOW() WHERE id::text = $1 AND revoked_at IS NULL`, id)
+	if err != nil {
+		return &ErrDatabaseOperation{Operation: "revoke ingest API key", Err: err}
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return model.ErrIngestAPIKeyNotFound
+	}
+	return nil
+}
+
+func (r *IngestRepo) UseAPIKey(ctx context.Context, keyHash string) (model.IngestAPIKey, error) {
+	query := `
+		UPDATE ingest_api_key SET last_used_at = NOW()
+		WHERE key_hash = $1 AND revoked_at IS NULL
+		RETURNING id, name, key_prefix, scopes, created_by, created_at, last_used_at, revoked_at
+	`
+	var key model.IngestAPIKey
+	if err := r.db.GetContext(ctx, &key, query, keyHash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.IngestAPIKey{}, model.ErrInvalidIngestAPIKey
+		}
+		return model.IngestAPIKey{}, &ErrDatabaseOperation{Operation: "use ingest API key", Err: err}
+	}
+	return key, nil
+}
+
+// UpsertTweets inserts or overwrites the tweets by ID in one transaction. xmax is 0 only for freshly inserted rows.
+func (r *IngestRepo) UpsertTweets(ctx context.Context, tweets []model.TwitterCryptoTweet) ([]bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, &ErrDatabaseOperation{Operation: "begin tweet upsert", Err: err}
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO twitter_crypto_tweets_foxhole
+			(id, url, text, source, retweetcount, replycount, likecount, quotecount, viewcount, tweet_created_at,
+			bookmarkcount, isreply, conversationid, ispinned, isretweet, isquote, media_url, tweet_created_date,
+			author_username, tickers_rule_based, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, NOW(), NOW())
+		ON CONFLICT (id) DO UPDATE SET
+			url = EXCLUDED.url,
+			text = EXCLUDED.text,
+			source = EXCLUDED.source,
+			retweetcount = EXCLUDED.retweetcount,
+			replycount = EXCLUDED.replycount,
+			likecount = EXCLUDED.likecount,
+			quotecount = EXCLUDED.quotecount,
+			viewcount = EXCLUDED.viewcount,
+			tweet_created_at = EXCLUDED.tweet_created_at,
+			bookmarkcount = EXCLUDED.bookmarkcount,
+			isreply = EXCLUDED.isreply,
+			conversationid = EXCLUDED.conversationid,
+			ispinned = EXCLUDED.ispinned,
+			isretweet = EXCLUDED.isretweet,
+			isquote = EXCLUDED.isquote,
+			media_url = EXCLUDED.media_url,
+			tweet_created_date = EXCLUDED.tweet_created_date,
+			author_username = EXCLUDED.author_username,
+			tickers_rule_based = EXCLUDED.tickers_rule_based,
+			updated_at = NOW()
+		RETURNING (xmax = 0) AS created
+	`
+	created := make([]bool, len(tweets))
+	for i, t := range tweets {
+		if err := tx.GetContext(ctx, &created[i], query,
+			t.ID, t.URL, t.Text, t.Source, t.RetweetCount, t.ReplyCount, t.LikeCount, t.QuoteCount, float64(t.ViewCount), t.TweetCreatedAt,
+			t.BookmarkCount, t.IsReply, t.Conversation, t.IsPinned, t.IsRetweet, t.IsQuote, t.MediaURL, t.TweetCreatedDate,
+			t.AuthorUsername, t.TickersRuleBased,
+		); err != nil {
+			return nil, &ErrDatabaseOperation{Operation: "upsert tweet " + t.ID, Err: err}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, &ErrDatabaseOperation{Operation: "commit tweet upsert", Err: err}
+	}
+	return created, nil
+}
+
+func (r *IngestRepo) UpsertSentiments(ctx context.Context, sentiments []model.TwitterCryptoSentiment) ([]bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, &ErrDatabaseOperation{Operation: "begin sentiment upsert", Err: err}
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO twitter_crypto_sentiment
+			(id, text, author_username, response_score, response_sentiment, response_tickers, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())
+		ON CONFLICT (id) DO UPDATE SET
+			text = EXCLUDED.text,
+			author_username = EXCLUDED.author_username,
+			response_score = EXCLUDED.response_score,
+			response_sentiment = EXCLUDED.response_sentiment,
+			response_tickers = EXCLUDED.response_tickers,
+			updated_at = NOW()
+		RETURNING (xmax = 0) AS created
+	`
+	created := make([]bool, len(sentiments))
+	for i, s := range sentiments {
+		if err := tx.GetContext(ctx, &created[i], query,
+			s.ID, s.Text, s.AuthorUsername, s.ResponseScore, s.ResponseSentiment, s.ResponseTickers,
+		); err != nil {
+			return nil, &ErrDatabaseOperation{Operation: "upsert sentiment " + s.ID, Err: err}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, &ErrDatabaseOperation{Operation: "commit sentiment upsert", Err: err}
+	}
+	return created, nil
+}
+
+// ReplaceSignals swaps the stored signals of each tweet and prompt version for the given set, so resending a tweet
+// never duplicates its signals while other prompt versions stay for comparison. A tweet counts as created when it had
+// no signals of that prompt version before.
+func (r *IngestRepo) ReplaceSignals(ctx context.Context, tweets []model.IngestTweetSignals) ([]bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, &ErrDatabaseOperation{Operation: "begin signal replace", Err: err}
+	}
+	defer tx.Rollback()
+
+	insert := `
+		INSERT INTO twitter_crypto_signal
+			(tweet_id, content, ticker, action, score, sentiment, prompt_version, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, COALESCE($8, NOW()), NOW())
+	`
+	created := make([]bool, len(tweets))
+	for i, t := range tweets {
+		result, err := tx.ExecContext(ctx,
+			`DELETE FROM twitter_crypto_signal WHERE tweet_id = $1 AND COALESCE(prompt_version, '') = $2`, t.TweetID, t.PromptVersion,
+		)
+		if err != nil {
+			return nil, &ErrDatabaseOperation{Operation: "delete signals of tweet " + t.TweetID, Err: err}
+		}
+		if rows, err := result.RowsAffected(); err == nil {
+			created[i] = rows == 0
+		}
+		for _, s := range t.Signals {
+			if _, err := tx.ExecContext(ctx, insert,
+				t.TweetID, s.SignalContent, s.SignalTicker, s.SignalAction, s.SignalScore, s.SignalSentiment, t.PromptVersion, s.SignalCreatedAt,
+			); err != nil {
+				return nil, &ErrDatabaseOperation{Operation: "insert signal of tweet " + t.TweetID, Err: err}
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, &ErrDatabaseOperation{Operation: "commit signal replace", Err: err}
+	}
+	return created, nil
+}
+
+func (r *IngestRepo) GetTweetAuthors(ctx context.Context, tweetIDs []string) (map[string]string, error) {
+	var rows []struct {
+		ID             string `db:"id"`
+		AuthorUsername string `db:"author_username"`
+	}
+	query := `SELECT id, author_username FROM twitter_crypto_tweets_foxhole WHERE id = ANY($1)`
+	if err := r.db.SelectContext(ctx, &rows, query, pq.Array(tweetIDs)); err != nil {
+		return nil, fmt.Errorf("failed to get tweet authors: %w", err)
+	}
+
+	authors := make(map[string]string, len(rows))
+	for _, row := range rows {
+		authors[row.ID] = row.AuthorUsername
+	}
+	return authors, nil
+}
@@ -58,7 +58,7 @@ SELECT
         ELSE 'NEUTRAL'
     END AS sentiment
 FROM 
-    twitter_crypto_signal AS signal
+    twitter_crypto_signal_reviewed AS signal
 JOIN 
     twitter_crypto_tweets_foxhole AS tweets 
     ON signal.tweet_id = tweets.id
@@ -104,7 +104,7 @@ func (r *MarketOverviewRepo) GetMarketOverview(days int, authorTier string) (mod
 						(COUNT(CASE WHEN signal.sentiment = 'Bullish' THEN 1 END) + COUNT(CASE WHEN signal.sentiment = 'Bearish' THEN 1 END)))
 				ELSE 50
 			END as bias_percentage
-		FROM twitter_crypto_signal signal
+		FROM twitter_crypto_signal_reviewed signal
 		JOIN twitter_crypto_tweets_foxhole tweets ON signal.tweet_id = tweets.id
 		JOIN twitter_crypto_author_profile tcap 
 	on tweets.author_username = tcap.author_username 
@@ -190,12 +190,6 @@ FROM aggregated;
 }
 
 func (r *MarketOverviewRepo) GetTokenDetailMarketOverview(token string, days int) ([]model.TokenDetailMarketOverview, error) {
-	// Try both with and without USDT suffix
-	tokenVariations := []string{token}
-	if !strings.HasSuffix(strings.ToUpper(token), "USDT") {
-		tokenVariations = append(tokenVariations, token+"USDT")
-	}
-
 	query := `
 	WITH signal_data AS (
 		SELECT 
@@ -207,10 +201,10 @@ func (r *MarketOverviewRepo) GetTokenDetailMarketOverview(token string, days int
 		COALESCE(ts.action, 'NONE') as signal_action,
 		COALESCE(ts.score, 0) as signal_score,
 		COALESCE(ts.sentiment, '') as signal_sentiment
-		FROM twitter_crypto_signal ts
+		FROM twitter_crypto_signal_reviewed ts
 		WHERE ts.ticker != 'NONE' 
 		AND ts.ticker != 'USDCUSDT'
-		AND (LOWER(ts.ticker) = LOWER($1) OR LOWER(ts.ticker) = LOWER($2))
+		AND canonical_ticker(ts.ticker) = canonical_ticker($1)
 	)
 	SELECT 
 		t.id,
@@ -268,12 +262,10 @@ func (r *MarketOverviewRepo) GetTokenDetailMarketOverview(token string, days int
 	// Format the query with the days parameter
 	formattedQuery := fmt.Sprintf(query, days)
 
-	// fmt.Printf("Token detail query %s params: %s, %s", formattedQuery, tokenVariations[0], tokenVariations[len(tokenVariations)-1])
-
 	var results []model.TokenDetailMarketOverview
 
-	// Try with both token variations
-	err := r.db.Select(&results, formattedQuery, tokenVariations[0], tokenVariations[len(tokenVariations)-1])
+	// Any spelling of the token (BTC, BTCUSDT, XBT) resolves to the same asset
+	err := r.db.Select(&results, formattedQuery, token)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get token detail market overview: %w", err)
 	}
@@ -284,16 +276,10 @@ func (r *MarketOverviewRepo) GetTokenDetailMarketOverview(token string, days int
 			"WHERE t.tweet_created_date >= CURRENT_DATE - INTERVAL '%d days'",
 			"", 1)
 
-		err = r.db.Select(&results, lenientQuery, tokenVariations[0], tokenVariations[len(tokenVariations)-1])
+		err = r.db.Select(&results, lenientQuery, token)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get token detail with lenient query: %w", err)
 		}
-
-		// If still no results, log this for debugging
-		if len(results) == 0 {
-			fmt.Printf("No results found for token %s or %s with any date range\n",
-				tokenVariations[0], tokenVariations[len(tokenVariations)-1])
-		}
 	}
 
 	return results, nil
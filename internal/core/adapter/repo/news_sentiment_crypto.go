@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/quantsmithapp/datastation-backend/internal/core/port"
 	"github.com/quantsmithapp/datastation-backend/internal/model"
 )
@@ -18,6 +19,10 @@ func NewNewsSentimentCryptoRepo(db *sqlx.DB) port.NewsSentimentCryptoRepo {
 	return &NewsSentimentCryptoRepo{db: db}
 }
 
+// newsDateOffset is the offset of the wall clock cryptonewsapi_crypto_news_sentiment.date is stored in (UTC+7)
+const newsDateOffset = 7 * time.Hour
+
+// GetNewsSentiment returns the bubble sentiment of the 24 hours up to date
 func (r *NewsSentimentCryptoRepo) GetNewsSentiment(ctx context.Context, date time.Time) ([]model.NewsSentimentEntities, error) {
 	var data []model.NewsSentimentEntities
 	sentimentQuery := `
@@ -33,7 +38,7 @@ WITH sentiment_counts AS (
             date,
             LOWER(sentiment) as sentiment
         FROM cryptonewsapi_crypto_news_sentiment
-        WHERE date >= NOW() + interval '7 hours' - INTERVAL '30 days'  -- Adjust time range as needed
+        WHERE date >= $1::timestamp - INTERVAL '30 days'  -- Adjust time range as needed
     ) AS expanded_tickers
     GROUP BY ticker, date_trunc('hour', date), sentiment
 ),
@@ -41,8 +46,8 @@ time_series AS (
     SELECT 
         ticker,
         generate_series(
-            date_trunc('hour', NOW() + interval '7 hours' - INTERVAL '30 days'),
-            date_trunc('hour', NOW() + interval '7 hours'),
+            date_trunc('hour', $1::timestamp - INTERVAL '30 days'),
+            date_trunc('hour', $1::timestamp),
             '24 hour'
         ) AS hour_timestamp
     FROM (
@@ -177,13 +182,58 @@ SELECT
 		max_dates_per_coin mdc ON fd.coin = mdc.coin AND fd.date = mdc.max_date
 	WHERE
 		fd.total_mentions >= 5
-		AND fd.original_datetime > NOW() + interval '7 hours' - INTERVAL '1 day'
-		AND fd.original_datetime <= NOW() + interval '7 hours';
+		AND fd.original_datetime > $1::timestamp - INTERVAL '1 day'
+		AND fd.original_datetime <= $1::timestamp;
 	`
 
-	if err := r.db.SelectContext(ctx, &data, sentimentQuery); err != nil {
+	if err := r.db.SelectContext(ctx, &data, sentimentQuery, date.UTC().Add(newsDateOffset)); err != nil {
 		return nil, fmt.Errorf("failed to get news sentiment: %w", err)
 	}
 
 	return data, nil
 }
+
+// GetNewsMentions returns one row per article and canonical ticker it mentions, published in [req.From, req.To) and
+// filtered by req.Tickers and req.Sources when set
+func (r *NewsSentimentCryptoRepo) GetNewsMentions(ctx context.Context, req model.NewsSentimentRequest) ([]model.NewsArticleMention, error) {
+	query := `
+		WITH news AS (
+			SELECT
+				n.id::text AS id,
+				COALESCE(n.title, '') AS title,
+				COALESCE(n.source_name, '') AS source_name,
+				LOWER(COALESCE(n.sentiment, 'neutral')) AS sentiment,
+				n.date - make_interval(secs => $5) AS published_at,
+				n.tickers
+			FROM cryptonewsapi_crypto_news_sentiment n
+			WHERE n.date >= $1 AND n.date < $2
+			AND (cardinality($3::text[]) = 0 OR n.source_name = ANY($3))
+		)
+		SELECT news.id, news.title, news.source_name, news.sentiment, news.published_at, t.ticker
+		FROM news
+		CROSS JOIN LATERAL (
+			SELECT DISTINCT canonical_ticker(coin) AS ticker
+			FROM UNNEST(string_to_array(news.tickers, ',')) AS coin
+		) t
+		WHERE t.ticker <> ''
+		AND (cardinality($4::text[]) = 0 OR t.ticker = ANY($4))
+		ORDER BY news.published_at
+	`
+	mentions := []model.NewsArticleMention{}
+	if err := r.db.SelectContext(ctx, &mentions, query,
+		req.From.UTC().Add(newsDateOffset), req.To.UTC().Add(newsDateOffset),
+		pq.Array(req.Sources), pq.Array(req.Tickers), newsDateOffset.Seconds(),
+	); err != nil {
+		return nil, fmt.Errorf("failed to get news mentions: %w", err)
+	}
+	return mentions, nil
+}
+
+func (r *NewsSentimentCryptoRepo) GetNewsSources(ctx context.Context) ([]string, error) {
+	sources := []string{}
+	query := `SELECT DISTINCT source_name FROM cryptonewsapi_crypto_news_sentiment WHERE source_name <> '' ORDER BY source_name`
+	if err := r.db.SelectContext(ctx, &sources, query); err != nil {
+		return nil, fmt.Errorf("failed to get news sources: %w", err)
+	}
+	return sources, nil
+}
@@ -253,3 +253,22 @@ func (r *NotificationRepo) DisconnectNotification(ctx context.Context, uid strin
 	}
 	return nil
 }
+
+// GetAuthorFollowerTelegrams returns the connected Telegram accounts of the users with the author in a notification group
+func (r *NotificationRepo) GetAuthorFollowerTelegrams(ctx context.Context, authorUsername string) ([]model.Telegram, error) {
+	query := `
+		SELECT DISTINCT
+			u.telegram_chat_id,
+			COALESCE(u.telegram_user_id, '') AS telegram_user_id
+		FROM crypto_notification_authors_list auth
+		INNER JOIN crypto_notification_group grp ON grp.group_id = auth.group_id
+		INNER JOIN crypto_user u ON u.uuid = grp.crypto_user_id
+		WHERE auth.authors_username = $1
+		AND COALESCE(u.telegram_chat_id, '') != ''
+	`
+	var telegrams []model.Telegram
+	if err := r.db.SelectContext(ctx, &telegrams, query, authorUsername); err != nil {
+		return nil, fmt.Errorf("failed to get author follower telegrams: %w", err)
+	}
+	return telegrams, nil
+}
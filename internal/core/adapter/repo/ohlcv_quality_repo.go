@@ -0,0 +1,412 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
+
+// ohlcvQualityRetention is how long scans are kept
+const ohlcvQualityRetention = 30 * 24 * time.Hour
+
+type ohlcvQualityRepo struct {
+	db *sql.DB
+}
+
+func NewOHLCVQualityRepo(db *sql.DB) port.OHLCVQualityRepo {
+	return &ohlcvQualityRepo{db: db}
+}
+
+func (r *ohlcvQualityRepo) GetOHLCVTables(ctx context.Context) ([]model.OHLCVTable, error) {
+	// Continuous aggregates are views, only base tables hold raw candles
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT c.table_name, c.column_name, t.table_type
+		FROM information_schema.columns c
+		JOIN information_schema.tables t ON t.table_schema = c.table_schema AND t.table_name = c.table_name
+		WHERE (c.table_name LIKE 'binance\_%' OR c.table_name LIKE 'forex\_%')
+		AND c.column_name IN ('time', 'bucket')
+		ORDER BY c.table_name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ohlcv tables: %w", err)
+	}
+	defer rows.Close()
+
+	var tables []model.OHLCVTable
+	for rows.Next() {
+		var name, column, tableType string
+		if err := rows.Scan(&name, &column, &tableType); err != nil {
+			return nil, fmt.Errorf("failed to scan ohlcv table: %w", err)
+		}
+		// "time" wins when a table has both
+		if n := len(tables); n > 0 && tables[n-1].Name == name {
+			if column == "time" {
+				tables[n-1].TimeColumn = column
+			}
+			continue
+		}
+		separator := strings.Index(name, "_")
+		table := model.OHLCVTable{
+			Name:       name,
+			DataType:   name[:separator],
+			TimeColumn: column,
+			Writable:   tableType == "BASE TABLE",
+		}
+		table.TimeFrame, _ = model.ParseTimeFrame(name[separator+1:])
+		tables = append(tables, table)
+	}
+	return tables, rows.Err()
+}
+
+func (r *ohlcvQualityRepo) FindGaps(ctx context.Context, table model.OHLCVTable, since time.Time) ([]model.OHLCVQualityFinding, error) {
+	// Steps off the timeframe grid are non-monotonic, not gaps
+	query := fmt.Sprintf(`
+		SELECT ticker, prev, t
+		FROM (
+			SELECT ticker, %[1]s AS t, lag(%[1]s) OVER (PARTITION BY ticker ORDER BY %[1]s) AS prev
+			FROM %[2]s
+			WHERE %[1]s >= $1
+		) s
+		WHERE t - prev > $2 * interval '1 second'
+		AND mod(extract(epoch FROM t - prev)::bigint, $2) = 0
+		ORDER BY ticker, t
+	`, pq.QuoteIdentifier(table.TimeColumn), pq.QuoteIdentifier(table.Name))
+	step := int64(table.TimeFrame / time.Second)
+	rows, err := r.db.QueryContext(ctx, query, since, step)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find gaps in %s: %w", table.Name, err)
+	}
+	defer rows.Close()
+
+	var findings []model.OHLCVQualityFinding
+	for rows.Next() {
+		var ticker string
+		var prev, next time.Time
+		if err := rows.Scan(&ticker, &prev, &next); err != nil {
+			return nil, fmt.Errorf("failed to scan gap: %w", err)
+		}
+		findings = append(findings, model.OHLCVQualityFinding{
+			TableName: table.Name,
+			Ticker:    ticker,
+			Kind:      model.OHLCVIssueGap,
+			StartTime: prev.Add(table.TimeFrame).UTC(),
+			EndTime:   next.Add(-table.TimeFrame).UTC(),
+			Candles:   int(next.Sub(prev)/table.TimeFrame) - 1,
+		})
+	}
+	return findings, rows.Err()
+}
+
+func (r *ohlcvQualityRepo) FindDuplicates(ctx context.Context, table model.OHLCVTable, since time.Time) ([]model.OHLCVQualityFinding, error) {
+	query := fmt.Sprintf(`
+		SELECT ticker, min(t), max(t), count(*), sum(n - 1)
+		FROM (
+			SELECT ticker, %[1]s AS t, count(*) AS n
+			FROM %[2]s
+			WHERE %[1]s >= $1
+			GROUP BY ticker, %[1]s
+			HAVING count(*) > 1
+		) d
+		GROUP BY ticker
+		ORDER BY ticker
+	`, pq.QuoteIdentifier(table.TimeColumn), pq.QuoteIdentifier(table.Name))
+	return r.queryRangeFindings(ctx, table, model.OHLCVIssueDuplicate, query, since)
+}
+
+func (r *ohlcvQualityRepo) FindNonMonotonic(ctx context.Context, table model.OHLCVTable, since time.Time) ([]model.OHLCVQualityFinding, error) {
+	query := fmt.Sprintf(`
+		SELECT ticker, min(t), max(t), count(*), count(*)
+		FROM (
+			SELECT ticker, %[1]s AS t, lag(%[1]s) OVER (PARTITION BY ticker ORDER BY %[1]s) AS prev
+			FROM %[2]s
+			WHERE %[1]s >= $1
+		) s
+		WHERE mod(extract(epoch FROM t - prev)::bigint, $2) <> 0
+		OR t > now() + $2 * interval '1 second'
+		GROUP BY ticker
+		ORDER BY ticker
+	`, pq.QuoteIdentifier(table.TimeColumn), pq.QuoteIdentifier(table.Name))
+	return r.queryRangeFindings(ctx, table, model.OHLCVIssueNonMonotonic, query, since, int64(table.TimeFrame/time.Second))
+}
+
+func (r *ohlcvQualityRepo) FindZeroVolumeRuns(ctx context.Context, table model.OHLCVTable, since time.Time, minRun int) ([]model.OHLCVQualityFinding, error) {
+	// Consecutive candles without volume share the difference of their row numbers
+	query := fmt.Sprintf(`
+		SELECT ticker, min(t), max(t), count(*), count(*)
+		FROM (
+			SELECT ticker, %[1]s AS t, volume,
+				row_number() OVER (PARTITION BY ticker ORDER BY %[1]s)
+				- row_number() OVER (PARTITION BY ticker, volume = 0 ORDER BY %[1]s) AS grp
+			FROM %[2]s
+			WHERE %[1]s >= $1
+		) s
+		WHERE volume = 0
+		GROUP BY ticker, grp
+		HAVING count(*) >= $2
+		ORDER BY ticker, min(t)
+	`, pq.QuoteIdentifier(table.TimeColumn), pq.QuoteIdentifier(table.Name))
+	return r.queryRangeFindings(ctx, table, model.OHLCVIssueZeroVolume, query, since, minRun)
+}
+
+func (r *ohlcvQualityRepo) FindInconsistentCandles(ctx context.Context, table model.OHLCVTable, since time.Time) ([]model.OHLCVQualityFinding, error) {
+	query := fmt.Sprintf(`
+		SELECT ticker, min(%[1]s), max(%[1]s), count(*), count(*)
+		FROM %[2]s
+		WHERE %[1]s >= $1
+		AND (high < low OR open > high OR open < low OR close > high OR close < low OR low <= 0 OR volume < 0)
+		GROUP BY ticker
+		ORDER BY ticker
+	`, pq.QuoteIdentifier(table.TimeColumn), pq.QuoteIdentifier(table.Name))
+	return r.queryRangeFindings(ctx, table, model.OHLCVIssueInconsistent, query, since)
+}
+
+// queryRangeFindings reads rows of ticker, start, end, count of buckets and count of candles
+func (r *ohlcvQualityRepo) queryRangeFindings(ctx context.Context, table model.OHLCVTable, kind string, query string, args ...interface{}) ([]model.OHLCVQualityFinding, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find %s candles in %s: %w", kind, table.Name, err)
+	}
+	defer rows.Close()
+
+	var findings []model.OHLCVQualityFinding
+	for rows.Next() {
+		finding := model.OHLCVQualityFinding{TableName: table.Name, Kind: kind}
+		var buckets int
+		if err := rows.Scan(&finding.Ticker, &finding.StartTime, &finding.EndTime, &buckets, &finding.Candles); err != nil {
+			return nil, fmt.Errorf("failed to scan %s finding: %w", kind, err)
+		}
+		finding.StartTime = finding.StartTime.UTC()
+		finding.EndTime = finding.EndTime.UTC()
+		if kind == model.OHLCVIssueDuplicate {
+			finding.Detail = fmt.Sprintf("%d buckets with more than one candle", buckets)
+		}
+		findings = append(findings, finding)
+	}
+	return findings, rows.Err()
+}
+
+func (r *ohlcvQualityRepo) GetLatestCandleTimes(ctx context.Context, table model.OHLCVTable, since time.Time) (map[string]time.Time, error) {
+	query := fmt.Sprintf(`
+		SELECT ticker, max(%[1]s)
+		FROM %[2]s
+		WHERE %[1]s >= $1
+		GROUP BY ticker
+	`, pq.QuoteIdentifier(table.TimeColumn), pq.QuoteIdentifier(table.Name))
+	rows, err := r.db.QueryContext(ctx, query, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest candles of %s: %w", table.Name, err)
+	}
+	defer rows.Close()
+
+	latest := make(map[string]time.Time)
+	for rows.Next() {
+		var ticker string
+		var t time.Time
+		if err := rows.Scan(&ticker, &t); err != nil {
+			return nil, fmt.Errorf("failed to scan latest candle: %w", err)
+		}
+		latest[ticker] = t.UTC()
+	}
+	return latest, rows.Err()
+}
+
+func (r *ohlcvQualityRepo) InsertCandles(ctx context.Context, table model.OHLCVTable, candles []model.OHLCVData) (int, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO %[2]s (%[1]s, ticker, open, high, low, close, volume)
+		SELECT $1, $2, $3, $4, $5, $6, $7
+		WHERE NOT EXISTS (SELECT 1 FROM %[2]s WHERE ticker = $2 AND %[1]s = $1)
+	`, pq.QuoteIdentifier(table.TimeColumn), pq.QuoteIdentifier(table.Name))
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	inserted := 0
+	for _, candle := range candles {
+		result, err := tx.ExecContext(ctx, query, candle.Time, candle.Ticker, candle.Open, candle.High, candle.Low, candle.Close, candle.Volume)
+		if err != nil {
+			return 0, fmt.Errorf("failed to insert candle into %s: %w", table.Name, err)
+		}
+		n, _ := result.RowsAffected()
+		inserted += int(n)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit candles: %w", err)
+	}
+	return inserted, nil
+}
+
+func (r *ohlcvQualityRepo) SaveQualityRun(ctx context.Context, run model.OHLCVQualityRun, findings []model.OHLCVQualityFinding) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO ohlcv_quality_run (run_at, finished_at, tables, findings, repaired)
+		VALUES ($1, $2, $3, $4, $5)
+	`, run.RunAt, run.FinishedAt, run.Tables, run.Findings, run.Repaired)
+	if err != nil {
+		return fmt.Errorf("failed to save quality run: %w", err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO ohlcv_quality_finding (run_at, table_name, ticker, kind, start_time, end_time, candles, repaired, detail)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare finding insert: %w", err)
+	}
+	defer stmt.Close()
+	for _, f := range findings {
+		if _, err := stmt.ExecContext(ctx, run.RunAt, f.TableName, f.Ticker, f.Kind, f.StartTime, f.EndTime, f.Candles, f.Repaired, f.Detail); err != nil {
+			return fmt.Errorf("failed to save quality finding: %w", err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM ohlcv_quality_run WHERE run_at < $1`, run.RunAt.Add(-ohlcvQualityRetention)); err != nil {
+		return fmt.Errorf("failed to delete old quality runs: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit quality run: %w", err)
+	}
+	return nil
+}
+
+func (r *ohlcvQualityRepo) GetQualityRun(ctx context.Context, runAt *time.Time) (model.OHLCVQualityRun, error) {
+	query := `
+		SELECT run_at, finished_at, tables, findings, repaired
+		FROM ohlcv_quality_run
+		ORDER BY run_at DESC
+		LIMIT 1
+	`
+	var args []interface{}
+	if runAt != nil {
+		query = `
+			SELECT run_at, finished_at, tables, findings, repaired
+			FROM ohlcv_quality_run
+			WHERE run_at = $1
+		`
+		args = append(args, *runAt)
+	}
+
+	var run model.OHLCVQualityRun
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&run.RunAt, &run.FinishedAt, &run.Tables, &run.Findings, &run.Repaired)
+	if errors.Is(err, sql.ErrNoRows) {
+		return run, model.ErrOHLCVQualityRunNotFound
+	}
+	if err != nil {
+		return run, fmt.Errorf("failed to get quality run: %w", err)
+	}
+	run.RunAt = run.RunAt.UTC()
+	run.FinishedAt = run.FinishedAt.UTC()
+	return run, nil
+}
+
+func (r *ohlcvQualityRepo) GetQualityRuns(ctx context.Context, limit int) ([]model.OHLCVQualityRun, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT run_at, finished_at, tables, findings, repaired
+		FROM ohlcv_quality_run
+		ORDER BY run_at DESC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quality runs: %w", err)
+	}
+	defer rows.Close()
+
+	runs := make([]model.OHLCVQualityRun, 0)
+	for rows.Next() {
+		var run model.OHLCVQualityRun
+		if err := rows.Scan(&run.RunAt, &run.FinishedAt, &run.Tables, &run.Findings, &run.Repaired); err != nil {
+			return nil, fmt.Errorf("failed to scan quality run: %w", err)
+		}
+		run.RunAt = run.RunAt.UTC()
+		run.FinishedAt = run.FinishedAt.UTC()
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
+
+func (r *ohlcvQualityRepo) GetQualityFindings(ctx context.Context, filter model.OHLCVQualityFilter) ([]model.OHLCVQualityFinding, error) {
+	where, args := qualityFindingFilter(filter)
+	args = append(args, filter.Limit)
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT id, run_at, table_name, ticker, kind, start_time, end_time, candles, repaired, detail
+		FROM ohlcv_quality_finding
+		WHERE %s
+		ORDER BY table_name, ticker, kind, start_time
+		LIMIT $%d
+	`, where, len(args)), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quality findings: %w", err)
+	}
+	defer rows.Close()
+
+	findings := make([]model.OHLCVQualityFinding, 0)
+	for rows.Next() {
+		var f model.OHLCVQualityFinding
+		if err := rows.Scan(&f.ID, &f.RunAt, &f.TableName, &f.Ticker, &f.Kind, &f.StartTime, &f.EndTime, &f.Candles, &f.Repaired, &f.Detail); err != nil {
+			return nil, fmt.Errorf("failed to scan quality finding: %w", err)
+		}
+		f.RunAt = f.RunAt.UTC()
+		f.StartTime = f.StartTime.UTC()
+		f.EndTime = f.EndTime.UTC()
+		findings = append(findings, f)
+	}
+	return findings, rows.Err()
+}
+
+func (r *ohlcvQualityRepo) CountQualityFindings(ctx context.Context, filter model.OHLCVQualityFilter) (map[string]int, error) {
+	where, args := qualityFindingFilter(filter)
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT kind, count(*)
+		FROM ohlcv_quality_finding
+		WHERE %s
+		GROUP BY kind
+	`, where), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count quality findings: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var kind string
+		var count int
+		if err := rows.Scan(&kind, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan quality finding count: %w", err)
+		}
+		counts[kind] = count
+	}
+	return counts, rows.Err()
+}
+
+// qualityFindingFilter builds the conditions of a filter whose RunAt is set
+func qualityFindingFilter(filter model.OHLCVQualityFilter) (string, []interface{}) {
+	conditions := []string{"run_at = $1"}
+	args := []interface{}{*filter.RunAt}
+	if filter.Table != "" {
+		args = append(args, filter.Table)
+		conditions = append(conditions, fmt.Sprintf("table_name = $%d", len(args)))
+	}
+	if filter.Ticker != "" {
+		args = append(args, filter.Ticker)
+		conditions = append(conditions, fmt.Sprintf("ticker = $%d", len(args)))
+	}
+	if filter.Kind != "" {
+		args = append(args, filter.Kind)
+		conditions = append(conditions, fmt.Sprintf("kind = $%d", len(args)))
+	}
+	return strings.Join(conditions, " AND "), args
+}
@@ -75,18 +75,11 @@ func (r *PerformanceRepo) buildMultiholdingNavQuery(period string, holdingPeriod
 	var query string
 	var args []interface{}
 
-	// Validate holdingPeriod
-	validHoldingPeriods := map[string]bool{
-		"24": true, "48": true, "72": true, "96": true,
-		"120": true, "144": true, "168": true,
-	}
-
-	if !validHoldingPeriods[holdingPeriod] {
-		return "", nil, errors.New("invalid holding period")
-	}
-
 	// Determine the nav column to use based on holdingPeriod
-	navColumn := fmt.Sprintf("nav_%s", holdingPeriod)
+	navColumn, err := multiholdingNavColumn(holdingPeriod)
+	if err != nil {
+		return "", nil, err
+	}
 
 	switch period {
 	case "7D":
@@ -146,6 +139,65 @@ func (r *PerformanceRepo) buildMultiholdingNavQuery(period string, holdingPeriod
 	return query, args, nil
 }
 
+// multiholdingNavColumn validates the holding period and returns its crypto_author_port_nav column
+func multiholdingNavColumn(holdingPeriod string) (string, error) {
+	validHoldingPeriods := map[string]bool{
+		"24": true, "48": true, "72": true, "96": true,
+		"120": true, "144": true, "168": true,
+	}
+
+	if !validHoldingPeriods[holdingPeriod] {
+		return "", model.ErrInvalidHoldingPeriod
+	}
+	return fmt.Sprintf("nav_%s", holdingPeriod), nil
+}
+
+// AuthorNavHistoryRepo returns the full daily NAV history of an author. An empty holdingPeriod reads the
+// single-holding crypto_author_nav, otherwise the matching multiholding column of crypto_author_port_nav is used.
+func (r *PerformanceRepo) AuthorNavHistoryRepo(ctx context.Context, authorUsername string, holdingPeriod string) ([]model.Nav, error) {
+	table := "crypto_author_nav"
+	navColumn := "nav"
+	if holdingPeriod != "" {
+		column, err := multiholdingNavColumn(holdingPeriod)
+		if err != nil {
+			return nil, err
+		}
+		table = "crypto_author_port_nav"
+		navColumn = column
+	}
+
+	query := `
+		SELECT t.day, t.nav
+		FROM (
+			SELECT
+				DATE(datetime) AS day,
+				` + navColumn + ` AS nav,
+				ROW_NUMBER() OVER (PARTITION BY DATE(datetime) ORDER BY datetime DESC) AS rn
+			FROM ` + table + `
+			WHERE author_username = $1
+		) t
+		WHERE t.rn = 1
+		ORDER BY t.day ASC;
+	`
+
+	var rows []struct {
+		Datetime time.Time `db:"day"`
+		Nav      float64   `db:"nav"`
+	}
+	if err := r.cryptoDB.SelectContext(ctx, &rows, query, authorUsername); err != nil {
+		return nil, fmt.Errorf("failed to get nav history for %s: %w", authorUsername, err)
+	}
+
+	navs := make([]model.Nav, 0, len(rows))
+	for _, row := range rows {
+		if math.IsNaN(row.Nav) || math.IsInf(row.Nav, 0) {
+			continue
+		}
+		navs = append(navs, model.Nav{Datetime: row.Datetime, Nav: row.Nav})
+	}
+	return navs, nil
+}
+
 func (r *PerformanceRepo) AuthorNavRepo(ctx context.Context, period string) ([]model.AuthorNav, error) {
 	var (
 		data  []model.AuthorNav
@@ -405,13 +457,13 @@ func (r *PerformanceRepo) AuthorDetailRepo(ctx context.Context, authorUsername s
 		}
 	}
 
-	// 4. Get recent signals with pagination
+	// 4. Get recent signals with pagination, read with reviewer corrections applied and voided signals left out
 	var signals []model.AuthorSignal
 	signalsQuery := `
 		SELECT 
 			s.tweet_id, s.content, s.ticker, s.action, s.score, s.sentiment, s.prompt_version,
 			s.created_at, s.updated_at
-		FROM twitter_crypto_signal s
+		FROM twitter_crypto_signal_reviewed s
 		INNER JOIN twitter_crypto_tweets_foxhole t ON s.tweet_id = t.id
 		WHERE t.author_username = $1
 		ORDER BY s.created_at DESC
@@ -446,7 +498,7 @@ func (r *PerformanceRepo) AuthorDetailRepo(ctx context.Context, authorUsername s
 	var totalSignals int
 	totalSignalsQuery := `
 		SELECT COUNT(*) 
-		FROM twitter_crypto_signal s
+		FROM twitter_crypto_signal_reviewed s
 		INNER JOIN twitter_crypto_tweets_foxhole t ON s.tweet_id = t.id
 		WHERE t.author_username = $1
 	`
@@ -808,13 +860,13 @@ func (r *PerformanceRepo) AuthorMultiholdingDetailRepo(ctx context.Context, auth
 		}
 	}
 
-	// 4. Get recent signals with pagination (same as original method)
+	// 4. Get recent signals with pagination, read with reviewer corrections applied (same as original method)
 	var signals []model.AuthorSignal
 	signalsQuery := `
 		SELECT 
 			s.tweet_id, s.content, s.ticker, s.action, s.score, s.sentiment, s.prompt_version,
 			s.created_at, s.updated_at
-		FROM twitter_crypto_signal s
+		FROM twitter_crypto_signal_reviewed s
 		INNER JOIN twitter_crypto_tweets_foxhole t ON s.tweet_id = t.id
 		WHERE t.author_username = $1
 		ORDER BY s.created_at DESC
@@ -849,7 +901,7 @@ func (r *PerformanceRepo) AuthorMultiholdingDetailRepo(ctx context.Context, auth
 	var totalSignals int
 	totalSignalsQuery := `
 		SELECT COUNT(*) 
-		FROM twitter_crypto_signal s
+		FROM twitter_crypto_signal_reviewed s
 		INNER JOIN twitter_crypto_tweets_foxhole t ON s.tweet_id = t.id
 		WHERE t.author_username = $1
 	`
@@ -1003,7 +1055,7 @@ func (r *PerformanceRepo) GetAuthorSentimentAnalysis(ctx context.Context, author
 		since = now.AddDate(0, 0, -7)
 		query = `
 			SELECT s.ticker, s.sentiment, COUNT(*) as count
-			FROM twitter_crypto_signal s
+			FROM twitter_crypto_signal_reviewed s
 			INNER JOIN twitter_crypto_tweets_foxhole t ON s.tweet_id = t.id
 			WHERE t.author_username = $1 
 			AND t.tweet_created_at >= $2
@@ -1018,7 +1070,7 @@ func (r *PerformanceRepo) GetAuthorSentimentAnalysis(ctx context.Context, author
 		since = now.AddDate(0, -1, 0)
 		query = `
 			SELECT s.ticker, s.sentiment, COUNT(*) as count
-			FROM twitter_crypto_signal s
+			FROM twitter_crypto_signal_reviewed s
 			INNER JOIN twitter_crypto_tweets_foxhole t ON s.tweet_id = t.id
 			WHERE t.author_username = $1 
 			AND t.tweet_created_at >= $2
@@ -1032,7 +1084,7 @@ func (r *PerformanceRepo) GetAuthorSentimentAnalysis(ctx context.Context, author
 	case "ALL":
 		query = `
 			SELECT s.ticker, s.sentiment, COUNT(*) as count
-			FROM twitter_crypto_signal s
+			FROM twitter_crypto_signal_reviewed s
 			INNER JOIN twitter_crypto_tweets_foxhole t ON s.tweet_id = t.id
 			WHERE t.author_username = $1
 			AND s.ticker != ''
@@ -1266,6 +1318,42 @@ func (r *PerformanceRepo) buildAuthorNavData(navMap map[string][]model.Nav, auth
 	return data
 }
 
+// selectAuthorDailyNav returns the last crypto_author_nav value of each day since the given time grouped by author
+func selectAuthorDailyNav(ctx context.Context, db *sqlx.DB, since time.Time) (map[string][]model.Nav, error) {
+	query := `
+		SELECT t.author_username, t.day, t.nav
+		FROM (
+			SELECT
+				author_username,
+				DATE(datetime) AS day,
+				nav,
+				ROW_NUMBER() OVER (PARTITION BY author_username, DATE(datetime) ORDER BY datetime DESC) AS rn
+			FROM crypto_author_nav
+			WHERE datetime >= $1
+		) t
+		WHERE t.rn = 1
+		ORDER BY t.author_username, t.day ASC
+	`
+
+	var rows []struct {
+		AuthorUsername string    `db:"author_username"`
+		Day            time.Time `db:"day"`
+		Nav            float64   `db:"nav"`
+	}
+	if err := db.SelectContext(ctx, &rows, query, since); err != nil {
+		return nil, fmt.Errorf("failed to get author daily nav: %w", err)
+	}
+
+	navMap := make(map[string][]model.Nav)
+	for _, row := range rows {
+		navMap[row.AuthorUsername] = append(navMap[row.AuthorUsername], model.Nav{
+			Datetime: row.Day,
+			Nav:      row.Nav,
+		})
+	}
+	return navMap, nil
+}
+
 // calculateDrawdowns computes maximum and current drawdowns
 func (r *PerformanceRepo) calculateDrawdowns(navs []model.Nav) (peak, maxDrawdown, currentDrawdown float64) {
 	if len(navs) == 0 {
@@ -0,0 +1,112 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
+
+type PromptComparisonRepo struct {
+	db *sqlx.DB // For twitter_crypto_* tables
+}
+
+func NewPromptComparisonRepo(db *sqlx.DB) *PromptComparisonRepo {
+	return &PromptComparisonRepo{db: db}
+}
+
+func (r *PromptComparisonRepo) GetPromptVersions(ctx context.Context, since time.Time) ([]model.PromptVersionStats, error) {
+	query := `
+		SELECT
+			COALESCE(s.prompt_version, '') AS prompt_version,
+			COUNT(*) AS signals,
+			COUNT(DISTINCT s.tweet_id) AS tweets,
+			MIN(s.created_at) AS first_signal_at,
+			MAX(s.created_at) AS last_signal_at,
+			COALESCE(s.prompt_version, '') = COALESCE((SELECT prompt_version FROM twitter_crypto_prompt_version_active), '') AS active
+		FROM twitter_crypto_signal s
+		WHERE s.created_at >= $1
+		GROUP BY 1
+		ORDER BY last_signal_at DESC
+	`
+	versions := []model.PromptVersionStats{}
+	if err := r.db.SelectContext(ctx, &versions, query, since); err != nil {
+		return nil, fmt.Errorf("failed to get prompt versions: %w", err)
+	}
+	return versions, nil
+}
+
+// SetActivePromptVersion makes twitter_crypto_signal_active read the signals of the version wherever it extracted them
+func (r *PromptComparisonRepo) SetActivePromptVersion(ctx context.Context, promptVersion, updatedBy string) error {
+	var exists bool
+	if err := r.db.GetContext(ctx, &exists,
+		`SELECT EXISTS (SELECT 1 FROM twitter_crypto_signal WHERE COALESCE(prompt_version, '') = $1)`, promptVersion,
+	); err != nil {
+		return &ErrDatabaseOperation{Operation: "find prompt version", Err: err}
+	}
+	if !exists {
+		return model.ErrPromptVersionNotFound
+	}
+
+	query := `
+		INSERT INTO twitter_crypto_prompt_version_active (singleton, prompt_version, updated_by, updated_at)
+		VALUES (true, $1, $2, NOW())
+		ON CONFLICT (singleton) DO UPDATE SET
+			prompt_version = EXCLUDED.prompt_version,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = NOW()
+	`
+	if _, err := r.db.ExecContext(ctx, query, promptVersion, updatedBy); err != nil {
+		return &ErrDatabaseOperation{Operation: "set active prompt version", Err: err}
+	}
+	return nil
+}
+
+// promptExtractedCTE selects the tweets posted in [$3, $4) that both prompt versions $1 and $2 extracted. A tweet where
+// a version found no ticker still counts as extracted by it.
+const promptExtractedCTE = `
+	extracted AS (
+		SELECT s.tweet_id
+		FROM twitter_crypto_signal s
+		INNER JOIN twitter_crypto_tweets_foxhole t ON t.id = s.tweet_id
+		WHERE s.prompt_version IN ($1, $2)
+		AND t.tweet_created_at >= $3 AND t.tweet_created_at < $4
+		GROUP BY s.tweet_id
+		HAVING COUNT(DISTINCT s.prompt_version) = 2
+	)
+`
+
+// GetPromptVersionSignals returns the latest signal of each version per tweet and canonical ticker on the tweets both
+// versions extracted, with the number of those tweets
+func (r *PromptComparisonRepo) GetPromptVersionSignals(ctx context.Context, versionA, versionB string, from, to time.Time) ([]model.PromptVersionSignal, int, error) {
+	var shared int
+	countQuery := `WITH ` + promptExtractedCTE + ` SELECT COUNT(*) FROM extracted`
+	if err := r.db.GetContext(ctx, &shared, countQuery, versionA, versionB, from, to); err != nil {
+		return nil, 0, fmt.Errorf("failed to count prompt version tweets: %w", err)
+	}
+
+	query := `
+		WITH ` + promptExtractedCTE + `
+		SELECT DISTINCT ON (s.tweet_id, s.prompt_version, 5)
+			s.tweet_id,
+			t.author_username,
+			t.tweet_created_at,
+			s.prompt_version,
+			canonical_ticker(s.ticker) AS ticker,
+			COALESCE(s.action, 'NONE') AS action
+		FROM twitter_crypto_signal s
+		INNER JOIN extracted e ON e.tweet_id = s.tweet_id
+		INNER JOIN twitter_crypto_tweets_foxhole t ON t.id = s.tweet_id
+		WHERE s.prompt_version IN ($1, $2)
+		AND s.ticker IS NOT NULL
+		AND s.ticker NOT IN ('', 'NONE')
+		ORDER BY s.tweet_id, s.prompt_version, 5, s.created_at DESC
+	`
+	signals := []model.PromptVersionSignal{}
+	if err := r.db.SelectContext(ctx, &signals, query, versionA, versionB, from, to); err != nil {
+		return nil, 0, fmt.Errorf("failed to get prompt version signals: %w", err)
+	}
+	return signals, shared, nil
+}
@@ -87,7 +87,7 @@ func (r *sentimentAnalysisRepo) getOverallSentiment(authorList []string, dateRan
 				ELSE tcs.sentiment
 			END as sentiment,
 			count(tcs.sentiment) as count
-		FROM twitter_crypto_signal tcs
+		FROM twitter_crypto_signal_reviewed tcs
 		JOIN twitter_crypto_tweets_foxhole tct ON tcs.tweet_id = tct.id
 		WHERE tct.author_username = ANY($1)
 		AND tct.tweet_created_at >= CURRENT_DATE - INTERVAL '%s days'
@@ -120,7 +120,7 @@ func (r *sentimentAnalysisRepo) getOverallSentiment(authorList []string, dateRan
 func (r *sentimentAnalysisRepo) getTokenSentiment(authorList []string, dateRange string) ([]model.TokenSentiment, error) {
 	query := fmt.Sprintf(`
 		SELECT 
-			REPLACE(ticker, 'USDT', '') as ticker,
+			canonical_ticker(ticker) as ticker,
 			CASE 
 				WHEN tcs.sentiment = 'Neutral' THEN 'neutral'
 				WHEN tcs.sentiment = 'Bullish' THEN 'positive'
@@ -128,7 +128,7 @@ func (r *sentimentAnalysisRepo) getTokenSentiment(authorList []string, dateRange
 				ELSE tcs.sentiment
 			END as sentiment,
 			count(sentiment) as count
-		FROM twitter_crypto_signal tcs
+		FROM twitter_crypto_signal_reviewed tcs
 		JOIN twitter_crypto_tweets_foxhole tct ON tcs.tweet_id = tct.id
 		WHERE tct.author_username = ANY($1)
 		AND tct.tweet_created_at >= CURRENT_DATE - INTERVAL '%s days'
@@ -136,7 +136,7 @@ func (r *sentimentAnalysisRepo) getTokenSentiment(authorList []string, dateRange
 		AND tcs.sentiment IS NOT null
 		AND tcs.sentiment != 'NaN'
 		AND ticker != 'NONE'
-		GROUP BY REPLACE(ticker, 'USDT', ''), tcs.sentiment 
+		GROUP BY canonical_ticker(ticker), tcs.sentiment
 	`, dateRange)
 
 	var results []model.TickerSentimentQueryResult
@@ -177,8 +177,8 @@ func (r *sentimentAnalysisRepo) getTweetIdsPerSentiment(authorList []string, dat
 			SELECT DISTINCT ON (tcs.tweet_id) 
 				tcs.tweet_id, 
 				tcs.sentiment,
-				REPLACE(tcs.ticker, 'USDT', '') as ticker
-			FROM twitter_crypto_signal tcs
+				canonical_ticker(tcs.ticker) as ticker
+			FROM twitter_crypto_signal_reviewed tcs
 			JOIN twitter_crypto_tweets_foxhole tct ON tcs.tweet_id = tct.id
 			WHERE 
 				tct.author_username = ANY($1)
@@ -7,6 +7,7 @@ import (
 
 	"github.com/quantsmithapp/datastation-backend/internal/constant"
 	"github.com/quantsmithapp/datastation-backend/internal/core/domain"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
 	"github.com/quantsmithapp/datastation-backend/internal/model"
 	"github.com/quantsmithapp/datastation-backend/pkg/logger"
 	"go.uber.org/zap"
@@ -14,14 +15,16 @@ import (
 )
 
 type sentimentCryptoRepo struct {
-	db     *gorm.DB
-	logger logger.Logger
+	db      *gorm.DB
+	tickers port.TickerResolver
+	logger  logger.Logger
 }
 
-func NewSentimentCryptoRepo(db *gorm.DB, logger logger.Logger) domain.SentimentCryptoRepository {
+func NewSentimentCryptoRepo(db *gorm.DB, tickers port.TickerResolver, logger logger.Logger) domain.SentimentCryptoRepository {
 	return &sentimentCryptoRepo{
-		db:     db,
-		logger: logger,
+		db:      db,
+		tickers: tickers,
+		logger:  logger,
 	}
 }
 func (r *sentimentCryptoRepo) GetSentimentAggregateCountRow(ctx context.Context, ticker, timeRange, sourceNames, topics string) (int, error) {
@@ -117,23 +120,11 @@ func (r *sentimentCryptoRepo) GetUniqueTickers(ctx context.Context) ([]model.Tic
 	// Process tickers and create map for deduplication
 	tickerMap := make(map[string]model.TickerInfo)
 	for _, ticker := range rawTickers {
-		// Remove surrounding quotes, spaces, and square brackets
-		ticker = strings.Trim(ticker, "' []")
-		// Remove leading $ if present
-		ticker = strings.TrimPrefix(ticker, "$")
-		// Convert to uppercase
-		ticker = strings.ToUpper(ticker)
-		// Remove any trailing numbers or apostrophes
-		ticker = strings.TrimRight(ticker, "0123456789'")
-
-		// Convert to lowercase for comparison with CRYPTO_TICKERS
-		tickerLower := strings.ToLower(ticker)
-
-		// Only add if it exists in CRYPTO_TICKERS constant
-		if name, exists := constant.CRYPTO_TICKERS[tickerLower]; exists && ticker != "" {
-			tickerMap[ticker] = model.TickerInfo{
-				Symbol: ticker,
-				Name:   name,
+		// Only keep tickers that resolve to a known asset
+		if resolved := r.tickers.ResolveTicker(ticker); resolved.Known {
+			tickerMap[resolved.AssetID] = model.TickerInfo{
+				Symbol: resolved.AssetID,
+				Name:   resolved.Name,
 			}
 		}
 	}
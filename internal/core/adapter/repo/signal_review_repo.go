@@ -0,0 +1,275 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
+
+type SignalReviewRepo struct {
+	db *sqlx.DB // For twitter_crypto_signal_* review tables, next to twitter_crypto_signal
+}
+
+func NewSignalReviewRepo(db *sqlx.DB) *SignalReviewRepo {
+	return &SignalReviewRepo{db: db}
+}
+
+// signalFlagsCTE aggregates the flags per signal
+const signalFlagsCTE = `
+	flags AS (
+		SELECT tweet_id, ticker, prompt_version, COUNT(*) AS flag_count, array_agg(DISTINCT reason ORDER BY reason) AS reasons
+		FROM twitter_crypto_signal_flag
+		GROUP BY tweet_id, ticker, prompt_version
+	)
+`
+
+type storedSignalKey struct {
+	Ticker        string `db:"ticker"`
+	PromptVersion string `db:"prompt_version"`
+}
+
+// storedSignal returns the ticker and prompt version of the signal as stored, matching the given ticker exactly or by
+// canonical ticker so flags work with the ticker as shown in any feed. Without a prompt version the signal shown in
+// the feeds, the one in twitter_crypto_signal_active, is picked.
+func storedSignal(ctx context.Context, tx *sqlx.Tx, tweetID, ticker, promptVersion string) (storedSignalKey, error) {
+	query := `
+		SELECT s.ticker, COALESCE(s.prompt_version, '') AS prompt_version
+		FROM twitter_crypto_signal s
+		WHERE s.tweet_id = $1
+		AND (s.ticker = $2 OR canonical_ticker(s.ticker) = canonical_ticker($2))
+		AND ($3 = '' OR COALESCE(s.prompt_version, '') = $3)
+		ORDER BY
+			(s.ticker = $2) DESC,
+			EXISTS (
+				SELECT 1 FROM twitter_crypto_signal_active a
+				WHERE a.tweet_id = s.tweet_id AND COALESCE(a.prompt_version, '') = COALESCE(s.prompt_version, '')
+			) DESC,
+			s.created_at DESC
+		LIMIT 1
+	`
+	var stored storedSignalKey
+	if err := tx.GetContext(ctx, &stored, query, tweetID, ticker, promptVersion); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return stored, model.ErrSignalNotFound
+		}
+		return stored, &ErrDatabaseOperation{Operation: "find signal", Err: err}
+	}
+	return stored, nil
+}
+
+// FlagSignal records the flag of a user, one per user and signal, and queues the signal for review unless it is
+// already in the queue or reviewed
+func (r *SignalReviewRepo) FlagSignal(ctx context.Context, uid string, flag model.SignalFlagRequest) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return &ErrDatabaseOperation{Operation: "begin signal flag", Err: err}
+	}
+	defer tx.Rollback()
+
+	signal, err := storedSignal(ctx, tx, flag.TweetID, flag.Ticker, flag.PromptVersion)
+	if err != nil {
+		return err
+	}
+
+	flagQuery := `
+		INSERT INTO twitter_crypto_signal_flag (tweet_id, ticker, prompt_version, crypto_user_id, reason, comment)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (tweet_id, ticker, prompt_version, crypto_user_id) DO UPDATE SET
+			reason = EXCLUDED.reason,
+			comment = EXCLUDED.comment,
+			created_at = NOW()
+	`
+	if _, err := tx.ExecContext(ctx, flagQuery, flag.TweetID, signal.Ticker, signal.PromptVersion, uid, flag.Reason, flag.Comment); err != nil {
+		return &ErrDatabaseOperation{Operation: "insert signal flag", Err: err}
+	}
+
+	reviewQuery := `
+		INSERT INTO twitter_crypto_signal_review (tweet_id, ticker, prompt_version, status)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (tweet_id, ticker, prompt_version) DO NOTHING
+	`
+	if _, err := tx.ExecContext(ctx, reviewQuery, flag.TweetID, signal.Ticker, signal.PromptVersion, model.SignalReviewStatusPending); err != nil {
+		return &ErrDatabaseOperation{Operation: "queue signal review", Err: err}
+	}
+
+	auditQuery := `
+		INSERT INTO twitter_crypto_signal_review_audit (tweet_id, ticker, prompt_version, event, actor, reason, note)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	if _, err := tx.ExecContext(ctx, auditQuery,
+		flag.TweetID, signal.Ticker, signal.PromptVersion, model.SignalReviewEventFlagged, uid, flag.Reason, flag.Comment,
+	); err != nil {
+		return &ErrDatabaseOperation{Operation: "insert signal review audit", Err: err}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return &ErrDatabaseOperation{Operation: "commit signal flag", Err: err}
+	}
+	return nil
+}
+
+// GetSignalReviews returns a page of the review queue, most flagged first then oldest first. Empty filters match
+// everything.
+func (r *SignalReviewRepo) GetSignalReviews(ctx context.Context, query model.SignalReviewQuery) ([]model.SignalReviewItem, int, error) {
+	sqlQuery := `
+		WITH ` + signalFlagsCTE + `
+		SELECT
+			r.tweet_id,
+			COALESCE(t.text, '') AS tweet_text,
+			COALESCE(t.url, '') AS tweet_url,
+			t.author_username,
+			t.tweet_created_at,
+			r.ticker,
+			COALESCE(s.action, '') AS action,
+			COALESCE(s.sentiment, '') AS sentiment,
+			s.score,
+			COALESCE(s.content, '') AS content,
+			r.prompt_version,
+			s.created_at AS signal_created_at,
+			r.status,
+			COALESCE(f.flag_count, 0) AS flag_count,
+			COALESCE(f.reasons, '{}') AS reasons,
+			r.corrected_ticker,
+			r.corrected_action,
+			r.corrected_sentiment,
+			r.note,
+			r.reviewed_by,
+			r.reviewed_at,
+			r.updated_at,
+			COUNT(*) OVER() AS total
+		FROM twitter_crypto_signal_review r
+		INNER JOIN twitter_crypto_signal s
+			ON s.tweet_id = r.tweet_id AND s.ticker = r.ticker AND COALESCE(s.prompt_version, '') = r.prompt_version
+		INNER JOIN twitter_crypto_tweets_foxhole t ON t.id = r.tweet_id
+		LEFT JOIN flags f ON f.tweet_id = r.tweet_id AND f.ticker = r.ticker AND f.prompt_version = r.prompt_version
+		WHERE ($1 = '' OR r.status = $1)
+		AND ($2 = '' OR $2 = ANY(f.reasons))
+		AND ($3 = '' OR r.prompt_version = $3)
+		ORDER BY COALESCE(f.flag_count, 0) DESC, r.created_at ASC
+		LIMIT $4 OFFSET $5
+	`
+	var rows []struct {
+		model.SignalReviewItem
+		Total int `db:"total"`
+	}
+	offset := (query.Page - 1) * query.Limit
+	if err := r.db.SelectContext(ctx, &rows, sqlQuery, query.Status, query.Reason, query.PromptVersion, query.Limit, offset); err != nil {
+		return nil, 0, fmt.Errorf("failed to get signal reviews: %w", err)
+	}
+
+	items := make([]model.SignalReviewItem, len(rows))
+	total := 0
+	for i, row := range rows {
+		items[i] = row.SignalReviewItem
+		total = row.Total
+	}
+	return items, total, nil
+}
+
+// ReviewSignal stores the decision of a reviewer, signals that were never flagged can be reviewed too
+func (r *SignalReviewRepo) ReviewSignal(ctx context.Context, decision model.SignalReviewDecision) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return &ErrDatabaseOperation{Operation: "begin signal review", Err: err}
+	}
+	defer tx.Rollback()
+
+	signal, err := storedSignal(ctx, tx, decision.TweetID, decision.Ticker, decision.PromptVersion)
+	if err != nil {
+		return err
+	}
+
+	reviewQuery := `
+		INSERT INTO twitter_crypto_signal_review
+			(tweet_id, ticker, prompt_version, status, corrected_ticker, corrected_action, corrected_sentiment, note, reviewed_by, reviewed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW())
+		ON CONFLICT (tweet_id, ticker, prompt_version) DO UPDATE SET
+			status = EXCLUDED.status,
+			corrected_ticker = EXCLUDED.corrected_ticker,
+			corrected_action = EXCLUDED.corrected_action,
+			corrected_sentiment = EXCLUDED.corrected_sentiment,
+			note = EXCLUDED.note,
+			reviewed_by = EXCLUDED.reviewed_by,
+			reviewed_at = NOW(),
+			updated_at = NOW()
+	`
+	if _, err := tx.ExecContext(ctx, reviewQuery,
+		decision.TweetID, signal.Ticker, signal.PromptVersion, decision.Status,
+		decision.CorrectedTicker, decision.CorrectedAction, decision.CorrectedSentiment, decision.Note, decision.ReviewedBy,
+	); err != nil {
+		return &ErrDatabaseOperation{Operation: "upsert signal review", Err: err}
+	}
+
+	auditQuery := `
+		INSERT INTO twitter_crypto_signal_review_audit
+			(tweet_id, ticker, prompt_version, event, actor, note, corrected_ticker, corrected_action, corrected_sentiment)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	if _, err := tx.ExecContext(ctx, auditQuery,
+		decision.TweetID, signal.Ticker, signal.PromptVersion, decision.Status, decision.ReviewedBy, decision.Note,
+		decision.CorrectedTicker, decision.CorrectedAction, decision.CorrectedSentiment,
+	); err != nil {
+		return &ErrDatabaseOperation{Operation: "insert signal review audit", Err: err}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return &ErrDatabaseOperation{Operation: "commit signal review", Err: err}
+	}
+	return nil
+}
+
+// GetSignalReviewAudit returns the audit trail of a tweet, or of one of its signals when ticker is set
+func (r *SignalReviewRepo) GetSignalReviewAudit(ctx context.Context, tweetID, ticker string) ([]model.SignalReviewAudit, error) {
+	query := `
+		SELECT id, tweet_id, ticker, prompt_version, event, actor, reason, note, corrected_ticker, corrected_action, corrected_sentiment, created_at
+		FROM twitter_crypto_signal_review_audit
+		WHERE tweet_id = $1 AND ($2 = '' OR ticker = $2)
+		ORDER BY created_at ASC, id ASC
+	`
+	audit := []model.SignalReviewAudit{}
+	if err := r.db.SelectContext(ctx, &audit, query, tweetID, ticker); err != nil {
+		return nil, fmt.Errorf("failed to get signal review audit: %w", err)
+	}
+	return audit, nil
+}
+
+// GetSignalTrainingExamples returns the signals reviewed in [from, to) with the extraction and the expected output
+func (r *SignalReviewRepo) GetSignalTrainingExamples(ctx context.Context, from, to time.Time, promptVersion string) ([]model.SignalTrainingExample, error) {
+	query := `
+		WITH ` + signalFlagsCTE + `
+		SELECT
+			r.tweet_id,
+			COALESCE(t.text, '') AS tweet_text,
+			t.author_username,
+			t.tweet_created_at,
+			r.prompt_version,
+			s.ticker AS extracted_ticker,
+			COALESCE(s.action, '') AS extracted_action,
+			COALESCE(s.sentiment, '') AS extracted_sentiment,
+			r.status AS label,
+			CASE WHEN r.status = 'voided' THEN '' ELSE COALESCE(r.corrected_ticker, s.ticker) END AS expected_ticker,
+			CASE WHEN r.status = 'voided' THEN '' ELSE COALESCE(r.corrected_action, s.action, '') END AS expected_action,
+			CASE WHEN r.status = 'voided' THEN '' ELSE COALESCE(r.corrected_sentiment, s.sentiment, '') END AS expected_sentiment,
+			COALESCE(f.reasons, '{}') AS reasons,
+			r.reviewed_at
+		FROM twitter_crypto_signal_review r
+		INNER JOIN twitter_crypto_signal s
+			ON s.tweet_id = r.tweet_id AND s.ticker = r.ticker AND COALESCE(s.prompt_version, '') = r.prompt_version
+		INNER JOIN twitter_crypto_tweets_foxhole t ON t.id = r.tweet_id
+		LEFT JOIN flags f ON f.tweet_id = r.tweet_id AND f.ticker = r.ticker AND f.prompt_version = r.prompt_version
+		WHERE r.status <> 'pending'
+		AND r.reviewed_at >= $1 AND r.reviewed_at < $2
+		AND ($3 = '' OR r.prompt_version = $3)
+		ORDER BY r.reviewed_at ASC
+	`
+	examples := []model.SignalTrainingExample{}
+	if err := r.db.SelectContext(ctx, &examples, query, from, to, promptVersion); err != nil {
+		return nil, fmt.Errorf("failed to get signal training examples: %w", err)
+	}
+	return examples, nil
+}
@@ -0,0 +1,101 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
+
+type TickerRepo struct {
+	db *sqlx.DB // For crypto_asset and crypto_asset_alias, next to the twitter_crypto_* tables
+}
+
+func NewTickerRepo(db *sqlx.DB) *TickerRepo {
+	return &TickerRepo{db: db}
+}
+
+func (r *TickerRepo) GetAssets(ctx context.Context) ([]model.CryptoAsset, error) {
+	query := `
+		SELECT id, name, created_at, updated_at
+		FROM crypto_asset
+		ORDER BY id ASC
+	`
+	assets := []model.CryptoAsset{}
+	if err := r.db.SelectContext(ctx, &assets, query); err != nil {
+		return nil, fmt.Errorf("failed to get crypto assets: %w", err)
+	}
+	return assets, nil
+}
+
+func (r *TickerRepo) GetAliases(ctx context.Context) ([]model.TickerAlias, error) {
+	query := `
+		SELECT alias, asset_id, kind, exchange, created_at
+		FROM crypto_asset_alias
+		ORDER BY asset_id ASC, alias ASC
+	`
+	aliases := []model.TickerAlias{}
+	if err := r.db.SelectContext(ctx, &aliases, query); err != nil {
+		return nil, fmt.Errorf("failed to get ticker aliases: %w", err)
+	}
+	return aliases, nil
+}
+
+func (r *TickerRepo) UpsertAsset(ctx context.Context, asset model.CryptoAssetRequest) error {
+	query := `
+		INSERT INTO crypto_asset (id, name)
+		VALUES ($1, $2)
+		ON CONFLICT (id) DO UPDATE SET
+			name = EXCLUDED.name,
+			updated_at = NOW()
+	`
+	if _, err := r.db.ExecContext(ctx, query, asset.ID, asset.Name); err != nil {
+		return &ErrDatabaseOperation{Operation: "upsert crypto asset", Err: err}
+	}
+	return nil
+}
+
+// DeleteAsset removes the asset with its aliases
+func (r *TickerRepo) DeleteAsset(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM crypto_asset WHERE id = $1`, id)
+	if err != nil {
+		return &ErrDatabaseOperation{Operation: "delete crypto asset", Err: err}
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return model.ErrAssetNotFound
+	}
+	return nil
+}
+
+func (r *TickerRepo) UpsertAlias(ctx context.Context, alias model.TickerAliasRequest) error {
+	query := `
+		INSERT INTO crypto_asset_alias (alias, asset_id, kind, exchange)
+		SELECT $1, a.id, $3, $4
+		FROM crypto_asset a
+		WHERE a.id = $2
+		ON CONFLICT (alias) DO UPDATE SET
+			asset_id = EXCLUDED.asset_id,
+			kind = EXCLUDED.kind,
+			exchange = EXCLUDED.exchange
+	`
+	result, err := r.db.ExecContext(ctx, query, alias.Alias, alias.AssetID, alias.Kind, alias.Exchange)
+	if err != nil {
+		return &ErrDatabaseOperation{Operation: "upsert ticker alias", Err: err}
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return model.ErrAssetNotFound
+	}
+	return nil
+}
+
+func (r *TickerRepo) DeleteAlias(ctx context.Context, alias string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM crypto_asset_alias WHERE alias = $1`, alias)
+	if err != nil {
+		return &ErrDatabaseOperation{Operation: "delete ticker alias", Err: err}
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return model.ErrTickerAliasNotFound
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/quantsmithapp/datastation-backend/internal/constant"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
+
+type TierEngineRepo struct {
+	cryptoDB   *sqlx.DB // For crypto_author_nav and crypto_author_tier_proposal
+	postgresDB *sqlx.DB // For twitter_crypto_* tables
+}
+
+func NewTierEngineRepo(cryptoDB *sqlx.DB, postgresDB *sqlx.DB) *TierEngineRepo {
+	return &TierEngineRepo{
+		cryptoDB:   cryptoDB,
+		postgresDB: postgresDB,
+	}
+}
+
+// winrateExpression returns the backtesting winrate column for the period, "overall" uses the blended winrate
+func winrateExpression(period string) (string, error) {
+	if period == "" || period == "overall" {
+		return `b.crypto_winrate_1d * 0.35 +
+			b.crypto_winrate_3d * 0.3 +
+			b.crypto_winrate_7d * 0.2 +
+			b.crypto_winrate_15d * 0.1 +
+			b.crypto_winrate_30d * 0.05`, nil
+	}
+	field, exists := constant.WINRATE_FIELD_NAME[period]
+	if !exists {
+		return "", errors.New("invalid winrate period")
+	}
+	return "b." + field, nil
+}
+
+// GetAuthorTierMetrics returns the current tier and number of signals since the given time for every selected author
+func (r *TierEngineRepo) GetAuthorTierMetrics(ctx context.Context, since time.Time) ([]model.AuthorTierMetrics, error) {
+	query := `
+		SELECT
+			p.author_username,
+			COALESCE(p.author_tier, '') AS author_tier,
+			COALESCE(sc.signal_count, 0) AS signal_count
+		FROM twitter_crypto_author_profile p
+		LEFT JOIN (
+			SELECT t.author_username, COUNT(*) AS signal_count
+			FROM twitter_crypto_signal_reviewed s
+			INNER JOIN twitter_crypto_tweets_foxhole t ON s.tweet_id = t.id
+			WHERE s.created_at >= $1
+			GROUP BY t.author_username
+		) sc ON sc.author_username = p.author_username
+		WHERE p.is_select = true
+	`
+
+	var metrics []model.AuthorTierMetrics
+	if err := r.postgresDB.SelectContext(ctx, &metrics, query, since); err != nil {
+		return nil, fmt.Errorf("failed to get author tier metrics: %w", err)
+	}
+	return metrics, nil
+}
+
+// GetAuthorDailyNav returns the last NAV of each day since the given time grouped by author
+func (r *TierEngineRepo) GetAuthorDailyNav(ctx context.Context, since time.Time) (map[string][]model.Nav, error) {
+	return selectAuthorDailyNav(ctx, r.cryptoDB, since)
+}
+
+// InsertTierProposals stores new proposals and supersedes any pending proposal left for the same author
+func (r *TierEngineRepo) InsertTierProposals(ctx context.Context, proposals []model.AuthorTierProposal) error {
+	if len(proposals) == 0 {
+		return nil
+	}
+
+	tx, err := r.cryptoDB.BeginTxx(ctx, nil)
+	if err != nil {
+		return &ErrDatabaseOperation{Operation: "begin tier proposal insert", Err: err}
+	}
+	defer tx.Rollback()
+
+	supersedeQuery := `
+		UPDATE crypto_author_tier_proposal
+		SET status = $1, updated_at = NOW()
+		WHERE author_username = $2 AND status = $3
+	`
+	insertQuery := `
+		INSERT INTO crypto_author_tier_proposal
+			(author_username, current_tier, proposed_tier, winrate, signal_count, max_drawdown, consistency, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	for _, p := range proposals {
+		if _, err := tx.ExecContext(ctx, supersedeQuery, model.TierProposalStatusSuperseded, p.AuthorUsername, model.TierProposalStatusPending); err != nil {
+			return &ErrDatabaseOperation{Operation: "supersede tier proposal", Err: err}
+		}
+		if _, err := tx.ExecContext(ctx, insertQuery,
+			p.AuthorUsername, p.CurrentTier, p.ProposedTier, p.Winrate, p.SignalCount, p.MaxDrawdown, p.Consistency, model.TierProposalStatusPending,
+		); err != nil {
+			return &ErrDatabaseOperation{Operation: "insert tier proposal", Err: err}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return &ErrDatabaseOperation{Operation: "commit tier proposal insert", Err: err}
+	}
+	return nil
+}
+
+func (r *TierEngineRepo) GetTierProposals(ctx context.Context, status string) ([]model.AuthorTierProposal, error) {
+	query := `
+		SELECT id, author_username, COALESCE(current_tier, '') AS current_tier, proposed_tier, winrate, signal_count,
+			max_drawdown, consistency, status, reviewed_by, reviewed_at, created_at, updated_at
+		FROM crypto_author_tier_proposal
+		WHERE ($1 = '' OR status = $1)
+		ORDER BY created_at DESC
+	`
+	proposals := []model.AuthorTierProposal{}
+	if err := r.cryptoDB.SelectContext(ctx, &proposals, query, status); err != nil {
+		return nil, fmt.Errorf("failed to get tier proposals: %w", err)
+	}
+	return proposals, nil
+}
+
+func (r *TierEngineRepo) GetTierProposalByID(ctx context.Context, id string) (model.AuthorTierProposal, error) {
+	query := `
+		SELECT id, author_username, COALESCE(current_tier, '') AS current_tier, proposed_tier, winrate, signal_count,
+			max_drawdown, consistency, status, reviewed_by, reviewed_at, created_at, updated_at
+		FROM crypto_author_tier_proposal
+		WHERE id = $1
+	`
+	var proposal model.AuthorTierProposal
+	if err := r.cryptoDB.GetContext(ctx, &proposal, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return proposal, model.ErrTierProposalNotFound
+		}
+		return proposal, fmt.Errorf("failed to get tier proposal: %w", err)
+	}
+	return proposal, nil
+}
+
+// ReviewTierProposal moves a pending proposal to its reviewed status. The row stays locked while apply runs, so a
+// recompute cannot supersede the proposal meanwhile, and the status change is rolled back when apply fails.
+func (r *TierEngineRepo) ReviewTierProposal(ctx context.Context, id, status, reviewedBy string, apply func() error) error {
+	tx, err := r.cryptoDB.BeginTxx(ctx, nil)
+	if err != nil {
+		return &ErrDatabaseOperation{Operation: "begin tier proposal review", Err: err}
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE crypto_author_tier_proposal
+		SET status = $1, reviewed_by = $2, reviewed_at = NOW(), updated_at = NOW()
+		WHERE id = $3 AND status = $4
+	`
+	result, err := tx.ExecContext(ctx, query, status, reviewedBy, id, model.TierProposalStatusPending)
+	if err != nil {
+		return &ErrDatabaseOperation{Operation: "update tier proposal status", Err: err}
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return &ErrDatabaseOperation{Operation: "update tier proposal status", Err: err}
+	}
+	if affected == 0 {
+		return model.ErrTierProposalNotPending
+	}
+
+	if apply != nil {
+		if err := apply(); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return &ErrDatabaseOperation{Operation: "commit tier proposal review", Err: err}
+	}
+	return nil
+}
@@ -1,24 +1,46 @@
 package repo
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/lib/pq"
 	"github.com/quantsmithapp/datastation-backend/internal/core/port"
 	"github.com/quantsmithapp/datastation-backend/internal/model"
 	"github.com/quantsmithapp/datastation-backend/pkg/logger"
 )
 
+// timescaleTable is an OHLCV table or continuous aggregate named {type}_{tf}
+type timescaleTable struct {
+	name       string
+	timeColumn string
+	// timeFrame is zero when the tf suffix is not a fixed duration, such tables are only served as is
+	timeFrame time.Duration
+}
+
 type timescaleRepo struct {
 	db     *sql.DB
 	logger logger.Logger
+
+	mu     sync.RWMutex
+	tables map[string]timescaleTable // By name, nil until loaded
 }
 
 func NewTimescaleRepo(db *sql.DB) port.TimescaleRepo {
-	return &timescaleRepo{
+	r := &timescaleRepo{
 		db:     db,
 		logger: logger.NewLogger(),
 	}
+	// Table metadata is cached at startup, a failed load is retried on the first request
+	if err := r.loadTables(); err != nil {
+		r.logger.Error(fmt.Errorf("error loading timescale tables: %v", err))
+	}
+	return r
 }
 
 func (r *timescaleRepo) GetCryptoOHLCV(req model.OHLCVRequest) ([]model.OHLCVData, error) {
@@ -29,63 +51,98 @@ func (r *timescaleRepo) GetForexOHLCV(req model.OHLCVRequest) ([]model.OHLCVData
 	return r.getOHLCV("forex", req)
 }
 
-func (r *timescaleRepo) getOHLCV(dataType string, req model.OHLCVRequest) ([]model.OHLCVData, error) {
-	tableName := fmt.Sprintf("%s_%s", dataType, req.TimeFrame)
+func (r *timescaleRepo) StreamCryptoOHLCV(ctx context.Context, req model.OHLCVRequest, fn func(model.OHLCVData) error) error {
+	return r.streamOHLCV(ctx, "binance", req, fn)
+}
 
-	// Log the table name and request details for debugging
-	r.logger.Info(fmt.Sprintf("Querying table: %s with request: %+v", tableName, req))
+func (r *timescaleRepo) StreamForexOHLCV(ctx context.Context, req model.OHLCVRequest, fn func(model.OHLCVData) error) error {
+	return r.streamOHLCV(ctx, "forex", req, fn)
+}
 
-	// Check if the table exists
-	var tableExists bool
-	err := r.db.QueryRow("SELECT EXISTS (SELECT FROM information_schema.tables WHERE table_name = $1)", tableName).Scan(&tableExists)
+func (r *timescaleRepo) getOHLCV(dataType string, req model.OHLCVRequest) ([]model.OHLCVData, error) {
+	var result []model.OHLCVData
+	err := r.streamOHLCV(context.Background(), dataType, req, func(data model.OHLCVData) error {
+		result = append(result, data)
+		return nil
+	})
 	if err != nil {
-		r.logger.Error(fmt.Errorf("error checking if table exists: %v", err))
 		return nil, err
 	}
-	if !tableExists {
-		return nil, fmt.Errorf("table %s does not exist", tableName)
+
+	// Log the number of results and the first result for debugging
+	r.logger.Info(fmt.Sprintf("Query returned %d results", len(result)))
+	if len(result) > 0 {
+		r.logger.Info(fmt.Sprintf("First result: %+v", result[0]))
 	}
 
-	// Get the table schema
-	rows, err := r.db.Query("SELECT column_name FROM information_schema.columns WHERE table_name = $1", tableName)
+	return result, nil
+}
+
+// streamOHLCV serves the {type}_{tf} table when it exists and is aligned to the requested timezone. Any other
+// timeframe is derived from the coarsest finer table that divides it. Candles are passed to fn by time, then ticker,
+// as they are read.
+func (r *timescaleRepo) streamOHLCV(ctx context.Context, dataType string, req model.OHLCVRequest, fn func(model.OHLCVData) error) error {
+	loc := time.UTC
+	if req.Timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(req.Timezone); err != nil {
+			return model.ErrInvalidOHLCVTimezone
+		}
+	}
+	tables, err := r.getTables()
 	if err != nil {
-		r.logger.Error(fmt.Errorf("error getting table schema for %s: %v", tableName, err))
-		return nil, err
+		return err
 	}
-	defer rows.Close()
+	_, offset := req.StartDate.In(loc).Zone()
 
-	var columns []string
-	for rows.Next() {
-		var column string
-		if err := rows.Scan(&column); err != nil {
-			r.logger.Error(fmt.Errorf("error scanning column name: %v", err))
-			return nil, err
+	tableName := fmt.Sprintf("%s_%s", dataType, req.TimeFrame)
+	table, exists := tables[tableName]
+	if exists && (table.timeFrame == 0 || alignedToOffset(table.timeFrame, offset)) {
+		return r.queryOHLCV(ctx, table, req, req.StartDate, fn)
+	}
+
+	timeFrame, ok := model.ParseTimeFrame(req.TimeFrame)
+	if !ok {
+		return fmt.Errorf("%w: %s", model.ErrUnsupportedTimeFrame, req.TimeFrame)
+	}
+	var source *timescaleTable
+	for _, candidate := range tables {
+		if !strings.HasPrefix(candidate.name, dataType+"_") || candidate.timeFrame == 0 || candidate.timeFrame >= timeFrame ||
+			timeFrame%candidate.timeFrame != 0 || !alignedToOffset(candidate.timeFrame, offset) {
+			continue
 		}
-		columns = append(columns, column)
+		if source == nil || candidate.timeFrame > source.timeFrame {
+			c := candidate
+			source = &c
+		}
+	}
+	if source == nil {
+		return fmt.Errorf("%w: %s", model.ErrUnsupportedTimeFrame, req.TimeFrame)
 	}
-	r.logger.Info(fmt.Sprintf("Table %s columns: %v", tableName, columns))
 
-	// Determine the time column name
-	timeColumn := "bucket" // Default to "bucket"
-	if contains(columns, "time") {
-		timeColumn = "time"
-	} else if !contains(columns, "bucket") {
-		return nil, fmt.Errorf("neither 'time' nor 'bucket' column found in table %s", tableName)
+	r.logger.Info(fmt.Sprintf("Deriving %s candles from table %s", req.TimeFrame, source.name))
+	resampler := newOHLCVResampler(timeFrame, loc, fn)
+	// Start at the beginning of the first candle so it is not missing its first part
+	if err := r.queryOHLCV(ctx, *source, req, candleStart(req.StartDate, timeFrame, loc), resampler.add); err != nil {
+		return err
 	}
+	return resampler.flush()
+}
 
+func (r *timescaleRepo) queryOHLCV(ctx context.Context, table timescaleTable, req model.OHLCVRequest, start time.Time, fn func(model.OHLCVData) error) error {
 	// Construct the SQL query
 	query := fmt.Sprintf(`
 		SELECT %s, ticker, open, high, low, close, volume
 		FROM %s
 		WHERE %s >= $1
-	`, timeColumn, tableName, timeColumn)
+	`, table.timeColumn, table.name, table.timeColumn)
 
-	args := []interface{}{req.StartDate}
+	args := []interface{}{start}
 	argCount := 1
 
 	if req.EndDate != nil {
 		argCount++
-		query += fmt.Sprintf(" AND %s <= $%d", timeColumn, argCount)
+		query += fmt.Sprintf(" AND %s <= $%d", table.timeColumn, argCount)
 		args = append(args, req.EndDate)
 	}
 
@@ -93,47 +150,188 @@ func (r *timescaleRepo) getOHLCV(dataType string, req model.OHLCVRequest) ([]mod
 		argCount++
 		query += fmt.Sprintf(" AND ticker = $%d", argCount)
 		args = append(args, req.Ticker)
+	} else if len(req.Tickers) > 0 {
+		argCount++
+		query += fmt.Sprintf(" AND ticker = ANY($%d)", argCount)
+		args = append(args, pq.Array(req.Tickers))
 	}
 
-	query += fmt.Sprintf(" ORDER BY %s, ticker", timeColumn)
+	query += fmt.Sprintf(" ORDER BY %s, ticker", table.timeColumn)
 
 	// Log the full SQL query and arguments
 	r.logger.Info(fmt.Sprintf("Executing SQL query: %s with args: %v", query, args))
 
 	// Execute the query
-	rows, err = r.db.Query(query, args...)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		r.logger.Error(fmt.Errorf("error querying %s: %v", tableName, err))
-		return nil, err
+		r.logger.Error(fmt.Errorf("error querying %s: %v", table.name, err))
+		return err
 	}
 	defer rows.Close()
 
-	var result []model.OHLCVData
 	for rows.Next() {
 		var data model.OHLCVData
 		err := rows.Scan(&data.Time, &data.Ticker, &data.Open, &data.High, &data.Low, &data.Close, &data.Volume)
 		if err != nil {
 			r.logger.Error(fmt.Errorf("error scanning row: %v", err))
-			return nil, err
+			return err
+		}
+		if err := fn(data); err != nil {
+			return err
 		}
-		result = append(result, data)
 	}
+	return rows.Err()
+}
 
-	// Log the number of results and the first result for debugging
-	r.logger.Info(fmt.Sprintf("Query returned %d results", len(result)))
-	if len(result) > 0 {
-		r.logger.Info(fmt.Sprintf("First result: %+v", result[0]))
+func (r *timescaleRepo) getTables() (map[string]timescaleTable, error) {
+	r.mu.RLock()
+	tables := r.tables
+	r.mu.RUnlock()
+	if tables != nil {
+		return tables, nil
 	}
 
-	return result, nil
+	if err := r.loadTables(); err != nil {
+		r.logger.Error(fmt.Errorf("error loading timescale tables: %v", err))
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.tables, nil
+}
+
+// loadTables caches the OHLCV tables and continuous aggregates with their time column, "time" or "bucket"
+func (r *timescaleRepo) loadTables() error {
+	rows, err := r.db.Query(`
+		SELECT table_name, column_name
+		FROM information_schema.columns
+		WHERE (table_name LIKE 'binance\_%' OR table_name LIKE 'forex\_%')
+		AND column_name IN ('time', 'bucket')
+	`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	tables := make(map[string]timescaleTable)
+	for rows.Next() {
+		var name, column string
+		if err := rows.Scan(&name, &column); err != nil {
+			return err
+		}
+		table, ok := tables[name]
+		if !ok {
+			table = timescaleTable{name: name, timeColumn: column}
+			table.timeFrame, _ = model.ParseTimeFrame(name[strings.Index(name, "_")+1:])
+		}
+		// "time" wins when a table has both
+		if column == "time" {
+			table.timeColumn = column
+		}
+		tables[name] = table
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.tables = tables
+	r.mu.Unlock()
+	r.logger.Info(fmt.Sprintf("Loaded %d timescale tables", len(tables)))
+	return nil
+}
+
+// alignedToOffset reports whether candles of the timeframe, aligned to UTC like the tables are, also start on local
+// boundaries at the UTC offset in seconds
+func alignedToOffset(timeFrame time.Duration, offset int) bool {
+	period := timeFrame
+	if period > 24*time.Hour {
+		period = 24 * time.Hour
+	}
+	return (time.Duration(offset)*time.Second)%period == 0
 }
 
-// Helper function to check if a slice contains a string
-func contains(slice []string, str string) bool {
-	for _, v := range slice {
-		if v == str {
-			return true
+// candleOrigin is the Monday TimescaleDB's time_bucket aligns buckets to, derived candles use it in local time
+var candleOrigin = time.Date(2000, 1, 3, 0, 0, 0, 0, time.UTC)
+
+// candleStart returns the start of the candle of the timeframe containing t, counted from local midnight of
+// candleOrigin. Candles of whole days follow the calendar so they start at local midnight across DST changes.
+func candleStart(t time.Time, timeFrame time.Duration, loc *time.Location) time.Time {
+	local := t.In(loc)
+	if timeFrame%(24*time.Hour) == 0 {
+		n := int(timeFrame / (24 * time.Hour))
+		year, month, day := local.Date()
+		days := int(time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Sub(candleOrigin) / (24 * time.Hour))
+		days -= ((days % n) + n) % n
+		return time.Date(2000, 1, 3+days, 0, 0, 0, 0, loc)
+	}
+
+	// Work on the wall clock so sub-day candles start on local boundaries
+	_, offset := local.Zone()
+	wall := time.Duration(local.Unix()+int64(offset)-candleOrigin.Unix()) * time.Second
+	wall -= ((wall % timeFrame) + timeFrame) % timeFrame
+	start := candleOrigin.Add(wall)
+	return time.Date(start.Year(), start.Month(), start.Day(), start.Hour(), start.Minute(), 0, 0, loc)
+}
+
+// ohlcvResampler aggregates candles sorted by time into candles of the timeframe per ticker: the first open, highest
+// high, lowest low, last close and summed volume. Candles are passed on by ticker once a later candle starts the
+// next one, so only one candle per ticker is held at a time.
+type ohlcvResampler struct {
+	timeFrame time.Duration
+	loc       *time.Location
+	emit      func(model.OHLCVData) error
+	start     time.Time
+	pending   map[string]*model.OHLCVData
+}
+
+func newOHLCVResampler(timeFrame time.Duration, loc *time.Location, emit func(model.OHLCVData) error) *ohlcvResampler {
+	return &ohlcvResampler{
+		timeFrame: timeFrame,
+		loc:       loc,
+		emit:      emit,
+		pending:   make(map[string]*model.OHLCVData),
+	}
+}
+
+func (s *ohlcvResampler) add(c model.OHLCVData) error {
+	start := candleStart(c.Time, s.timeFrame, s.loc)
+	if !start.Equal(s.start) {
+		if err := s.flush(); err != nil {
+			return err
+		}
+		s.start = start
+	}
+
+	agg, ok := s.pending[c.Ticker]
+	if !ok {
+		c.Time = start
+		s.pending[c.Ticker] = &c
+		return nil
+	}
+	if c.High > agg.High {
+		agg.High = c.High
+	}
+	if c.Low < agg.Low {
+		agg.Low = c.Low
+	}
+	agg.Close = c.Close
+	agg.Volume += c.Volume
+	return nil
+}
+
+// flush passes on the pending candles
+func (s *ohlcvResampler) flush() error {
+	tickers := make([]string, 0, len(s.pending))
+	for ticker := range s.pending {
+		tickers = append(tickers, ticker)
+	}
+	sort.Strings(tickers)
+	for _, ticker := range tickers {
+		if err := s.emit(*s.pending[ticker]); err != nil {
+			return err
 		}
 	}
-	return false
+	clear(s.pending)
+	return nil
 }
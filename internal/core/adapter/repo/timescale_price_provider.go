@@ -0,0 +1,78 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
+
+type TimescalePriceProvider struct {
+	ohlcvRepo port.TimescaleRepo
+}
+
+// NewTimescalePriceProvider creates a provider that derives prices from the hourly crypto candles of the last day,
+// it has no market caps
+func NewTimescalePriceProvider(ohlcvRepo port.TimescaleRepo) port.PriceProvider {
+	return &TimescalePriceProvider{ohlcvRepo: ohlcvRepo}
+}
+
+func (p *TimescalePriceProvider) Name() string {
+	return "timescale"
+}
+
+func (p *TimescalePriceProvider) FetchPrices(ctx context.Context) ([]model.MarketPrice, error) {
+	if p.ohlcvRepo == nil {
+		return nil, model.ErrOHLCVUnavailable
+	}
+
+	type window struct {
+		first, last model.OHLCVData
+		volume      float64
+	}
+	// The current hour and the 23 before it, the change is from the open of the first one
+	start := time.Now().UTC().Truncate(time.Hour).Add(-23 * time.Hour)
+	windows := make(map[string]*window)
+	err := p.ohlcvRepo.StreamCryptoOHLCV(ctx, model.OHLCVRequest{
+		TimeFrame: "1h",
+		StartDate: start,
+		AllPair:   true,
+	}, func(candle model.OHLCVData) error {
+		w, ok := windows[candle.Ticker]
+		if !ok {
+			w = &window{first: candle, last: candle}
+			windows[candle.Ticker] = w
+		}
+		if candle.Time.Before(w.first.Time) {
+			w.first = candle
+		}
+		if !candle.Time.Before(w.last.Time) {
+			w.last = candle
+		}
+		w.volume += candle.Volume * candle.Close
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	prices := make([]model.MarketPrice, 0, len(windows))
+	for ticker, w := range windows {
+		price := w.last.Close
+		volume := w.volume
+		marketPrice := model.MarketPrice{
+			Symbol:    ticker,
+			Price:     &price,
+			Volume24h: &volume,
+			Source:    p.Name(),
+			UpdatedAt: w.last.Time,
+		}
+		if w.first.Open != 0 {
+			change := (price - w.first.Open) / w.first.Open * 100
+			marketPrice.Change24h = &change
+		}
+		prices = append(prices, marketPrice)
+	}
+	return prices, nil
+}
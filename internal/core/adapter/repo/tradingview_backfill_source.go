@@ -0,0 +1,37 @@
+package repo
+
+import (
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/internal/tradingview"
+)
+
+type TradingViewBackfillSource struct {
+	client *tradingview.TradingViewClient
+}
+
+// NewTradingViewBackfillSource creates a source of candles from the TradingView chart sessions of the client
+func NewTradingViewBackfillSource(client *tradingview.TradingViewClient) port.OHLCVBackfillSource {
+	return &TradingViewBackfillSource{client: client}
+}
+
+func (s *TradingViewBackfillSource) GetCandles(ticker string, exchange string, interval string, bars int) ([]model.OHLCVData, error) {
+	data, err := s.client.GetHistoricalData(ticker, exchange, interval, bars, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	candles := make([]model.OHLCVData, len(data))
+	for i, bar := range data {
+		candles[i] = model.OHLCVData{
+			Time:   bar.DateTime.UTC(),
+			Ticker: ticker,
+			Open:   bar.Open,
+			High:   bar.High,
+			Low:    bar.Low,
+			Close:  bar.Close,
+			Volume: bar.Volume,
+		}
+	}
+	return candles, nil
+}
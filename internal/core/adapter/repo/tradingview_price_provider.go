@@ -0,0 +1,105 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
+
+const (
+	tradingViewScannerURL = "https://scanner.tradingview.com/coin/scan?label-product=screener-coin"
+	// tradingViewScannerLimit is how many coins by rank are loaded
+	tradingViewScannerLimit = 500
+)
+
+// tradingViewScannerColumns are read by name so the order of the request columns does not matter
+var tradingViewScannerColumns = []string{"base_currency", "close", "24h_close_change|5", "24h_vol_cmc", "market_cap_calc"}
+
+type TradingViewPriceProvider struct {
+	client *http.Client
+}
+
+// NewTradingViewPriceProvider creates a provider of the top coins of the TradingView screener
+func NewTradingViewPriceProvider() port.PriceProvider {
+	return &TradingViewPriceProvider{client: &http.Client{Timeout: 15 * time.Second}}
+}
+
+func (p *TradingViewPriceProvider) Name() string {
+	return "tradingview"
+}
+
+func (p *TradingViewPriceProvider) FetchPrices(ctx context.Context) ([]model.MarketPrice, error) {
+	payload := map[string]interface{}{
+		"columns":               tradingViewScannerColumns,
+		"markets":               []string{"coin"},
+		"range":                 []int{0, tradingViewScannerLimit},
+		"ignore_unknown_fields": false,
+		"sort":                  map[string]string{"sortBy": "crypto_total_rank", "sortOrder": "asc"},
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode scanner request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tradingViewScannerURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scanner request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call scanner: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("scanner returned status %d", resp.StatusCode)
+	}
+
+	var scannerResponse model.ScannerResponse
+	if err := json.NewDecoder(resp.Body).Decode(&scannerResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode scanner response: %w", err)
+	}
+
+	column := make(map[string]int, len(tradingViewScannerColumns))
+	for i, name := range tradingViewScannerColumns {
+		column[name] = i
+	}
+	value := func(row model.ScannerData, name string) *float64 {
+		i := column[name]
+		if i >= len(row.D) {
+			return nil
+		}
+		if v, ok := row.D[i].(float64); ok {
+			return &v
+		}
+		return nil
+	}
+
+	now := time.Now().UTC()
+	prices := make([]model.MarketPrice, 0, len(scannerResponse.Data))
+	for _, row := range scannerResponse.Data {
+		if len(row.D) == 0 {
+			continue
+		}
+		symbol, ok := row.D[column["base_currency"]].(string)
+		if !ok || symbol == "" {
+			continue
+		}
+		prices = append(prices, model.MarketPrice{
+			Symbol:    symbol,
+			Price:     value(row, "close"),
+			Change24h: value(row, "24h_close_change|5"),
+			Volume24h: value(row, "24h_vol_cmc"),
+			MarketCap: value(row, "market_cap_calc"),
+			Source:    p.Name(),
+			UpdatedAt: now,
+		})
+	}
+	return prices, nil
+}
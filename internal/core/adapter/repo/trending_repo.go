@@ -0,0 +1,142 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
+
+type TrendingRepo struct {
+	cryptoDB   *sqlx.DB // For crypto_ticker_watchlist
+	postgresDB *sqlx.DB // For twitter_crypto_* tables
+}
+
+func NewTrendingRepo(cryptoDB *sqlx.DB, postgresDB *sqlx.DB) *TrendingRepo {
+	return &TrendingRepo{
+		cryptoDB:   cryptoDB,
+		postgresDB: postgresDB,
+	}
+}
+
+// GetTickerMentionWindows counts the tweets mentioning each signal ticker per author and window, for the given number
+// of windows ending at windowEnd. Tickers are resolved with canonical_ticker so they match the watchlist.
+func (r *TrendingRepo) GetTickerMentionWindows(ctx context.Context, windowEnd time.Time, window time.Duration, windows int) ([]model.TickerMentionWindow, error) {
+	query := `
+		SELECT
+			canonical_ticker(s.ticker) AS ticker,
+			t.author_username,
+			floor(extract(epoch FROM ($1 - t.tweet_created_at)) / $2)::int AS window_index,
+			COUNT(DISTINCT s.tweet_id) AS mentions
+		FROM twitter_crypto_signal_reviewed s
+		INNER JOIN twitter_crypto_tweets_foxhole t ON s.tweet_id = t.id
+		INNER JOIN twitter_crypto_author_profile a ON a.author_username = t.author_username
+		WHERE a.is_select = true
+		AND t.tweet_created_at > $3 AND t.tweet_created_at <= $1
+		AND s.ticker IS NOT NULL
+		AND s.ticker NOT IN ('', 'NONE', 'USDCUSDT')
+		GROUP BY 1, 2, 3
+	`
+	since := windowEnd.Add(-time.Duration(windows) * window)
+
+	var mentions []model.TickerMentionWindow
+	if err := r.postgresDB.SelectContext(ctx, &mentions, query, windowEnd, window.Seconds(), since); err != nil {
+		return nil, fmt.Errorf("failed to get ticker mention windows: %w", err)
+	}
+	return mentions, nil
+}
+
+func (r *TrendingRepo) GetAuthorWeightInputs(ctx context.Context) ([]model.AuthorWeightInput, error) {
+	winrate, err := winrateExpression("overall")
+	if err != nil {
+		return nil, err
+	}
+	query := fmt.Sprintf(`
+		SELECT
+			p.author_username,
+			COALESCE(p.author_tier, '') AS author_tier,
+			COALESCE(%s, 0) AS winrate
+		FROM twitter_crypto_author_profile p
+		LEFT JOIN twitter_crypto_backtesting b ON b.author_id = p.author_id
+		WHERE p.is_select = true
+	`, winrate)
+
+	var inputs []model.AuthorWeightInput
+	if err := r.postgresDB.SelectContext(ctx, &inputs, query); err != nil {
+		return nil, fmt.Errorf("failed to get author weight inputs: %w", err)
+	}
+	return inputs, nil
+}
+
+func (r *TrendingRepo) GetWatchlist(ctx context.Context, uid string) ([]model.WatchlistTicker, error) {
+	query := `
+		SELECT w.ticker, w.trending_alert, w.last_alerted_at, w.created_at
+		FROM crypto_ticker_watchlist w
+		WHERE w.crypto_user_id = (SELECT id FROM crypto_user WHERE uuid = $1)
+		ORDER BY w.ticker ASC
+	`
+	watchlist := []model.WatchlistTicker{}
+	if err := r.cryptoDB.SelectContext(ctx, &watchlist, query, uid); err != nil {
+		return nil, fmt.Errorf("failed to get watchlist: %w", err)
+	}
+	return watchlist, nil
+}
+
+func (r *TrendingRepo) UpsertWatchlistTicker(ctx context.Context, uid, ticker string, trendingAlert bool) error {
+	query := `
+		INSERT INTO crypto_ticker_watchlist (crypto_user_id, ticker, trending_alert)
+		VALUES ((SELECT id FROM crypto_user WHERE uuid = $1), $2, $3)
+		ON CONFLICT (crypto_user_id, ticker) DO UPDATE SET
+			trending_alert = EXCLUDED.trending_alert
+	`
+	if _, err := r.cryptoDB.ExecContext(ctx, query, uid, ticker, trendingAlert); err != nil {
+		return &ErrDatabaseOperation{Operation: "upsert watchlist ticker", Err: err}
+	}
+	return nil
+}
+
+func (r *TrendingRepo) DeleteWatchlistTicker(ctx context.Context, uid, ticker string) error {
+	query := `
+		DELETE FROM crypto_ticker_watchlist
+		WHERE crypto_user_id = (SELECT id FROM crypto_user WHERE uuid = $1)
+		AND ticker = $2
+	`
+	if _, err := r.cryptoDB.ExecContext(ctx, query, uid, ticker); err != nil {
+		return &ErrDatabaseOperation{Operation: "delete watchlist ticker", Err: err}
+	}
+	return nil
+}
+
+// GetTrendingAlertSubscribers returns the users watching the tickers with trending alerts on, skipping those already
+// alerted for the ticker after alertedBefore
+func (r *TrendingRepo) GetTrendingAlertSubscribers(ctx context.Context, tickers []string, alertedBefore time.Time) ([]model.WatchlistAlertSubscriber, error) {
+	query := `
+		SELECT u.uuid, w.ticker
+		FROM crypto_ticker_watchlist w
+		INNER JOIN crypto_user u ON u.id = w.crypto_user_id
+		WHERE w.trending_alert = true
+		AND w.ticker = ANY($1)
+		AND (w.last_alerted_at IS NULL OR w.last_alerted_at < $2)
+	`
+	var subscribers []model.WatchlistAlertSubscriber
+	if err := r.cryptoDB.SelectContext(ctx, &subscribers, query, pq.Array(tickers), alertedBefore); err != nil {
+		return nil, fmt.Errorf("failed to get trending alert subscribers: %w", err)
+	}
+	return subscribers, nil
+}
+
+func (r *TrendingRepo) MarkTrendingAlerted(ctx context.Context, uid, ticker string, alertedAt time.Time) error {
+	query := `
+		UPDATE crypto_ticker_watchlist
+		SET last_alerted_at = $3
+		WHERE crypto_user_id = (SELECT id FROM crypto_user WHERE uuid = $1)
+		AND ticker = $2
+	`
+	if _, err := r.cryptoDB.ExecContext(ctx, query, uid, ticker, alertedAt); err != nil {
+		return &ErrDatabaseOperation{Operation: "mark trending alerted", Err: err}
+	}
+	return nil
+}
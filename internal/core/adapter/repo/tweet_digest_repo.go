@@ -0,0 +1,289 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
+
+type TweetDigestRepo struct {
+	cryptoDB   *sqlx.DB // For crypto_tweet_digest and crypto_tweet_digest_subscription
+	postgresDB *sqlx.DB // For twitter_crypto_* tables
+}
+
+func NewTweetDigestRepo(cryptoDB *sqlx.DB, postgresDB *sqlx.DB) *TweetDigestRepo {
+	return &TweetDigestRepo{
+		cryptoDB:   cryptoDB,
+		postgresDB: postgresDB,
+	}
+}
+
+func (r *TweetDigestRepo) GetKeyTopics(ctx context.Context, from, to time.Time) ([]model.DigestTopic, error) {
+	query := `
+		SELECT date_time, response_topic
+		FROM public.twitter_crypto_key_topic
+		WHERE date_time >= $1 AND date_time < $2
+		ORDER BY date_time ASC
+	`
+	topics := []model.DigestTopic{}
+	if err := r.postgresDB.SelectContext(ctx, &topics, query, from, to); err != nil {
+		return nil, fmt.Errorf("failed to get key topics: %w", err)
+	}
+	return topics, nil
+}
+
+// GetTickerStats counts the signals of tracked authors per canonical ticker on tweets posted in [from, to)
+func (r *TweetDigestRepo) GetTickerStats(ctx context.Context, from, to time.Time) ([]model.DigestTickerStats, error) {
+	query := `
+		SELECT
+			canonical_ticker(s.ticker) AS ticker,
+			COUNT(DISTINCT s.tweet_id) AS mentions,
+			COUNT(DISTINCT t.author_username) AS authors,
+			COUNT(*) FILTER (WHERE s.action = 'LONG') AS longs,
+			COUNT(*) FILTER (WHERE s.action = 'SHORT') AS shorts
+		FROM twitter_crypto_signal_reviewed s
+		INNER JOIN twitter_crypto_tweets_foxhole t ON s.tweet_id = t.id
+		INNER JOIN twitter_crypto_author_profile a ON a.author_username = t.author_username
+		WHERE a.is_select = true
+		AND t.tweet_created_at >= $1 AND t.tweet_created_at < $2
+		AND s.ticker IS NOT NULL
+		AND s.ticker NOT IN ('', 'NONE', 'USDCUSDT')
+		GROUP BY 1
+	`
+	stats := []model.DigestTickerStats{}
+	if err := r.postgresDB.SelectContext(ctx, &stats, query, from, to); err != nil {
+		return nil, fmt.Errorf("failed to get digest ticker stats: %w", err)
+	}
+	return stats, nil
+}
+
+func (r *TweetDigestRepo) GetTopAuthors(ctx context.Context, from, to time.Time, limit int) ([]model.DigestAuthor, error) {
+	query := `
+		SELECT
+			t.author_username,
+			COUNT(*) AS tweets,
+			COALESCE(SUM(COALESCE(t.likecount, 0) + COALESCE(t.retweetcount, 0) + COALESCE(t.replycount, 0) + COALESCE(t.quotecount, 0)), 0)::bigint AS engagement,
+			COALESCE(SUM(t.viewcount), 0)::bigint AS views
+		FROM twitter_crypto_tweets_foxhole t
+		INNER JOIN twitter_crypto_author_profile a ON a.author_username = t.author_username
+		WHERE a.is_select = true
+		AND t.tweet_created_at >= $1 AND t.tweet_created_at < $2
+		GROUP BY t.author_username
+		ORDER BY engagement DESC, t.author_username ASC
+		LIMIT $3
+	`
+	authors := []model.DigestAuthor{}
+	if err := r.postgresDB.SelectContext(ctx, &authors, query, from, to, limit); err != nil {
+		return nil, fmt.Errorf("failed to get digest top authors: %w", err)
+	}
+	return authors, nil
+}
+
+// GetNewCalls returns the most engaged LONG/SHORT signals of tracked authors in [from, to) on tickers the author did not
+// signal between lookbackFrom and from
+func (r *TweetDigestRepo) GetNewCalls(ctx context.Context, from, to, lookbackFrom time.Time, limit int) ([]model.DigestCall, error) {
+	query := `
+		WITH calls AS (
+			SELECT DISTINCT ON (t.author_username, canonical_ticker(s.ticker))
+				s.tweet_id,
+				COALESCE(t.url, '') AS url,
+				t.author_username,
+				COALESCE(a.author_tier, '') AS author_tier,
+				canonical_ticker(s.ticker) AS ticker,
+				s.action,
+				(COALESCE(t.likecount, 0) + COALESCE(t.retweetcount, 0) + COALESCE(t.replycount, 0) + COALESCE(t.quotecount, 0))::bigint AS engagement,
+				t.tweet_created_at
+			FROM twitter_crypto_signal_reviewed s
+			INNER JOIN twitter_crypto_tweets_foxhole t ON s.tweet_id = t.id
+			INNER JOIN twitter_crypto_author_profile a ON a.author_username = t.author_username
+			WHERE a.is_select = true
+			AND t.tweet_created_at >= $1 AND t.tweet_created_at < $2
+			AND s.action IN ('LONG', 'SHORT')
+			AND s.ticker IS NOT NULL
+			AND s.ticker NOT IN ('', 'NONE', 'USDCUSDT')
+			ORDER BY t.author_username, canonical_ticker(s.ticker), t.tweet_created_at ASC
+		)
+		SELECT c.*
+		FROM calls c
+		WHERE NOT EXISTS (
+			SELECT 1
+			FROM twitter_crypto_signal_reviewed ps
+			INNER JOIN twitter_crypto_tweets_foxhole pt ON ps.tweet_id = pt.id
+			WHERE pt.author_username = c.author_username
+			AND pt.tweet_created_at >= $3 AND pt.tweet_created_at < $1
+			AND ps.action IN ('LONG', 'SHORT')
+			AND canonical_ticker(ps.ticker) = c.ticker
+		)
+		ORDER BY c.engagement DESC, c.tweet_created_at ASC
+		LIMIT $4
+	`
+	calls := []model.DigestCall{}
+	if err := r.postgresDB.SelectContext(ctx, &calls, query, from, to, lookbackFrom, limit); err != nil {
+		return nil, fmt.Errorf("failed to get digest new calls: %w", err)
+	}
+	return calls, nil
+}
+
+type tweetDigestRow struct {
+	ID          string    `db:"id"`
+	Period      string    `db:"period"`
+	PeriodStart time.Time `db:"period_start"`
+	PeriodEnd   time.Time `db:"period_end"`
+	Content     []byte    `db:"content"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+func (row tweetDigestRow) toDigest() (model.TweetDigest, error) {
+	digest := model.TweetDigest{
+		ID:          row.ID,
+		Period:      row.Period,
+		PeriodStart: row.PeriodStart,
+		PeriodEnd:   row.PeriodEnd,
+		CreatedAt:   row.CreatedAt,
+	}
+	if err := json.Unmarshal(row.Content, &digest.TweetDigestContent); err != nil {
+		return model.TweetDigest{}, fmt.Errorf("failed to decode digest %s: %w", row.ID, err)
+	}
+	return digest, nil
+}
+
+// SaveDigest stores the digest, replacing the one of the same period and start
+func (r *TweetDigestRepo) SaveDigest(ctx context.Context, digest model.TweetDigest) (model.TweetDigest, error) {
+	content, err := json.Marshal(digest.TweetDigestContent)
+	if err != nil {
+		return model.TweetDigest{}, fmt.Errorf("failed to encode digest: %w", err)
+	}
+	query := `
+		INSERT INTO crypto_tweet_digest (period, period_start, period_end, content)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (period, period_start) DO UPDATE SET
+			period_end = EXCLUDED.period_end,
+			content = EXCLUDED.content,
+			created_at = CURRENT_TIMESTAMP
+		RETURNING id, period, period_start, period_end, content, created_at
+	`
+	var row tweetDigestRow
+	if err := r.cryptoDB.GetContext(ctx, &row, query, digest.Period, digest.PeriodStart, digest.PeriodEnd, content); err != nil {
+		return model.TweetDigest{}, &ErrDatabaseOperation{Operation: "save tweet digest", Err: err}
+	}
+	return row.toDigest()
+}
+
+// GetDigest returns the digest of the period starting at periodStart, or the latest one when periodStart is nil
+func (r *TweetDigestRepo) GetDigest(ctx context.Context, period string, periodStart *time.Time) (model.TweetDigest, error) {
+	query := `
+		SELECT id, period, period_start, period_end, content, created_at
+		FROM crypto_tweet_digest
+		WHERE period = $1
+		AND ($2::timestamp IS NULL OR period_start = $2)
+		ORDER BY period_start DESC
+		LIMIT 1
+	`
+	var row tweetDigestRow
+	if err := r.cryptoDB.GetContext(ctx, &row, query, period, periodStart); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.TweetDigest{}, model.ErrDigestNotFound
+		}
+		return model.TweetDigest{}, fmt.Errorf("failed to get tweet digest: %w", err)
+	}
+	return row.toDigest()
+}
+
+func (r *TweetDigestRepo) GetDigests(ctx context.Context, period string, limit int) ([]model.TweetDigest, error) {
+	query := `
+		SELECT id, period, period_start, period_end, content, created_at
+		FROM crypto_tweet_digest
+		WHERE period = $1
+		ORDER BY period_start DESC
+		LIMIT $2
+	`
+	var rows []tweetDigestRow
+	if err := r.cryptoDB.SelectContext(ctx, &rows, query, period, limit); err != nil {
+		return nil, fmt.Errorf("failed to get tweet digests: %w", err)
+	}
+	digests := make([]model.TweetDigest, 0, len(rows))
+	for _, row := range rows {
+		digest, err := row.toDigest()
+		if err != nil {
+			return nil, err
+		}
+		digests = append(digests, digest)
+	}
+	return digests, nil
+}
+
+func (r *TweetDigestRepo) GetSubscriptions(ctx context.Context, uid string) ([]model.DigestSubscription, error) {
+	query := `
+		SELECT s.period, s.channel, s.last_sent_at, s.created_at
+		FROM crypto_tweet_digest_subscription s
+		WHERE s.crypto_user_id = (SELECT id FROM crypto_user WHERE uuid = $1)
+		ORDER BY s.period ASC, s.channel ASC
+	`
+	subscriptions := []model.DigestSubscription{}
+	if err := r.cryptoDB.SelectContext(ctx, &subscriptions, query, uid); err != nil {
+		return nil, fmt.Errorf("failed to get digest subscriptions: %w", err)
+	}
+	return subscriptions, nil
+}
+
+func (r *TweetDigestRepo) Subscribe(ctx context.Context, uid, period, channel string) error {
+	query := `
+		INSERT INTO crypto_tweet_digest_subscription (crypto_user_id, period, channel)
+		VALUES ((SELECT id FROM crypto_user WHERE uuid = $1), $2, $3)
+		ON CONFLICT (crypto_user_id, period, channel) DO NOTHING
+	`
+	if _, err := r.cryptoDB.ExecContext(ctx, query, uid, period, channel); err != nil {
+		return &ErrDatabaseOperation{Operation: "subscribe to digest", Err: err}
+	}
+	return nil
+}
+
+func (r *TweetDigestRepo) Unsubscribe(ctx context.Context, uid, period, channel string) error {
+	query := `
+		DELETE FROM crypto_tweet_digest_subscription
+		WHERE crypto_user_id = (SELECT id FROM crypto_user WHERE uuid = $1)
+		AND period = $2
+		AND channel = $3
+	`
+	if _, err := r.cryptoDB.ExecContext(ctx, query, uid, period, channel); err != nil {
+		return &ErrDatabaseOperation{Operation: "unsubscribe from digest", Err: err}
+	}
+	return nil
+}
+
+// GetPendingSubscribers returns the subscriptions of the period that have not been sent the digest starting at
+// periodStart yet
+func (r *TweetDigestRepo) GetPendingSubscribers(ctx context.Context, period string, periodStart time.Time) ([]model.DigestSubscriber, error) {
+	query := `
+		SELECT u.uuid, s.channel, COALESCE(u.telegram_chat_id, '') AS telegram_chat_id, COALESCE(u.email, '') AS email
+		FROM crypto_tweet_digest_subscription s
+		INNER JOIN crypto_user u ON u.id = s.crypto_user_id
+		WHERE s.period = $1
+		AND (s.last_sent_period_start IS NULL OR s.last_sent_period_start < $2)
+	`
+	var subscribers []model.DigestSubscriber
+	if err := r.cryptoDB.SelectContext(ctx, &subscribers, query, period, periodStart); err != nil {
+		return nil, fmt.Errorf("failed to get digest subscribers: %w", err)
+	}
+	return subscribers, nil
+}
+
+func (r *TweetDigestRepo) MarkSent(ctx context.Context, uid, period, channel string, periodStart time.Time) error {
+	query := `
+		UPDATE crypto_tweet_digest_subscription
+		SET last_sent_period_start = $4, last_sent_at = CURRENT_TIMESTAMP
+		WHERE crypto_user_id = (SELECT id FROM crypto_user WHERE uuid = $1)
+		AND period = $2
+		AND channel = $3
+	`
+	if _, err := r.cryptoDB.ExecContext(ctx, query, uid, period, channel, periodStart); err != nil {
+		return &ErrDatabaseOperation{Operation: "mark digest sent", Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package repo
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// ts_headline marks matches with control characters rather than HTML, the tweet text is escaped before the
+// sentinels are swapped for <mark> tags so snippets are safe to render
+const (
+	headlineStartSel = "\x02"
+	headlineStopSel  = "\x03"
+)
+
+var headlineOptions = "StartSel=" + headlineStartSel + ", StopSel=" + headlineStopSel + ", MaxWords=35, MinWords=15, MaxFragments=2"
+
+var headlineReplacer = strings.NewReplacer(headlineStartSel, "<mark>", headlineStopSel, "</mark>")
+
+// buildTSQuery converts a user search string into to_tsquery syntax.
+//
+//	"funding rate"   phrase, words must be adjacent
+//	eth*             prefix match
+//	btc OR eth       either term, terms are ANDed by default
+//	-scam, NOT scam  exclude term
+//	( ... )          grouping
+//
+// Lexemes are reduced to letters, digits and underscores so user input can never produce invalid tsquery syntax.
+// An empty string is returned when nothing searchable is left.
+func buildTSQuery(input string) string {
+	var out []string
+	depth := 0
+
+	last := func() string {
+		if len(out) == 0 {
+			return ""
+		}
+		return out[len(out)-1]
+	}
+	endsOperand := func() bool {
+		l := last()
+		return l != "" && l != "&" && l != "|" && l != "!" && l != "("
+	}
+	pushOperand := func(operand string) {
+		if endsOperand() {
+			out = append(out, "&")
+		}
+		out = append(out, operand)
+	}
+	trimDangling := func() {
+		for len(out) > 0 && (last() == "&" || last() == "|" || last() == "!") {
+			out = out[:len(out)-1]
+		}
+	}
+
+	runes := []rune(input)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			var words []string
+			for _, w := range strings.Fields(string(runes[i+1 : min(end, len(runes))])) {
+				if lexeme := sanitizeLexeme(w); lexeme != "" {
+					words = append(words, lexeme)
+				}
+			}
+			if len(words) > 0 {
+				pushOperand("(" + strings.Join(words, " <-> ") + ")")
+			}
+			i = end + 1
+		case r == '(':
+			if endsOperand() {
+				out = append(out, "&")
+			}
+			out = append(out, "(")
+			depth++
+			i++
+		case r == ')':
+			if depth > 0 {
+				trimDangling()
+				if last() == "(" {
+					out = out[:len(out)-1]
+				} else {
+					out = append(out, ")")
+				}
+				depth--
+			}
+			i++
+		case r == '-' || r == '!':
+			if endsOperand() {
+				out = append(out, "&")
+			}
+			if last() != "!" {
+				out = append(out, "!")
+			}
+			i++
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && runes[end] != '(' && runes[end] != ')' && runes[end] != '"' {
+				end++
+			}
+			word := string(runes[i:end])
+			i = end
+
+			switch strings.ToUpper(word) {
+			case "OR", "|":
+				if endsOperand() {
+					out = append(out, "|")
+				}
+				continue
+			case "AND", "&":
+				continue
+			case "NOT":
+				if endsOperand() {
+					out = append(out, "&")
+				}
+				if last() != "!" {
+					out = append(out, "!")
+				}
+				continue
+			}
+
+			prefix := strings.HasSuffix(word, "*")
+			lexeme := sanitizeLexeme(word)
+			if lexeme == "" {
+				continue
+			}
+			if prefix {
+				lexeme += ":*"
+			}
+			pushOperand(lexeme)
+		}
+	}
+
+	trimDangling()
+	for ; depth > 0; depth-- {
+		if last() == "(" {
+			out = out[:len(out)-1]
+			trimDangling()
+			continue
+		}
+		out = append(out, ")")
+	}
+	trimDangling()
+	return strings.Join(out, " ")
+}
+
+func sanitizeLexeme(word string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(word) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// highlightSnippet HTML-escapes a ts_headline snippet and turns its match sentinels into <mark> tags
+func highlightSnippet(snippet string) string {
+	return headlineReplacer.Replace(html.EscapeString(snippet))
+}
@@ -123,7 +123,7 @@ func (r *TwitterCryptoRepo) GetAuthorWinrate(ctx context.Context, selectedWinrat
 	return profiles, nil
 }
 
-func (r *TwitterCryptoRepo) GetPaginatedTweets(ctx context.Context, start, limit int, sortBy, sortOrder string) ([]model.TwitterCryptoTweet, int, error) {
+func (r *TwitterCryptoRepo) GetPaginatedTweets(ctx context.Context, page model.FeedPage, sortBy, sortOrder string) ([]model.TwitterCryptoTweet, *model.FeedCursor, int, error) {
 	var tweets []model.TwitterCryptoTweet
 	var total int
 
@@ -141,12 +141,26 @@ func (r *TwitterCryptoRepo) GetPaginatedTweets(ctx context.Context, start, limit
 	if sortOrder != "asc" && sortOrder != "desc" {
 		sortOrder = "desc"
 	}
+	if err := checkFeedCursor(page, "tweet_created_at", sortBy, sortOrder); err != nil {
+		return nil, nil, 0, err
+	}
 
 	// Get total count
-	countQuery := `SELECT COUNT(*) FROM public.twitter_crypto_tweets_foxhole`
-	err := r.db.GetContext(ctx, &total, countQuery)
-	if err != nil {
-		return nil, 0, err
+	if page.WithTotal {
+		countQuery := `SELECT COUNT(*) FROM public.twitter_crypto_tweets_foxhole`
+		err := r.db.GetContext(ctx, &total, countQuery)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+	}
+
+	var args []interface{}
+	argCount := 1
+	whereClause := ""
+	if page.Cursor != nil {
+		whereClause = "WHERE " + feedKeysetClause("tweet_created_at", "id", sortOrder, argCount)
+		args = append(args, page.Cursor.CreatedAt, page.Cursor.ID)
+		argCount += 2
 	}
 
 	// Get paginated data
@@ -157,12 +171,24 @@ func (r *TwitterCryptoRepo) GetPaginatedTweets(ctx context.Context, start, limit
 		       isretweet, isquote, COALESCE(media_url, '') as media_url, tweet_created_date, author_username, 
 		       tickers_rule_based, created_at, updated_at
 		FROM public.twitter_crypto_tweets_foxhole
-		ORDER BY %s %s
-		LIMIT $1 OFFSET $2
-	`, sortBy, sortOrder)
+		%s
+		ORDER BY %s %s, id %s
+		LIMIT $%d OFFSET $%d
+	`, whereClause, sortBy, sortOrder, sortOrder, argCount, argCount+1)
 
-	err = r.db.SelectContext(ctx, &tweets, query, limit, start)
-	return tweets, total, err
+	args = append(args, page.Limit, feedOffset(page))
+
+	err := r.db.SelectContext(ctx, &tweets, query, args...)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	var next *model.FeedCursor
+	if sortBy == "tweet_created_at" && len(tweets) > 0 {
+		last := tweets[len(tweets)-1]
+		next = nextFeedCursor(len(tweets), page.Limit, last.TweetCreatedAt, last.ID, sortBy, sortOrder)
+	}
+	return tweets, next, total, nil
 }
 
 func (r *TwitterCryptoRepo) GetPaginatedSentiments(ctx context.Context, start, limit int, sortBy, sortOrder string) ([]model.TwitterCryptoSentiment, int, error) {
@@ -203,7 +229,7 @@ func (r *TwitterCryptoRepo) GetPaginatedSentiments(ctx context.Context, start, l
 	return sentiments, total, err
 }
 
-func (r *TwitterCryptoRepo) GetTweetsWithSentiments(ctx context.Context, start, limit int, sortBy, sortOrder string) ([]model.TwitterCryptoTweetWithSentiment, int, error) {
+func (r *TwitterCryptoRepo) GetTweetsWithSentiments(ctx context.Context, page model.FeedPage, sortBy, sortOrder string) ([]model.TwitterCryptoTweetWithSentiment, *model.FeedCursor, int, error) {
 	var tweets []model.TwitterCryptoTweetWithSentiment
 	var totalTweet int
 
@@ -227,16 +253,30 @@ func (r *TwitterCryptoRepo) GetTweetsWithSentiments(ctx context.Context, start,
 	if sortOrder != "asc" && sortOrder != "desc" {
 		sortOrder = "desc"
 	}
+	if err := checkFeedCursor(page, "t.tweet_created_at", sortBy, sortOrder); err != nil {
+		return nil, nil, 0, err
+	}
 
 	// Get total count
-	countTweetQuery := `
-		SELECT COUNT(*) 
-		FROM public.twitter_crypto_tweets_foxhole t
-		LEFT JOIN public.twitter_crypto_sentiment s ON t.id = s.id
-	`
-	err := r.db.GetContext(ctx, &totalTweet, countTweetQuery)
-	if err != nil {
-		return nil, 0, err
+	if page.WithTotal {
+		countTweetQuery := `
+			SELECT COUNT(*) 
+			FROM public.twitter_crypto_tweets_foxhole t
+			LEFT JOIN public.twitter_crypto_sentiment s ON t.id = s.id
+		`
+		err := r.db.GetContext(ctx, &totalTweet, countTweetQuery)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+	}
+
+	var args []interface{}
+	argCount := 1
+	whereClause := ""
+	if page.Cursor != nil {
+		whereClause = "WHERE " + feedKeysetClause("t.tweet_created_at", "t.id", sortOrder, argCount)
+		args = append(args, page.Cursor.CreatedAt, page.Cursor.ID)
+		argCount += 2
 	}
 
 	// Get paginated data with joined sentiment information
@@ -253,14 +293,28 @@ func (r *TwitterCryptoRepo) GetTweetsWithSentiments(ctx context.Context, start,
 			s.response_tickers
 		FROM public.twitter_crypto_tweets_foxhole t
 		LEFT JOIN public.twitter_crypto_sentiment s ON t.id = s.id
-		ORDER BY %s %s
-		LIMIT $1 OFFSET $2
-	`, sortBy, sortOrder)
+		%s
+		ORDER BY %s %s, t.id %s
+		LIMIT $%d OFFSET $%d
+	`, whereClause, sortBy, sortOrder, sortOrder, argCount, argCount+1)
+
+	args = append(args, page.Limit, feedOffset(page))
 
-	err = r.db.SelectContext(ctx, &tweets, query, limit, start)
-	return tweets, totalTweet, err
+	err := r.db.SelectContext(ctx, &tweets, query, args...)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	var next *model.FeedCursor
+	if sortBy == "t.tweet_created_at" && len(tweets) > 0 {
+		last := tweets[len(tweets)-1]
+		next = nextFeedCursor(len(tweets), page.Limit, last.TweetCreatedAt, last.ID, sortBy, sortOrder)
+	}
+	return tweets, next, totalTweet, nil
 }
 
+// GetTweetsWithSentimentAuthorSignal pages the tweets of the authors with their signals as extracted. It reads
+// twitter_crypto_signal_active rather than the reviewed view so reviewers see and flag the stored signal.
 func (r *TwitterCryptoRepo) GetTweetsWithSentimentAuthorSignal(ctx context.Context, start, limit int, sortBy, sortOrder string, authors []string, fromDate, toDate *time.Time) ([]model.TwitterCryptoTweetWithSentimentAuthorAndSignal, int, error) {
 	var tweets []model.TwitterCryptoTweetWithSentimentAuthorAndSignal
 	var total int
@@ -372,7 +426,7 @@ func (r *TwitterCryptoRepo) GetTweetsWithSentimentAuthorSignal(ctx context.Conte
 			ts.sentiment as signal_sentiment,
 			COALESCE(ts.tweet_id, td.id) as tweet_id
 		FROM tweet_data td
-		LEFT JOIN public.twitter_crypto_signal ts ON td.id = ts.tweet_id
+		LEFT JOIN public.twitter_crypto_signal_active ts ON td.id = ts.tweet_id
 		WHERE ts.ticker != 'NONE' AND ts.ticker != 'USDCUSDT
 		'
 	`, whereClause, sortBy, sortOrder, argCount, argCount+1)
@@ -389,7 +443,7 @@ func (r *TwitterCryptoRepo) GetTweetsWithSentimentAuthorSignal(ctx context.Conte
 	return tweets, total, err
 }
 
-func (r *TwitterCryptoRepo) GetTweetsWithSentimentsAndAuthor(ctx context.Context, start, limit int, sortBy, sortOrder string, authors []string, fromDate, toDate *time.Time, searchTokenSymbolValue string) ([]model.TwitterCryptoTweetWithSentimentAndAuthor, int, error) {
+func (r *TwitterCryptoRepo) GetTweetsWithSentimentsAndAuthor(ctx context.Context, page model.FeedPage, sortBy, sortOrder string, authors []string, fromDate, toDate *time.Time, searchTokenSymbolValue string) ([]model.TwitterCryptoTweetWithSentimentAndAuthor, *model.FeedCursor, int, error) {
 	var tweets []model.TwitterCryptoTweetWithSentimentAndAuthor
 	var totalTweet int
 
@@ -416,6 +470,9 @@ func (r *TwitterCryptoRepo) GetTweetsWithSentimentsAndAuthor(ctx context.Context
 	if sortOrder != "asc" && sortOrder != "desc" {
 		sortOrder = "desc"
 	}
+	if err := checkFeedCursor(page, "t.tweet_created_at", sortBy, sortOrder); err != nil {
+		return nil, nil, 0, err
+	}
 
 	// Build WHERE clause
 	var whereClauses []string
@@ -441,8 +498,9 @@ func (r *TwitterCryptoRepo) GetTweetsWithSentimentsAndAuthor(ctx context.Context
 	}
 
 	if searchTokenSymbolValue != "" {
-		whereClauses = append(whereClauses, fmt.Sprintf("s.response_tickers ILIKE $%d", argCount))
-		args = append(args, "%"+searchTokenSymbolValue+"%")
+		whereClauses = append(whereClauses, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM unnest(string_to_array(s.response_tickers, ',')) AS ticker WHERE canonical_ticker(ticker) = $%d)", argCount))
+		args = append(args, searchTokenSymbolValue)
 		argCount++
 	}
 
@@ -454,26 +512,28 @@ func (r *TwitterCryptoRepo) GetTweetsWithSentimentsAndAuthor(ctx context.Context
 	}
 
 	// Get total count with filters
-	countTweetQuery := fmt.Sprintf(`
-		SELECT COUNT(*) 
-		FROM public.twitter_crypto_tweets_foxhole t
-		LEFT JOIN public.twitter_crypto_sentiment s ON t.id = s.id
-		LEFT JOIN public.twitter_crypto_author_profile a ON t.author_username = a.author_username
-		%s
-	`, whereClause)
+	if page.WithTotal {
+		countTweetQuery := fmt.Sprintf(`
+			SELECT COUNT(*) 
+			FROM public.twitter_crypto_tweets_foxhole t
+			LEFT JOIN public.twitter_crypto_sentiment s ON t.id = s.id
+			LEFT JOIN public.twitter_crypto_author_profile a ON t.author_username = a.author_username
+			%s
+		`, whereClause)
 
-	if len(args) > 0 {
 		err := r.db.GetContext(ctx, &totalTweet, countTweetQuery, args...)
 		if err != nil {
-			return nil, 0, err
-		}
-	} else {
-		err := r.db.GetContext(ctx, &totalTweet, countTweetQuery)
-		if err != nil {
-			return nil, 0, err
+			return nil, nil, 0, err
 		}
 	}
 
+	// The cursor only narrows the page query, the total covers the whole filtered feed
+	if page.Cursor != nil {
+		whereClause += " AND " + feedKeysetClause("t.tweet_created_at", "t.id", sortOrder, argCount)
+		args = append(args, page.Cursor.CreatedAt, page.Cursor.ID)
+		argCount += 2
+	}
+
 	// Get paginated data with all filters
 	tweetWithGroupPaginatedQuery := fmt.Sprintf(`
 		SELECT 
@@ -498,18 +558,137 @@ func (r *TwitterCryptoRepo) GetTweetsWithSentimentsAndAuthor(ctx context.Context
 		LEFT JOIN public.twitter_crypto_sentiment s ON t.id = s.id
 		LEFT JOIN public.twitter_crypto_author_profile a ON t.author_username = a.author_username
 		%s
-		ORDER BY %s %s
+		ORDER BY %s %s, t.id %s
 		LIMIT $%d OFFSET $%d
-	`, whereClause, sortBy, sortOrder, argCount, argCount+1)
+	`, whereClause, sortBy, sortOrder, sortOrder, argCount, argCount+1)
 
 	// Add pagination parameters to args
-	args = append(args, limit, start)
+	args = append(args, page.Limit, feedOffset(page))
 
 	err := r.db.SelectContext(ctx, &tweets, tweetWithGroupPaginatedQuery, args...)
-	return tweets, totalTweet, err
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	var next *model.FeedCursor
+	if sortBy == "t.tweet_created_at" && len(tweets) > 0 {
+		last := tweets[len(tweets)-1]
+		next = nextFeedCursor(len(tweets), page.Limit, last.TweetCreatedAt, last.ID, sortBy, sortOrder)
+	}
+	return tweets, next, totalTweet, nil
+}
+
+// SearchTweets runs a full-text search over tweet text using the text_search tsvector column and combines it
+// with the author, sentiment, date and ticker filters of GetTweetsWithSentimentsAndAuthor
+func (r *TwitterCryptoRepo) SearchTweets(ctx context.Context, q model.TweetSearchQuery) ([]model.TwitterCryptoTweetSearchResult, int, error) {
+	tweets := []model.TwitterCryptoTweetSearchResult{}
+	var totalTweet int
+
+	tsQuery := buildTSQuery(q.Query)
+	if tsQuery == "" {
+		return tweets, 0, nil
+	}
+
+	var whereClauses []string
+	args := []interface{}{tsQuery}
+	argCount := 2
+
+	whereClauses = append(whereClauses, "t.text_search @@ query")
+
+	if len(q.Authors) > 0 {
+		whereClauses = append(whereClauses, fmt.Sprintf("t.author_username = ANY($%d)", argCount))
+		args = append(args, pq.Array(q.Authors))
+		argCount++
+	}
+
+	if q.Sentiment != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf("LOWER(s.response_sentiment) = LOWER($%d)", argCount))
+		args = append(args, q.Sentiment)
+		argCount++
+	}
+
+	if q.FromDate != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("t.tweet_created_at >= $%d", argCount))
+		args = append(args, q.FromDate)
+		argCount++
+	}
+
+	if q.ToDate != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("t.tweet_created_at <= $%d", argCount))
+		args = append(args, q.ToDate)
+		argCount++
+	}
+
+	if q.SearchTokenSymbolValue != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM unnest(string_to_array(s.response_tickers, ',')) AS ticker WHERE canonical_ticker(ticker) = $%d)", argCount))
+		args = append(args, q.SearchTokenSymbolValue)
+		argCount++
+	}
+
+	whereClauses = append(whereClauses, "a.is_select = true")
+	whereClause := "WHERE " + strings.Join(whereClauses, " AND ")
+
+	orderBy := "rank DESC, t.tweet_created_at DESC"
+	if q.SortBy == "recency" {
+		orderBy = "t.tweet_created_at DESC"
+	}
+
+	countTweetQuery := fmt.Sprintf(`
+		SELECT COUNT(*)
+		FROM public.twitter_crypto_tweets_foxhole t
+		CROSS JOIN to_tsquery('english', $1) query
+		LEFT JOIN public.twitter_crypto_sentiment s ON t.id = s.id
+		LEFT JOIN public.twitter_crypto_author_profile a ON t.author_username = a.author_username
+		%s
+	`, whereClause)
+
+	if err := r.db.GetContext(ctx, &totalTweet, countTweetQuery, args...); err != nil {
+		return nil, 0, err
+	}
+
+	searchQuery := fmt.Sprintf(`
+		SELECT
+			t.id, t.url, t.text, t.source, t.retweetcount, t.replycount,
+			t.likecount, t.quotecount,
+			CASE
+				WHEN t.viewcount IS NULL THEN 0
+				WHEN t.viewcount = 'NaN' THEN 0
+				ELSE t.viewcount::float
+			END as viewcount, t.tweet_created_at, t.bookmarkcount, t.isreply, t.conversationid,
+			t.ispinned, t.isretweet, t.isquote, COALESCE(t.media_url, '') as media_url, t.tweet_created_date,
+			t.author_username, t.tickers_rule_based, t.created_at, t.updated_at,
+			s.response_score,
+			COALESCE(s.response_sentiment, '') as response_sentiment,
+			COALESCE(s.response_tickers, '') as response_tickers,
+			a.author_url,
+			a.author_twitterurl,
+			a.author_name,
+			a.author_followers,
+			a.author_following,
+			ts_rank_cd(t.text_search, query) AS rank,
+			ts_headline('english', t.text, query, $%d) AS snippet
+		FROM public.twitter_crypto_tweets_foxhole t
+		CROSS JOIN to_tsquery('english', $1) query
+		LEFT JOIN public.twitter_crypto_sentiment s ON t.id = s.id
+		LEFT JOIN public.twitter_crypto_author_profile a ON t.author_username = a.author_username
+		%s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d
+	`, argCount, whereClause, orderBy, argCount+1, argCount+2)
+
+	args = append(args, headlineOptions, q.Limit, q.Start)
+
+	if err := r.db.SelectContext(ctx, &tweets, searchQuery, args...); err != nil {
+		return tweets, totalTweet, err
+	}
+	for i := range tweets {
+		tweets[i].Snippet = highlightSnippet(tweets[i].Snippet)
+	}
+	return tweets, totalTweet, nil
 }
 
-func (r *TwitterCryptoRepo) GetSummaries(ctx context.Context, start, limit int, sortBy, sortOrder string, fromDate, toDate *time.Time) ([]model.TwitterCryptoSummary, int, error) {
+func (r *TwitterCryptoRepo) GetSummaries(ctx context.Context, page model.FeedPage, sortBy, sortOrder string, fromDate, toDate *time.Time) ([]model.TwitterCryptoSummary, *model.FeedCursor, int, error) {
 	var summaries []model.TwitterCryptoSummary
 	var totalSummary int
 
@@ -525,6 +704,9 @@ func (r *TwitterCryptoRepo) GetSummaries(ctx context.Context, start, limit int,
 	if sortOrder != "asc" && sortOrder != "desc" {
 		sortOrder = "desc"
 	}
+	if err := checkFeedCursor(page, "date_time", sortBy, sortOrder); err != nil {
+		return nil, nil, 0, err
+	}
 
 	// Build WHERE clause for date filtering
 	var whereClauses []string
@@ -549,37 +731,58 @@ func (r *TwitterCryptoRepo) GetSummaries(ctx context.Context, start, limit int,
 	}
 
 	// Get total count with filters
-	countQuery := fmt.Sprintf(`
-		SELECT COUNT(*) 
-		FROM public.twitter_crypto_key_topic
-		%s`, whereClause)
+	if page.WithTotal {
+		countQuery := fmt.Sprintf(`
+			SELECT COUNT(*) 
+			FROM public.twitter_crypto_key_topic
+			%s`, whereClause)
 
-	if len(args) > 0 {
 		err := r.db.GetContext(ctx, &totalSummary, countQuery, args...)
 		if err != nil {
-			return nil, 0, err
+			return nil, nil, 0, err
 		}
-	} else {
-		err := r.db.GetContext(ctx, &totalSummary, countQuery)
-		if err != nil {
-			return nil, 0, err
+	}
+
+	// Several key topics can share an hour, the cursor continues after (date_time, created_at, id) of the last row
+	if page.Cursor != nil {
+		if page.Cursor.InsertedAt == nil {
+			return nil, nil, 0, model.ErrInvalidFeedCursor
 		}
+		op := "<"
+		if sortOrder == "asc" {
+			op = ">"
+		}
+		whereClauses = append(whereClauses, fmt.Sprintf("(date_time, created_at, id) %s ($%d, $%d, $%d)", op, argCount, argCount+1, argCount+2))
+		whereClause = "WHERE " + strings.Join(whereClauses, " AND ")
+		args = append(args, page.Cursor.CreatedAt, *page.Cursor.InsertedAt, page.Cursor.ID)
+		argCount += 3
 	}
 
 	// Get paginated data with date filters
 	topicQuery := fmt.Sprintf(`
-		SELECT date_time, response_topic, created_at, updated_at
+		SELECT id, date_time, response_topic, created_at, updated_at
 		FROM public.twitter_crypto_key_topic
 		%s
-		ORDER BY %s %s
+		ORDER BY %s %s, created_at %s, id %s
 		LIMIT $%d OFFSET $%d
-	`, whereClause, sortBy, sortOrder, argCount, argCount+1)
+	`, whereClause, sortBy, sortOrder, sortOrder, sortOrder, argCount, argCount+1)
 
 	// Add pagination parameters to args
-	args = append(args, limit, start)
+	args = append(args, page.Limit, feedOffset(page))
 
 	err := r.db.SelectContext(ctx, &summaries, topicQuery, args...)
-	return summaries, totalSummary, err
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	var next *model.FeedCursor
+	if sortBy == "date_time" && len(summaries) > 0 {
+		last := summaries[len(summaries)-1]
+		if next = nextFeedCursor(len(summaries), page.Limit, last.DateTime, last.ID, sortBy, sortOrder); next != nil {
+			next.InsertedAt = &last.CreatedAt
+		}
+	}
+	return summaries, next, totalSummary, nil
 }
 
 func (r *TwitterCryptoRepo) GetBubbleSentiment(ctx context.Context) (model.BubbleSentimentModel, error) {
@@ -609,8 +812,8 @@ func (r *TwitterCryptoRepo) GetBubbleSentiment(ctx context.Context) (model.Bubbl
 		WITH cleaned AS (
 			SELECT 
 				response_sentiment,
-				LOWER(translate(response_tickers, '$[]''', '')) AS response_tickers_clean
-			FROM 
+				response_tickers
+			FROM
 				twitter_crypto_sentiment t 
 			WHERE 
 				created_at >= NOW() - INTERVAL '24 hours'
@@ -618,9 +821,9 @@ func (r *TwitterCryptoRepo) GetBubbleSentiment(ctx context.Context) (model.Bubbl
 		unnested AS (
 			SELECT 
 				response_sentiment,
-				TRIM(ticker) AS ticker
+				LOWER(canonical_ticker(ticker)) AS ticker
 			FROM cleaned,
-				unnest(string_to_array(response_tickers_clean, ',')) AS ticker
+				unnest(string_to_array(response_tickers, ',')) AS ticker
 		),
 		agg AS (
 			SELECT 
@@ -660,6 +863,74 @@ func (r *TwitterCryptoRepo) GetBubbleSentiment(ctx context.Context) (model.Bubbl
 	}, nil
 }
 
+// GetSentimentBuckets counts the tweet sentiment of the tickers per bucket. Each response_tickers entry is resolved
+// with canonical_ticker and matched exactly against the canonical tickers.
+func (r *TwitterCryptoRepo) GetSentimentBuckets(ctx context.Context, q model.SentimentTimeSeriesQuery, bucket time.Duration) ([]model.SentimentBucketCount, error) {
+	args := []interface{}{pq.Array(q.Tickers), q.From, q.To, int64(bucket.Seconds())}
+	authorClause := ""
+	if len(q.Authors) > 0 {
+		authorClause = "AND t.author_username = ANY($5)"
+		args = append(args, pq.Array(q.Authors))
+	}
+
+	query := fmt.Sprintf(`
+		WITH mentions AS (
+			SELECT
+				t.tweet_created_at,
+				s.response_sentiment,
+				canonical_ticker(ticker) AS ticker
+			FROM public.twitter_crypto_sentiment s
+			JOIN public.twitter_crypto_tweets_foxhole t ON t.id = s.id,
+				unnest(string_to_array(s.response_tickers, ',')) AS ticker
+			WHERE t.tweet_created_at >= $2
+			AND t.tweet_created_at < $3
+			%s
+		)
+		SELECT
+			ticker,
+			to_timestamp(floor(extract(epoch FROM tweet_created_at) / $4) * $4) AS bucket_start,
+			COUNT(*) FILTER (WHERE response_sentiment = 'Positive') AS positive,
+			COUNT(*) FILTER (WHERE response_sentiment = 'Neutral') AS neutral,
+			COUNT(*) FILTER (WHERE response_sentiment = 'Negative') AS negative
+		FROM mentions
+		WHERE ticker = ANY($1)
+		GROUP BY ticker, bucket_start
+		ORDER BY ticker, bucket_start
+	`, authorClause)
+
+	var counts []model.SentimentBucketCount
+	if err := r.db.SelectContext(ctx, &counts, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get sentiment buckets: %w", err)
+	}
+	return counts, nil
+}
+
+// GetTopMentionedTickers returns the tickers with the most tweet mentions in [from, to), normalized like GetSentimentBuckets
+func (r *TwitterCryptoRepo) GetTopMentionedTickers(ctx context.Context, from, to time.Time, limit int) ([]string, error) {
+	query := `
+		SELECT ticker
+		FROM (
+			SELECT canonical_ticker(ticker) AS ticker
+			FROM public.twitter_crypto_sentiment s
+			JOIN public.twitter_crypto_tweets_foxhole t ON t.id = s.id,
+				unnest(string_to_array(s.response_tickers, ',')) AS ticker
+			WHERE t.tweet_created_at >= $1
+			AND t.tweet_created_at < $2
+		) m
+		WHERE ticker NOT IN ('', 'NAN', 'NONE')
+		GROUP BY ticker
+		ORDER BY COUNT(*) DESC, ticker ASC
+		LIMIT $3
+	`
+	var tickers []string
+	if err := r.db.SelectContext(ctx, &tickers, query, from, to, limit); err != nil {
+		return nil, fmt.Errorf("failed to get top mentioned tickers: %w", err)
+	}
+	return tickers, nil
+}
+
+// SearchTokenMentionSymbolsByAuthors returns the symbols mentioned by the authors, most recently mentioned first.
+// createdAt and id continue the list after the latest mention of the previous page's last symbol.
 func (r *TwitterCryptoRepo) SearchTokenMentionSymbolsByAuthors(ctx context.Context, symbol string, createdAt string, id string, limit int, selectedTimeRange string, authors []string) ([]model.TokenMentionSymbolAndAuthor, time.Time, string, int, error) {
 
 	timeRangeMap := map[string]string{
@@ -670,33 +941,72 @@ func (r *TwitterCryptoRepo) SearchTokenMentionSymbolsByAuthors(ctx context.Conte
 		"6m": "180 days",
 	}
 
-	querySearchTokenMentionsSymbol := fmt.Sprintf(`
-		SELECT 
-			DISTINCT REPLACE(tcs.ticker, 'USDT', '') as symbol
-		FROM twitter_crypto_signal tcs 
-		JOIN twitter_crypto_tweets_foxhole tct 
-			ON tcs.tweet_id = tct.id
-		JOIN twitter_crypto_author_profile tcap 
-			ON tct.author_username = tcap.author_username
-		WHERE tcap.author_username = ANY($1)
-			AND tct.tweet_created_at >= NOW() - INTERVAL '%s'
-			AND tcs.ticker != 'NONE'
-    		AND tcs.ticker != ''
-    		AND tcs.ticker IS NOT NULL
-	`, timeRangeMap[selectedTimeRange])
-
-	var tokens []model.TokenMentionSymbolAndAuthor
-	var lastCreatedAt time.Time
-	var lastId string
+	args := []interface{}{pq.Array(authors)}
+	argCount := 2
 
-	err := r.db.SelectContext(ctx, &tokens, querySearchTokenMentionsSymbol, pq.Array(authors))
+	cursorClause := ""
+	if createdAt != "" {
+		cursorCreatedAt, err := time.Parse(time.RFC3339Nano, createdAt)
+		if err != nil {
+			return nil, time.Time{}, "", 0, model.ErrInvalidFeedCursor
+		}
+		cursorClause = "WHERE " + feedKeysetClause("m.last_created_at", "m.last_id", "desc", argCount)
+		args = append(args, cursorCreatedAt, id)
+		argCount += 2
+	}
+
+	querySearchTokenMentionsSymbol := fmt.Sprintf(`
+		SELECT symbol, last_created_at, last_id
+		FROM (
+			SELECT DISTINCT ON (canonical_ticker(tcs.ticker))
+				canonical_ticker(tcs.ticker) as symbol,
+				tct.tweet_created_at as last_created_at,
+				tct.id as last_id
+			FROM twitter_crypto_signal_reviewed tcs 
+			JOIN twitter_crypto_tweets_foxhole tct 
+				ON tcs.tweet_id = tct.id
+			JOIN twitter_crypto_author_profile tcap 
+				ON tct.author_username = tcap.author_username
+			WHERE tcap.author_username = ANY($1)
+				AND tct.tweet_created_at >= NOW() - INTERVAL '%s'
+				AND tcs.ticker != 'NONE'
+				AND tcs.ticker != ''
+				AND tcs.ticker IS NOT NULL
+			ORDER BY canonical_ticker(tcs.ticker), tct.tweet_created_at DESC, tct.id DESC
+		) m
+		%s
+		ORDER BY m.last_created_at DESC, m.last_id DESC
+		LIMIT NULLIF($%d, 0)
+	`, timeRangeMap[selectedTimeRange], cursorClause, argCount)
+	args = append(args, limit)
+
+	var rows []struct {
+		model.TokenMentionSymbolAndAuthor
+		LastCreatedAt time.Time `db:"last_created_at"`
+		LastID        string    `db:"last_id"`
+	}
+	err := r.db.SelectContext(ctx, &rows, querySearchTokenMentionsSymbol, args...)
 	if err != nil {
 		return nil, time.Time{}, "", 0, err
 	}
+
+	tokens := make([]model.TokenMentionSymbolAndAuthor, len(rows))
+	for i, row := range rows {
+		tokens[i] = row.TokenMentionSymbolAndAuthor
+	}
+
+	var lastCreatedAt time.Time
+	var lastId string
+	if limit > 0 && len(rows) == limit {
+		lastCreatedAt = rows[len(rows)-1].LastCreatedAt
+		lastId = rows[len(rows)-1].LastID
+	}
 	return tokens, lastCreatedAt, lastId, len(tokens), nil
 }
 
-func (r *TwitterCryptoRepo) GetTweetsWithSentimentsAndTier(ctx context.Context, start, limit int, sortBy, sortOrder string, authors []string, fromDate, toDate *time.Time) ([]model.TwitterCryptoTweetWithSentimentAndTier, int, error) {
+// GetTweetsWithSentimentsAndTier pages the tweets of the tier's authors with their signals as extracted. It reads
+// twitter_crypto_signal_active rather than the reviewed view so reviewers see and flag the stored signal.
+func (r *TwitterCryptoRepo) GetTweetsWithSentimentsAndTier(ctx context.Context, page model.FeedPage, sortBy, sortOrder string, authors []string, fromDate, toDate *time.Time) ([]model.TwitterCryptoTweetWithSentimentAndTier, *model.FeedCursor, int, error) {
 	var tweets []model.TwitterCryptoTweetWithSentimentAndTier
 	var totalTweet int
 
@@ -723,6 +1033,9 @@ func (r *TwitterCryptoRepo) GetTweetsWithSentimentsAndTier(ctx context.Context,
 	if sortOrder != "asc" && sortOrder != "desc" {
 		sortOrder = "desc"
 	}
+	if err := checkFeedCursor(page, "t.tweet_created_at", sortBy, sortOrder); err != nil {
+		return nil, nil, 0, err
+	}
 
 	// Build WHERE clause
 	var whereClauses []string
@@ -755,26 +1068,33 @@ func (r *TwitterCryptoRepo) GetTweetsWithSentimentsAndTier(ctx context.Context,
 	}
 
 	// Get total count with filters
-	countTweetQuery := fmt.Sprintf(`
-		SELECT COUNT(*) 
-		FROM public.twitter_crypto_tweets_foxhole t
-		LEFT JOIN public.twitter_crypto_sentiment s ON t.id = s.id
-		LEFT JOIN public.twitter_crypto_author_profile a ON t.author_username = a.author_username
-		%s
-	`, whereClause)
+	if page.WithTotal {
+		countTweetQuery := fmt.Sprintf(`
+			SELECT COUNT(*) 
+			FROM public.twitter_crypto_tweets_foxhole t
+			LEFT JOIN public.twitter_crypto_sentiment s ON t.id = s.id
+			LEFT JOIN public.twitter_crypto_author_profile a ON t.author_username = a.author_username
+			%s
+		`, whereClause)
 
-	if len(args) > 0 {
 		err := r.db.GetContext(ctx, &totalTweet, countTweetQuery, args...)
 		if err != nil {
-			return nil, 0, err
-		}
-	} else {
-		err := r.db.GetContext(ctx, &totalTweet, countTweetQuery)
-		if err != nil {
-			return nil, 0, err
+			return nil, nil, 0, err
 		}
 	}
 
+	// Cursor pages only hold tweets that have a signal shown below, so every page is full. Offset pages keep the
+	// tweets without one so their results and total are unchanged.
+	if page.Cursor != nil {
+		whereClause += ` AND EXISTS (
+			SELECT 1 FROM public.twitter_crypto_signal_active ts
+			WHERE ts.tweet_id = t.id AND ts.ticker != 'NONE' AND ts.ticker != 'USDCUSDT'
+		)`
+		whereClause += " AND " + feedKeysetClause("t.tweet_created_at", "t.id", sortOrder, argCount)
+		args = append(args, page.Cursor.CreatedAt, page.Cursor.ID)
+		argCount += 2
+	}
+
 	// Updated query to include signal information using CTE
 	tweetWithGroupPaginatedQuery := fmt.Sprintf(`
 		WITH tweet_data AS (
@@ -785,15 +1105,17 @@ func (r *TwitterCryptoRepo) GetTweetsWithSentimentsAndTier(ctx context.Context,
 				COALESCE(s.response_sentiment, '') as response_sentiment,
 				COALESCE(s.response_tickers, '') as response_tickers,
 				t.tweet_created_at,
-				t.tweet_created_date
+				t.tweet_created_date,
+				%s AS sort_value
 			FROM public.twitter_crypto_tweets_foxhole t
 			LEFT JOIN public.twitter_crypto_sentiment s ON t.id = s.id
 			LEFT JOIN public.twitter_crypto_author_profile a ON t.author_username = a.author_username
 			%s
-			ORDER BY %s %s
+			ORDER BY %s %s, t.id %s
 			LIMIT $%d OFFSET $%d
 		)
 		SELECT 
+			td.tweet_id,
 			td.response_score,
 			td.response_sentiment,
 			td.response_tickers,
@@ -805,21 +1127,30 @@ func (r *TwitterCryptoRepo) GetTweetsWithSentimentsAndTier(ctx context.Context,
 			ts.ticker as signal_ticker,
 			COALESCE(ts.action, 'NONE') as signal_action,
 			ts.score as signal_score,
-			ts.sentiment as signal_sentiment
+			ts.sentiment as signal_sentiment,
+			(SELECT COUNT(*) FROM tweet_data) AS page_tweets
 		FROM tweet_data td
-		LEFT JOIN public.twitter_crypto_signal ts ON td.tweet_id = ts.tweet_id
+		LEFT JOIN public.twitter_crypto_signal_active ts ON td.tweet_id = ts.tweet_id
 		WHERE ts.ticker != 'NONE' AND ts.ticker != 'USDCUSDT'
-	`, whereClause, sortBy, sortOrder, argCount, argCount+1)
+		ORDER BY td.sort_value %s, td.tweet_id %s
+	`, sortBy, whereClause, sortBy, sortOrder, sortOrder, argCount, argCount+1, sortOrder, sortOrder)
 
 	// Add pagination parameters to args
-	args = append(args, limit, start)
+	args = append(args, page.Limit, feedOffset(page))
 
 	// Execute query and scan into tweets
 	err := r.db.SelectContext(ctx, &tweets, tweetWithGroupPaginatedQuery, args...)
 	if err != nil {
-		return nil, 0, fmt.Errorf("error scanning tweets: %v", err)
+		return nil, nil, 0, fmt.Errorf("error scanning tweets: %v", err)
 	}
 
-	return tweets, totalTweet, err
+	// A tweet can have several signals and offset pages can hold tweets without any, so whether the page is full is
+	// read from page_tweets. The cursor points after the last tweet with a signal, the keyset skips the others.
+	var next *model.FeedCursor
+	if sortBy == "t.tweet_created_at" && len(tweets) > 0 {
+		last := tweets[len(tweets)-1]
+		next = nextFeedCursor(last.PageTweets, page.Limit, last.TweetCreatedAt, last.TweetID, sortBy, sortOrder)
+	}
+	return tweets, next, totalTweet, nil
 }
 
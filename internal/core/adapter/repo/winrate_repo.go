@@ -1,7 +1,13 @@
 package repo
 
 import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/quantsmithapp/datastation-backend/internal/model"
 )
 
@@ -28,3 +34,37 @@ func (r *winRateRepo) GetWinRate() ([]model.WinRateEntities, error) {
 	var winRateResult []model.WinRateEntities
 	return winRateResult, nil
 }
+
+// GetSignalsForWinrate returns LONG/SHORT signals of selected authors whose tweet was posted in [from, to), with
+// signal reviews applied. Tickers are resolved with canonical_ticker, both the stored ones and the filter.
+func (r *winRateRepo) GetSignalsForWinrate(ctx context.Context, from, to time.Time, tickers, authors []string) ([]model.WinrateSignal, error) {
+	var queryBuilder strings.Builder
+	queryBuilder.WriteString(`
+		SELECT s.tweet_id, t.author_username, canonical_ticker(s.ticker) AS ticker, COALESCE(s.action, '') AS action,
+			COALESCE(s.sentiment, '') AS sentiment, t.tweet_created_at
+		FROM twitter_crypto_signal_reviewed s
+		INNER JOIN twitter_crypto_tweets_foxhole t ON s.tweet_id = t.id
+		INNER JOIN twitter_crypto_author_profile a ON a.author_username = t.author_username
+		WHERE a.is_select = true
+		AND t.tweet_created_at >= $1 AND t.tweet_created_at < $2
+		AND (s.action IN ('LONG', 'SHORT') OR s.sentiment IN ('Bullish', 'Bearish'))
+	`)
+	args := []interface{}{from, to}
+
+	if len(tickers) > 0 {
+		args = append(args, pq.Array(tickers))
+		queryBuilder.WriteString(fmt.Sprintf(
+			" AND canonical_ticker(s.ticker) IN (SELECT canonical_ticker(ticker) FROM unnest($%d::text[]) AS ticker)", len(args)))
+	}
+	if len(authors) > 0 {
+		args = append(args, pq.Array(authors))
+		queryBuilder.WriteString(fmt.Sprintf(" AND t.author_username = ANY($%d)", len(args)))
+	}
+	queryBuilder.WriteString(" ORDER BY t.tweet_created_at ASC")
+
+	var signals []model.WinrateSignal
+	if err := r.db.SelectContext(ctx, &signals, queryBuilder.String(), args...); err != nil {
+		return nil, fmt.Errorf("failed to get signals for winrate: %w", err)
+	}
+	return signals, nil
+}
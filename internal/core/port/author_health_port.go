@@ -0,0 +1,34 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
+
+type AuthorHealthRepo interface {
+	GetAuthorDailyNav(ctx context.Context, since time.Time) (map[string][]model.Nav, error)
+	GetAuthorHealth(ctx context.Context, authorUsername string) ([]model.AuthorHealth, error)
+	// UpsertAuthorHealth stores the health, clearing AlertedAt when the status changed
+	UpsertAuthorHealth(ctx context.Context, health model.AuthorHealth) error
+	MarkAuthorHealthAlerted(ctx context.Context, authorUsername string, alertedAt time.Time) error
+	GetAlertedSubscribers(ctx context.Context, authorUsername string, since time.Time) (map[string]bool, error)
+	MarkSubscriberAlerted(ctx context.Context, authorUsername, uid string, alertedAt time.Time) error
+	GetAuthorSubscribers(ctx context.Context, authorUsername string) ([]model.AuthorSubscriber, error)
+	PauseSubscription(ctx context.Context, walletID, authorUsername, reason string) error
+	GetPausedSubscriptions(ctx context.Context, uid string) ([]model.PausedSubscription, error)
+	ResumeSubscription(ctx context.Context, uid, walletID, authorUsername string) error
+	GetPreference(ctx context.Context, uid string) (model.AuthorHealthPreference, error)
+	UpdatePreference(ctx context.Context, uid string, autoPause bool) error
+}
+
+type AuthorHealthService interface {
+	Start(ctx context.Context)
+	Evaluate(ctx context.Context) ([]model.AuthorHealth, error)
+	GetAuthorHealth(ctx context.Context, authorUsername string) ([]model.AuthorHealth, error)
+	GetPausedSubscriptions(ctx context.Context, uid string) ([]model.PausedSubscription, error)
+	ResumeSubscription(ctx context.Context, uid, walletID, authorUsername string) error
+	GetPreference(ctx context.Context, uid string) (model.AuthorHealthPreference, error)
+	UpdatePreference(ctx context.Context, uid string, autoPause bool) error
+}
@@ -0,0 +1,17 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
+
+type AuthorNetworkRepo interface {
+	GetAuthorTickerSignals(ctx context.Context, since time.Time) ([]model.AuthorTickerSignal, error)
+}
+
+type AuthorNetworkService interface {
+	GetSimilarAuthors(ctx context.Context, username string, windowDays, minShared, limit int) (model.SimilarAuthorsResult, error)
+	GetClusters(ctx context.Context, windowDays, minShared int) (model.AuthorClusterResult, error)
+}
@@ -1,11 +1,22 @@
 package port
 
+import "context"
+
 type AuthorTierRepo interface {
 	GetAuthorsByTier(tier string) ([]string, error)
 	GetAllTiers() ([]string, error)
+	UpdateAuthorTier(authorUsername, tier string) error
+	// ReplaceAuthorTier sets the tier and returns the one it replaced, a nil tier is no tier
+	ReplaceAuthorTier(ctx context.Context, authorUsername string, tier *string) (*string, error)
+	// RestoreAuthorTier sets the tier back to previous while it is still replaced, it returns false when the tier
+	// changed meanwhile and was left as is
+	RestoreAuthorTier(ctx context.Context, authorUsername string, replaced, previous *string) (bool, error)
 }
 
 type AuthorTierService interface {
 	GetAuthorsByTier(tier string) ([]string, error)
 	GetAllTiers() ([]string, error)
+	UpdateAuthorTier(authorUsername, tier string) error
+	ReplaceAuthorTier(ctx context.Context, authorUsername string, tier *string) (*string, error)
+	RestoreAuthorTier(ctx context.Context, authorUsername string, replaced, previous *string) (bool, error)
 }
@@ -0,0 +1,20 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
+
+type ConsensusRepo interface {
+	GetLatestAuthorSignals(ctx context.Context, ticker, tier string, since time.Time) ([]model.ConsensusSignal, error)
+	InsertConsensusSnapshots(ctx context.Context, snapshots []model.ConsensusSnapshot) error
+	GetConsensusSnapshots(ctx context.Context, ticker, tier string, windowHours int, from, to time.Time) ([]model.ConsensusSnapshot, error)
+}
+
+type ConsensusService interface {
+	GetConsensus(ctx context.Context, query model.ConsensusQuery) ([]model.TickerConsensus, error)
+	Snapshot(ctx context.Context) error
+	GetConsensusHistory(ctx context.Context, query model.ConsensusQuery, from, to time.Time) ([]model.ConsensusSnapshot, error)
+}
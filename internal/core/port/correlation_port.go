@@ -0,0 +1,11 @@
+package port
+
+import (
+	"context"
+
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
+
+type CorrelationService interface {
+	GetCorrelation(ctx context.Context, req model.CorrelationRequest) (model.CorrelationResult, error)
+}
@@ -0,0 +1,12 @@
+package port
+
+import (
+	"context"
+
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
+
+type DivergenceService interface {
+	GetTickerDivergence(ctx context.Context, req model.DivergenceRequest) (model.TickerDivergence, error)
+	Scan(ctx context.Context, req model.DivergenceRequest, tickerLimit int, activeOnly bool) ([]model.DivergenceEvent, error)
+}
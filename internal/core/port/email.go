@@ -0,0 +1,8 @@
+package port
+
+import "context"
+
+// EmailService sends plain text emails
+type EmailService interface {
+	SendEmail(ctx context.Context, to, subject, body string) error
+}
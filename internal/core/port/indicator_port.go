@@ -0,0 +1,11 @@
+package port
+
+import (
+	"context"
+
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
+
+type IndicatorService interface {
+	GetIndicators(ctx context.Context, req model.IndicatorRequest) (model.IndicatorResult, error)
+}
@@ -0,0 +1,43 @@
+package port
+
+import (
+	"context"
+
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
+
+// SignalEventBus carries the events raised by ingestion to the components that react to new tweets and signals
+type SignalEventBus interface {
+	Publish(event model.SignalEvent)
+	Subscribe(name string, handler func(model.SignalEvent))
+}
+
+type IngestRepo interface {
+	CreateAPIKey(ctx context.Context, key model.IngestAPIKey, keyHash string) (model.IngestAPIKey, error)
+	GetAPIKeys(ctx context.Context) ([]model.IngestAPIKey, error)
+	RevokeAPIKey(ctx context.Context, id string) error
+	// UseAPIKey returns the active key with the given hash and records its use
+	UseAPIKey(ctx context.Context, keyHash string) (model.IngestAPIKey, error)
+
+	// The upserts return per record whether it was created rather than updated
+	UpsertTweets(ctx context.Context, tweets []model.TwitterCryptoTweet) ([]bool, error)
+	UpsertSentiments(ctx context.Context, sentiments []model.TwitterCryptoSentiment) ([]bool, error)
+	ReplaceSignals(ctx context.Context, tweets []model.IngestTweetSignals) ([]bool, error)
+	GetTweetAuthors(ctx context.Context, tweetIDs []string) (map[string]string, error)
+}
+
+// IngestAuthenticator checks ingestion API keys for the middleware
+type IngestAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, key string) (model.IngestAPIKey, error)
+}
+
+type IngestService interface {
+	IngestAuthenticator
+	CreateAPIKey(ctx context.Context, req model.IngestAPIKeyRequest, createdBy string) (model.CreatedIngestAPIKey, error)
+	GetAPIKeys(ctx context.Context) ([]model.IngestAPIKey, error)
+	RevokeAPIKey(ctx context.Context, id string) error
+
+	IngestTweets(ctx context.Context, tweets []model.TwitterCryptoTweet) (model.IngestResult, error)
+	IngestSentiments(ctx context.Context, sentiments []model.TwitterCryptoSentiment) (model.IngestResult, error)
+	IngestSignals(ctx context.Context, tweets []model.IngestTweetSignals) (model.IngestResult, error)
+}
@@ -12,6 +12,6 @@ type MarketOverviewRepo interface {
 type MarketOverviewService interface {
 	GetMarketOverview(days int, tier string) (model.MarketOverview, error)
 	GetMarketOverviewTable(tier string, timeframe string) ([]model.MarketOverviewTableResponse, error)
-	GetEnrichedMarketOverviewTable(tier string, timeframe string, scannerResult map[string]interface{}) ([]model.EnrichedMarketOverview, error)
+	GetEnrichedMarketOverviewTable(tier string, timeframe string) ([]model.EnrichedMarketOverview, error)
 	GetTokenDetailMarketOverview(token string, days int) ([]model.TokenDetailMarketOverview, error)
 }
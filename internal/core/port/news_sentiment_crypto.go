@@ -9,8 +9,12 @@ import (
 
 type NewsSentimentCryptoRepo interface {
 	GetNewsSentiment(ctx context.Context, date time.Time) ([]model.NewsSentimentEntities, error)
+	GetNewsMentions(ctx context.Context, req model.NewsSentimentRequest) ([]model.NewsArticleMention, error)
+	GetNewsSources(ctx context.Context) ([]string, error)
 }
 
 type NewsSentimentCryptoService interface {
 	GetNewsSentiment(ctx context.Context, date time.Time) ([]model.NewsSentimentEntities, error)
+	QueryNewsSentiment(ctx context.Context, req model.NewsSentimentRequest) (model.NewsSentimentResult, error)
+	GetNewsSources(ctx context.Context) ([]string, error)
 }
@@ -17,6 +17,7 @@ type NotificationRepo interface {
 	GetTelegram(ctx context.Context, uid string) (model.Telegram, error)
 	DeleteGroupAuthor(ctx context.Context, uid, groupID string) error
 	DisconnectNotification(ctx context.Context, uid string) error
+	GetAuthorFollowerTelegrams(ctx context.Context, authorUsername string) ([]model.Telegram, error)
 }
 
 type NotificationService interface {
@@ -0,0 +1,16 @@
+package port
+
+import (
+	"context"
+	"io"
+
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
+
+type OHLCVExportService interface {
+	PrepareExport(req model.OHLCVExportRequest) (model.OHLCVExportRequest, error)
+	WriteExport(ctx context.Context, req model.OHLCVExportRequest, w io.Writer) (int64, error)
+	CreateJob(ctx context.Context, userUUID string, req model.OHLCVExportRequest) (model.OHLCVExportJob, error)
+	GetJob(userUUID, id string) (model.OHLCVExportJob, error)
+	GetJobFile(userUUID, id string) (path string, fileName string, err error)
+}
@@ -0,0 +1,44 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
+
+// OHLCVQualityRepo runs the data quality checks on the Timescale OHLCV tables and stores their findings. Checks read
+// the candles from since on, gap and non-monotonic checks need a table with a timeframe.
+type OHLCVQualityRepo interface {
+	GetOHLCVTables(ctx context.Context) ([]model.OHLCVTable, error)
+	// FindGaps reports each run of missing candles between two candles of a ticker, with the missing ones counted
+	FindGaps(ctx context.Context, table model.OHLCVTable, since time.Time) ([]model.OHLCVQualityFinding, error)
+	FindDuplicates(ctx context.Context, table model.OHLCVTable, since time.Time) ([]model.OHLCVQualityFinding, error)
+	FindNonMonotonic(ctx context.Context, table model.OHLCVTable, since time.Time) ([]model.OHLCVQualityFinding, error)
+	FindZeroVolumeRuns(ctx context.Context, table model.OHLCVTable, since time.Time, minRun int) ([]model.OHLCVQualityFinding, error)
+	FindInconsistentCandles(ctx context.Context, table model.OHLCVTable, since time.Time) ([]model.OHLCVQualityFinding, error)
+	// GetLatestCandleTimes returns the time of the latest candle of each ticker with candles since
+	GetLatestCandleTimes(ctx context.Context, table model.OHLCVTable, since time.Time) (map[string]time.Time, error)
+	// InsertCandles adds the candles whose bucket has none for their ticker and returns how many were added
+	InsertCandles(ctx context.Context, table model.OHLCVTable, candles []model.OHLCVData) (int, error)
+
+	SaveQualityRun(ctx context.Context, run model.OHLCVQualityRun, findings []model.OHLCVQualityFinding) error
+	// GetQualityRun returns the run at runAt, or the latest one when runAt is nil
+	GetQualityRun(ctx context.Context, runAt *time.Time) (model.OHLCVQualityRun, error)
+	GetQualityRuns(ctx context.Context, limit int) ([]model.OHLCVQualityRun, error)
+	GetQualityFindings(ctx context.Context, filter model.OHLCVQualityFilter) ([]model.OHLCVQualityFinding, error)
+	CountQualityFindings(ctx context.Context, filter model.OHLCVQualityFilter) (map[string]int, error)
+}
+
+// OHLCVBackfillSource fetches the latest candles of a ticker from an upstream feed, interval is in TradingView's
+// notation such as 60 or 1D
+type OHLCVBackfillSource interface {
+	GetCandles(ticker string, exchange string, interval string, bars int) ([]model.OHLCVData, error)
+}
+
+type OHLCVQualityService interface {
+	// TriggerScan starts a scan in the background
+	TriggerScan() error
+	GetReport(ctx context.Context, filter model.OHLCVQualityFilter) (model.OHLCVQualityReport, error)
+	GetRuns(ctx context.Context, limit int) ([]model.OHLCVQualityRun, error)
+}
@@ -12,6 +12,7 @@ type PerformanceRepo interface {
 	AuthorMultiholdingDetailRepo(ctx context.Context, authorUsername string, period string, holdingPeriod string, start int, limit int) (model.AuthorDetail, error)
 	GetAuthorSentimentAnalysis(ctx context.Context, authorUsername string, period string) (bearishTokens []model.SentimentToken, bullishTokens []model.SentimentToken, err error)
 	MultiholdingPortNavRepo(ctx context.Context, period string, holdingPeriod string) ([]model.AuthorNav, error)
+	AuthorNavHistoryRepo(ctx context.Context, authorUsername string, holdingPeriod string) ([]model.Nav, error)
 }
 
 type PerformanceService interface {
@@ -19,4 +20,5 @@ type PerformanceService interface {
 	GetAuthorDetail(ctx context.Context, authorUsername string, period string, start int, limit int) (model.AuthorDetail, error)
 	GetAuthorMultiholdingDetail(ctx context.Context, authorUsername string, period string, holdingPeriod string, start int, limit int) (model.AuthorDetail, error)
 	GetMultiholdingPortNav(ctx context.Context, period string, holdingPeriod string) ([]model.AuthorNav, error)
+	GetAuthorReturnStats(ctx context.Context, authorUsername string, holdingPeriod string) (model.AuthorReturnStats, error)
 }
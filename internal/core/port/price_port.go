@@ -1,7 +1,23 @@
 package port
 
-import "github.com/quantsmithapp/datastation-backend/internal/model"
+import (
+	"context"
+
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
 
 type PricePort interface {
-	GetPriceChange(symbols []string, scannerData []model.ScannerData) map[string]interface{}
+	GetPriceChange(symbols []string) map[string]*float64
+}
+
+// PriceProvider loads the latest prices of every asset it covers
+type PriceProvider interface {
+	Name() string
+	FetchPrices(ctx context.Context) ([]model.MarketPrice, error)
+}
+
+// PriceCache serves the latest prices by canonical ticker, any spelling the ticker resolver knows can be looked up
+type PriceCache interface {
+	GetPrice(symbol string) (model.MarketPrice, bool)
+	GetPrices(symbols []string) map[string]model.MarketPrice
 }
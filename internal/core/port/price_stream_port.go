@@ -0,0 +1,21 @@
+package port
+
+import (
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
+
+// PriceQuoteSource is the upstream feed of quotes, subscribed once per symbol
+type PriceQuoteSource interface {
+	Subscribe(symbol string) error
+	Unsubscribe(symbol string) error
+}
+
+// PriceHub fans quotes out to the clients of the price stream. A client's channel is closed when it is dropped for
+// not keeping up or unregistered.
+type PriceHub interface {
+	Register() (clientID uint64, messages <-chan []byte)
+	Unregister(clientID uint64)
+	Subscribe(clientID uint64, symbols []string) ([]string, error)
+	Unsubscribe(clientID uint64, symbols []string) []string
+	Send(clientID uint64, event model.PriceStreamEvent)
+}
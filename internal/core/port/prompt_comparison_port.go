@@ -0,0 +1,20 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
+
+type PromptComparisonRepo interface {
+	GetPromptVersions(ctx context.Context, since time.Time) ([]model.PromptVersionStats, error)
+	GetPromptVersionSignals(ctx context.Context, versionA, versionB string, from, to time.Time) ([]model.PromptVersionSignal, int, error)
+	SetActivePromptVersion(ctx context.Context, promptVersion, updatedBy string) error
+}
+
+type PromptComparisonService interface {
+	GetPromptVersions(ctx context.Context, since time.Time) ([]model.PromptVersionStats, error)
+	ComparePromptVersions(ctx context.Context, req model.PromptComparisonRequest) (model.PromptComparison, error)
+	SetActivePromptVersion(ctx context.Context, promptVersion, updatedBy string) error
+}
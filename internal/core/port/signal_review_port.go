@@ -0,0 +1,26 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
+
+type SignalReviewRepo interface {
+	FlagSignal(ctx context.Context, uid string, flag model.SignalFlagRequest) error
+	GetSignalReviews(ctx context.Context, query model.SignalReviewQuery) ([]model.SignalReviewItem, int, error)
+	ReviewSignal(ctx context.Context, decision model.SignalReviewDecision) error
+	GetSignalReviewAudit(ctx context.Context, tweetID, ticker string) ([]model.SignalReviewAudit, error)
+	GetSignalTrainingExamples(ctx context.Context, from, to time.Time, promptVersion string) ([]model.SignalTrainingExample, error)
+}
+
+type SignalReviewService interface {
+	FlagSignal(ctx context.Context, uid string, req model.SignalFlagRequest) error
+	GetSignalReviews(ctx context.Context, query model.SignalReviewQuery) (model.SignalReviewPage, error)
+	CorrectSignal(ctx context.Context, req model.SignalCorrectionRequest, reviewer string) error
+	VoidSignal(ctx context.Context, req model.SignalDecisionRequest, reviewer string) error
+	ConfirmSignal(ctx context.Context, req model.SignalDecisionRequest, reviewer string) error
+	GetSignalReviewAudit(ctx context.Context, tweetID, ticker string) ([]model.SignalReviewAudit, error)
+	GetSignalTrainingExamples(ctx context.Context, from, to time.Time, promptVersion string) ([]model.SignalTrainingExample, error)
+}
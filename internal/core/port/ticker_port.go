@@ -0,0 +1,32 @@
+package port
+
+import (
+	"context"
+
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
+
+// TickerResolver maps any ticker spelling to its canonical asset ID
+type TickerResolver interface {
+	Resolve(ticker string) string
+	ResolveTicker(ticker string) model.ResolvedTicker
+}
+
+type TickerRepo interface {
+	GetAssets(ctx context.Context) ([]model.CryptoAsset, error)
+	GetAliases(ctx context.Context) ([]model.TickerAlias, error)
+	UpsertAsset(ctx context.Context, asset model.CryptoAssetRequest) error
+	DeleteAsset(ctx context.Context, id string) error
+	UpsertAlias(ctx context.Context, alias model.TickerAliasRequest) error
+	DeleteAlias(ctx context.Context, alias string) error
+}
+
+type TickerService interface {
+	TickerResolver
+	GetAssets(ctx context.Context) ([]model.CryptoAsset, error)
+	UpsertAsset(ctx context.Context, req model.CryptoAssetRequest) error
+	DeleteAsset(ctx context.Context, id string) error
+	UpsertAlias(ctx context.Context, req model.TickerAliasRequest) error
+	DeleteAlias(ctx context.Context, alias string) error
+	Reload(ctx context.Context) error
+}
@@ -0,0 +1,27 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
+
+type TierEngineRepo interface {
+	GetAuthorTierMetrics(ctx context.Context, since time.Time) ([]model.AuthorTierMetrics, error)
+	GetAuthorDailyNav(ctx context.Context, since time.Time) (map[string][]model.Nav, error)
+	InsertTierProposals(ctx context.Context, proposals []model.AuthorTierProposal) error
+	GetTierProposals(ctx context.Context, status string) ([]model.AuthorTierProposal, error)
+	GetTierProposalByID(ctx context.Context, id string) (model.AuthorTierProposal, error)
+	// ReviewTierProposal moves a pending proposal to status and runs apply, if any, before committing. The proposal
+	// stays pending when it was reviewed or superseded meanwhile or when apply fails.
+	ReviewTierProposal(ctx context.Context, id, status, reviewedBy string, apply func() error) error
+}
+
+type TierEngineService interface {
+	Start(ctx context.Context)
+	Recompute(ctx context.Context) ([]model.AuthorTierProposal, error)
+	GetProposals(ctx context.Context, status string) ([]model.AuthorTierProposal, error)
+	ApproveProposal(ctx context.Context, id, reviewedBy string) (model.AuthorTierProposal, error)
+	RejectProposal(ctx context.Context, id, reviewedBy string) (model.AuthorTierProposal, error)
+}
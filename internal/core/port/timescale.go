@@ -1,10 +1,15 @@
 package port
 
 import (
+	"context"
+
 	"github.com/quantsmithapp/datastation-backend/internal/model"
 )
 
 type TimescaleRepo interface {
 	GetCryptoOHLCV(req model.OHLCVRequest) ([]model.OHLCVData, error)
 	GetForexOHLCV(req model.OHLCVRequest) ([]model.OHLCVData, error)
+	// StreamCryptoOHLCV passes the candles GetCryptoOHLCV would return to fn as they are read, stopping at fn's first error
+	StreamCryptoOHLCV(ctx context.Context, req model.OHLCVRequest, fn func(model.OHLCVData) error) error
+	StreamForexOHLCV(ctx context.Context, req model.OHLCVRequest, fn func(model.OHLCVData) error) error
 }
@@ -0,0 +1,26 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
+
+type TrendingRepo interface {
+	GetTickerMentionWindows(ctx context.Context, windowEnd time.Time, window time.Duration, windows int) ([]model.TickerMentionWindow, error)
+	GetAuthorWeightInputs(ctx context.Context) ([]model.AuthorWeightInput, error)
+	GetWatchlist(ctx context.Context, uid string) ([]model.WatchlistTicker, error)
+	UpsertWatchlistTicker(ctx context.Context, uid, ticker string, trendingAlert bool) error
+	DeleteWatchlistTicker(ctx context.Context, uid, ticker string) error
+	GetTrendingAlertSubscribers(ctx context.Context, tickers []string, alertedBefore time.Time) ([]model.WatchlistAlertSubscriber, error)
+	MarkTrendingAlerted(ctx context.Context, uid, ticker string, alertedAt time.Time) error
+}
+
+type TrendingService interface {
+	GetTrending(ctx context.Context, weight string, limit int) (model.TrendingResult, error)
+	Evaluate(ctx context.Context) (model.TrendingResult, error)
+	GetWatchlist(ctx context.Context, uid string) ([]model.WatchlistTicker, error)
+	UpsertWatchlistTicker(ctx context.Context, uid, ticker string, trendingAlert bool) error
+	DeleteWatchlistTicker(ctx context.Context, uid, ticker string) error
+}
@@ -0,0 +1,32 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
+
+type TweetDigestRepo interface {
+	GetKeyTopics(ctx context.Context, from, to time.Time) ([]model.DigestTopic, error)
+	GetTickerStats(ctx context.Context, from, to time.Time) ([]model.DigestTickerStats, error)
+	GetTopAuthors(ctx context.Context, from, to time.Time, limit int) ([]model.DigestAuthor, error)
+	GetNewCalls(ctx context.Context, from, to, lookbackFrom time.Time, limit int) ([]model.DigestCall, error)
+	SaveDigest(ctx context.Context, digest model.TweetDigest) (model.TweetDigest, error)
+	GetDigest(ctx context.Context, period string, periodStart *time.Time) (model.TweetDigest, error)
+	GetDigests(ctx context.Context, period string, limit int) ([]model.TweetDigest, error)
+	GetSubscriptions(ctx context.Context, uid string) ([]model.DigestSubscription, error)
+	Subscribe(ctx context.Context, uid, period, channel string) error
+	Unsubscribe(ctx context.Context, uid, period, channel string) error
+	GetPendingSubscribers(ctx context.Context, period string, periodStart time.Time) ([]model.DigestSubscriber, error)
+	MarkSent(ctx context.Context, uid, period, channel string, periodStart time.Time) error
+}
+
+type TweetDigestService interface {
+	BuildDigest(ctx context.Context, req model.BuildDigestRequest) (model.TweetDigest, error)
+	GetDigest(ctx context.Context, period string, periodStart *time.Time) (model.TweetDigest, error)
+	GetDigests(ctx context.Context, period string, limit int) ([]model.TweetDigest, error)
+	GetSubscriptions(ctx context.Context, uid string) ([]model.DigestSubscription, error)
+	Subscribe(ctx context.Context, uid string, req model.DigestSubscriptionRequest) error
+	Unsubscribe(ctx context.Context, uid string, req model.DigestSubscriptionRequest) error
+}
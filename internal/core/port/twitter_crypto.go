@@ -12,14 +12,17 @@ type TwitterCryptoRepo interface {
 	GetAllTweets(ctx context.Context) ([]model.TwitterCryptoTweet, error)
 	GetAuthorProfiles(ctx context.Context) ([]model.TwitterCryptoAuthorProfile, error)
 	GetAuthorWinrate(ctx context.Context, selectedWinratePeriod string) ([]model.TwitterCryptoAuthorWinRate, error)
-	GetPaginatedTweets(ctx context.Context, start, limit int, sortBy, sortOrder string) ([]model.TwitterCryptoTweet, int, error)
+	GetPaginatedTweets(ctx context.Context, page model.FeedPage, sortBy, sortOrder string) ([]model.TwitterCryptoTweet, *model.FeedCursor, int, error)
 	GetPaginatedSentiments(ctx context.Context, start, limit int, sortBy, sortOrder string) ([]model.TwitterCryptoSentiment, int, error)
-	GetTweetsWithSentiments(ctx context.Context, start, limit int, sortBy, sortOrder string) ([]model.TwitterCryptoTweetWithSentiment, int, error)
+	GetTweetsWithSentiments(ctx context.Context, page model.FeedPage, sortBy, sortOrder string) ([]model.TwitterCryptoTweetWithSentiment, *model.FeedCursor, int, error)
 	GetTweetsWithSentimentAuthorSignal(ctx context.Context, start, limit int, sortBy, sortOrder string, authors []string, fromDate, toDate *time.Time) ([]model.TwitterCryptoTweetWithSentimentAuthorAndSignal, int, error)
-	GetTweetsWithSentimentsAndAuthor(ctx context.Context, start, limit int, sortBy, sortOrder string, authors []string, fromDate, toDate *time.Time, searchTokenSymbolValue string) ([]model.TwitterCryptoTweetWithSentimentAndAuthor, int, error)
-	GetTweetsWithSentimentsAndTier(ctx context.Context, start, limit int, sortBy, sortOrder string, authors []string, fromDate, toDate *time.Time) ([]model.TwitterCryptoTweetWithSentimentAndTier, int, error)
-	GetSummaries(ctx context.Context, start, limit int, sortBy, sortOrder string, fromDate, toDate *time.Time) ([]model.TwitterCryptoSummary, int, error)
+	GetTweetsWithSentimentsAndAuthor(ctx context.Context, page model.FeedPage, sortBy, sortOrder string, authors []string, fromDate, toDate *time.Time, searchTokenSymbolValue string) ([]model.TwitterCryptoTweetWithSentimentAndAuthor, *model.FeedCursor, int, error)
+	SearchTweets(ctx context.Context, q model.TweetSearchQuery) ([]model.TwitterCryptoTweetSearchResult, int, error)
+	GetTweetsWithSentimentsAndTier(ctx context.Context, page model.FeedPage, sortBy, sortOrder string, authors []string, fromDate, toDate *time.Time) ([]model.TwitterCryptoTweetWithSentimentAndTier, *model.FeedCursor, int, error)
+	GetSummaries(ctx context.Context, page model.FeedPage, sortBy, sortOrder string, fromDate, toDate *time.Time) ([]model.TwitterCryptoSummary, *model.FeedCursor, int, error)
 	GetBubbleSentiment(ctx context.Context) (model.BubbleSentimentModel, error)
+	GetSentimentBuckets(ctx context.Context, q model.SentimentTimeSeriesQuery, bucket time.Duration) ([]model.SentimentBucketCount, error)
+	GetTopMentionedTickers(ctx context.Context, from, to time.Time, limit int) ([]string, error)
 	SearchTokenMentionSymbolsByAuthors(ctx context.Context, symbol string, createdAt string, id string, limit int, selectedTimeRange string, authors []string) ([]model.TokenMentionSymbolAndAuthor, time.Time, string, int, error)
 }
 
@@ -28,13 +31,16 @@ type TwitterCryptoService interface {
 	GetAllTweets(ctx context.Context) ([]model.TwitterCryptoTweet, error)
 	GetAuthorProfiles(ctx context.Context) ([]model.TwitterCryptoAuthorProfile, error)
 	GetAuthorWinrate(ctx context.Context, selectedWinratePeriod string) ([]model.TwitterCryptoAuthorWinRate, error)
-	GetPaginatedTweets(ctx context.Context, start, limit int, sortBy, sortOrder string) ([]model.TwitterCryptoTweet, int, error)
+	GetPaginatedTweets(ctx context.Context, page model.FeedPage, sortBy, sortOrder string) ([]model.TwitterCryptoTweet, *model.FeedCursor, int, error)
 	GetPaginatedSentiments(ctx context.Context, start, limit int, sortBy, sortOrder string) ([]model.TwitterCryptoSentiment, int, error)
-	GetTweetsWithSentiments(ctx context.Context, start, limit int, sortBy, sortOrder string) ([]model.TwitterCryptoTweetWithSentiment, int, error)
-	GetTweetsWithSentimentsAndAuthor(ctx context.Context, start, limit int, sortBy, sortOrder string, authors []string, fromDate, toDate *time.Time, searchTokenSymbolValue string) ([]model.TwitterCryptoTweetWithSentimentAndAuthor, int, error)
+	GetTweetsWithSentiments(ctx context.Context, page model.FeedPage, sortBy, sortOrder string) ([]model.TwitterCryptoTweetWithSentiment, *model.FeedCursor, int, error)
+	GetTweetsWithSentimentsAndAuthor(ctx context.Context, page model.FeedPage, sortBy, sortOrder string, authors []string, fromDate, toDate *time.Time, searchTokenSymbolValue string) ([]model.TwitterCryptoTweetWithSentimentAndAuthor, *model.FeedCursor, int, error)
+	SearchTweets(ctx context.Context, q model.TweetSearchQuery) ([]model.TwitterCryptoTweetSearchResult, int, error)
 	GetTweetsWithSentimentAuthorSignal(ctx context.Context, start, limit int, sortBy, sortOrder string, authors []string, fromDate, toDate *time.Time) ([]model.TwitterCryptoTweetWithSentimentAuthorAndSignal, int, error)
-	GetTweetsWithSentimentsAndTier(ctx context.Context, start, limit int, sortBy, sortOrder string, authors []string, fromDate, toDate *time.Time) ([]model.TwitterCryptoTweetWithSentimentAndTier, int, error)
-	GetSummaries(ctx context.Context, start, limit int, sortBy, sortOrder string, fromDate, toDate *time.Time) ([]model.TwitterCryptoSummary, int, error)
+	GetTweetsWithSentimentsAndTier(ctx context.Context, page model.FeedPage, sortBy, sortOrder string, authors []string, fromDate, toDate *time.Time) ([]model.TwitterCryptoTweetWithSentimentAndTier, *model.FeedCursor, int, error)
+	GetSummaries(ctx context.Context, page model.FeedPage, sortBy, sortOrder string, fromDate, toDate *time.Time) ([]model.TwitterCryptoSummary, *model.FeedCursor, int, error)
 	GetBubbleSentiment(ctx context.Context) (model.BubbleSentimentModel, error)
+	GetSentimentTimeSeries(ctx context.Context, q model.SentimentTimeSeriesQuery) ([]model.TickerSentimentSeries, error)
+	GetTopMentionedTickers(ctx context.Context, from, to time.Time, limit int) ([]string, error)
 	SearchTokenMentionSymbolsByAuthors(ctx context.Context, symbol string, createdAt string, id string, limit int, selectedTimeRange string, authors []string) ([]model.TokenMentionSymbolAndAuthor, time.Time, string, int, error)
 }
@@ -1,11 +1,18 @@
 package port
 
-import "github.com/quantsmithapp/datastation-backend/internal/model"
+import (
+	"context"
+	"time"
+
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
 
 type WinRateRepo interface {
 	GetWinRate() ([]model.WinRateEntities, error)
+	GetSignalsForWinrate(ctx context.Context, from, to time.Time, tickers, authors []string) ([]model.WinrateSignal, error)
 }
 
 type WinRateService interface {
 	GetWinRate() ([]model.WinRateModel, error)
+	CalculateWinrate(ctx context.Context, req model.WinrateCalcRequest) (model.WinrateCalcResult, error)
 }
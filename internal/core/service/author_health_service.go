@@ -0,0 +1,350 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/quantsmithapp/datastation-backend/config"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
+)
+
+const (
+	defaultAuthorHealthIntervalMinutes = 360
+	defaultAuthorHealthRecentDays      = 14
+	defaultAuthorHealthBaselineDays    = 180
+	defaultAuthorHealthZThreshold      = 2.0
+	minAuthorHealthRecentReturns       = 5
+	minAuthorHealthBaselineReturns     = 20
+)
+
+type AuthorHealthService struct {
+	repo             port.AuthorHealthRepo
+	notificationRepo port.NotificationRepo
+	telegramService  port.TelegramService
+	config           config.AuthorHealthConfig
+}
+
+func NewAuthorHealthService(repo port.AuthorHealthRepo, notificationRepo port.NotificationRepo, telegramService port.TelegramService, cfg config.AuthorHealthConfig) *AuthorHealthService {
+	if cfg.IntervalMinutes <= 0 {
+		cfg.IntervalMinutes = defaultAuthorHealthIntervalMinutes
+	}
+	if cfg.RecentDays <= 0 {
+		cfg.RecentDays = defaultAuthorHealthRecentDays
+	}
+	if cfg.BaselineDays <= 0 {
+		cfg.BaselineDays = defaultAuthorHealthBaselineDays
+	}
+	if cfg.ZThreshold <= 0 {
+		cfg.ZThreshold = defaultAuthorHealthZThreshold
+	}
+	return &AuthorHealthService{
+		repo:             repo,
+		notificationRepo: notificationRepo,
+		telegramService:  telegramService,
+		config:           cfg,
+	}
+}
+
+// Start evaluates author health on the configured interval until ctx is cancelled
+func (s *AuthorHealthService) Start(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(s.config.IntervalMinutes) * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.Evaluate(ctx); err != nil {
+			logger.Errorf("author health: evaluation failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Evaluate recomputes the health of every author and alerts the subscribers of degraded authors not alerted yet
+func (s *AuthorHealthService) Evaluate(ctx context.Context) ([]model.AuthorHealth, error) {
+	now := time.Now()
+	recentCutoff := now.AddDate(0, 0, -s.config.RecentDays)
+	since := recentCutoff.AddDate(0, 0, -s.config.BaselineDays)
+
+	navMap, err := s.repo.GetAuthorDailyNav(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := s.repo.GetAuthorHealth(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	previousByAuthor := make(map[string]model.AuthorHealth, len(previous))
+	for _, h := range previous {
+		previousByAuthor[h.AuthorUsername] = h
+	}
+
+	result := make([]model.AuthorHealth, 0, len(navMap))
+	for authorUsername, navs := range navMap {
+		health := assessAuthorHealth(navs, recentCutoff, s.config.ZThreshold)
+		health.AuthorUsername = authorUsername
+		health.EvaluatedAt = now
+
+		// A degraded author stays unalerted until every subscriber was, so failed alerts are retried next time
+		health.StatusChangedAt = now
+		prev, ok := previousByAuthor[authorUsername]
+		if ok && prev.Status == health.Status {
+			health.AlertedAt = prev.AlertedAt
+			health.StatusChangedAt = prev.StatusChangedAt
+		}
+		if err := s.repo.UpsertAuthorHealth(ctx, health); err != nil {
+			logger.Errorf("author health: failed to store health for %s: %v", authorUsername, err)
+			continue
+		}
+
+		if health.Status == model.AuthorHealthDegraded && health.AlertedAt == nil {
+			if err := s.alertSubscribers(ctx, health, now); err != nil {
+				logger.Errorf("author health: alerts for %s failed, retrying them at the next evaluation: %v", authorUsername, err)
+			} else if err := s.repo.MarkAuthorHealthAlerted(ctx, authorUsername, now); err != nil {
+				logger.Errorf("author health: failed to mark %s alerted: %v", authorUsername, err)
+			} else {
+				health.AlertedAt = &now
+			}
+		}
+		result = append(result, health)
+	}
+	return result, nil
+}
+
+// alertSubscribers pauses opted-in subscriptions and sends one Telegram message per subscribed user. Users alerted
+// since the status changed are skipped, and a user is only recorded as alerted once their subscriptions were paused
+// and their message was sent. It fails when any user could not be alerted.
+func (s *AuthorHealthService) alertSubscribers(ctx context.Context, health model.AuthorHealth, now time.Time) error {
+	subscribers, err := s.repo.GetAuthorSubscribers(ctx, health.AuthorUsername)
+	if err != nil {
+		return fmt.Errorf("failed to get subscribers: %w", err)
+	}
+	alerted, err := s.repo.GetAlertedSubscribers(ctx, health.AuthorUsername, health.StatusChangedAt)
+	if err != nil {
+		return fmt.Errorf("failed to get alerted subscribers: %w", err)
+	}
+
+	var errs []error
+
+	walletsByUser := make(map[string][]string)
+	pauseFailed := make(map[string]bool)
+	for _, sub := range subscribers {
+		if alerted[sub.UserUUID] {
+			continue
+		}
+		line := sub.WalletName
+		if line == "" {
+			line = sub.WalletID
+		}
+		if sub.AutoPause {
+			if err := s.repo.PauseSubscription(ctx, sub.WalletID, health.AuthorUsername, "author performance degraded"); err != nil {
+				errs = append(errs, fmt.Errorf("failed to pause wallet %s: %w", sub.WalletID, err))
+				pauseFailed[sub.UserUUID] = true
+			} else {
+				line += " (paused)"
+			}
+		}
+		walletsByUser[sub.UserUUID] = append(walletsByUser[sub.UserUUID], line)
+	}
+
+	for uid, wallets := range walletsByUser {
+		// The user is alerted once every wallet is paused, paused wallets are no longer subscribers next time
+		if pauseFailed[uid] {
+			continue
+		}
+		if err := s.notifySubscriber(ctx, uid, authorHealthMessage(health, wallets)); err != nil {
+			errs = append(errs, fmt.Errorf("failed to notify user %s: %w", uid, err))
+			continue
+		}
+		if err := s.repo.MarkSubscriberAlerted(ctx, health.AuthorUsername, uid, now); err != nil {
+			errs = append(errs, fmt.Errorf("failed to mark user %s alerted: %w", uid, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// notifySubscriber sends the message to the user's Telegram, users without a valid Telegram link are skipped
+func (s *AuthorHealthService) notifySubscriber(ctx context.Context, uid, message string) error {
+	if s.telegramService == nil {
+		return nil
+	}
+	telegram, err := s.notificationRepo.GetTelegram(ctx, uid)
+	if err != nil || telegram.ChatID == "" {
+		return nil
+	}
+	chatID, err := strconv.ParseInt(telegram.ChatID, 10, 64)
+	if err != nil {
+		logger.Errorf("author health: invalid telegram chat id for user %s: %v", uid, err)
+		return nil
+	}
+	return s.telegramService.SendMessage(ctx, message, chatID)
+}
+
+func authorHealthMessage(health model.AuthorHealth, wallets []string) string {
+	return fmt.Sprintf(
+		"⚠️ Performance alert: %s\n"+
+			"Recent positive NAV days %.1f%% vs baseline %.1f%%\n"+
+			"Recent ROI %.2f%% vs baseline %.2f%%\n"+
+			"Recent max drawdown %.1f%% vs baseline %.1f%%\n"+
+			"Wallets: %s",
+		health.AuthorUsername,
+		health.RecentPositiveDayShare*100, health.BaselinePositiveDayShare*100,
+		health.RecentROI, health.BaselineROI,
+		health.RecentDrawdown*100, health.BaselineDrawdown*100,
+		strings.Join(wallets, ", "),
+	)
+}
+
+func (s *AuthorHealthService) GetAuthorHealth(ctx context.Context, authorUsername string) ([]model.AuthorHealth, error) {
+	return s.repo.GetAuthorHealth(ctx, authorUsername)
+}
+
+func (s *AuthorHealthService) GetPausedSubscriptions(ctx context.Context, uid string) ([]model.PausedSubscription, error) {
+	return s.repo.GetPausedSubscriptions(ctx, uid)
+}
+
+func (s *AuthorHealthService) ResumeSubscription(ctx context.Context, uid, walletID, authorUsername string) error {
+	return s.repo.ResumeSubscription(ctx, uid, walletID, authorUsername)
+}
+
+func (s *AuthorHealthService) GetPreference(ctx context.Context, uid string) (model.AuthorHealthPreference, error) {
+	return s.repo.GetPreference(ctx, uid)
+}
+
+func (s *AuthorHealthService) UpdatePreference(ctx context.Context, uid string, autoPause bool) error {
+	return s.repo.UpdatePreference(ctx, uid, autoPause)
+}
+
+// assessAuthorHealth splits the daily NAV at recentCutoff and compares the recent window to the baseline before it.
+// The author is degraded when the share of positive days (two-proportion z-test over days) or the mean daily return
+// (Welch t-test) of the recent window is significantly below the baseline, or its drawdown is significantly above the
+// drawdowns of baseline windows of the same length. Every test needs the same minimum samples and threshold.
+func assessAuthorHealth(navs []model.Nav, recentCutoff time.Time, zThreshold float64) model.AuthorHealth {
+	health := model.AuthorHealth{Status: model.AuthorHealthInsufficientData}
+
+	split := len(navs)
+	for i, nav := range navs {
+		if !nav.Datetime.Before(recentCutoff) {
+			split = i
+			break
+		}
+	}
+	if split == 0 || split == len(navs) {
+		return health
+	}
+
+	// The recent window starts from the last baseline NAV so its first return is not lost
+	baselineNavs := navs[:split]
+	recentNavs := navs[split-1:]
+	baselineReturns := dailyReturns(baselineNavs)
+	recentReturns := dailyReturns(recentNavs)
+
+	health.BaselinePositiveDayShare = winningShare(baselineReturns)
+	health.RecentPositiveDayShare = winningShare(recentReturns)
+	health.BaselineROI, _ = percentChange(baselineNavs[0].Nav, baselineNavs[len(baselineNavs)-1].Nav)
+	health.RecentROI, _ = percentChange(recentNavs[0].Nav, recentNavs[len(recentNavs)-1].Nav)
+	health.BaselineDrawdown = maxDrawdown(baselineNavs)
+	health.RecentDrawdown = maxDrawdown(recentNavs)
+
+	if len(recentReturns) < minAuthorHealthRecentReturns || len(baselineReturns) < minAuthorHealthBaselineReturns {
+		return health
+	}
+
+	health.PositiveDayZScore = twoProportionZ(
+		health.RecentPositiveDayShare, len(recentReturns), health.BaselinePositiveDayShare, len(baselineReturns))
+	health.ReturnTStat = welchT(recentReturns, baselineReturns)
+	health.DrawdownZScore = drawdownZ(health.RecentDrawdown, baselineNavs, len(recentNavs))
+	health.Status = model.AuthorHealthHealthy
+	if health.PositiveDayZScore <= -zThreshold || health.ReturnTStat <= -zThreshold || health.DrawdownZScore >= zThreshold {
+		health.Status = model.AuthorHealthDegraded
+	}
+	return health
+}
+
+func dailyReturns(navs []model.Nav) []float64 {
+	returns := make([]float64, 0, len(navs))
+	for i := 1; i < len(navs); i++ {
+		r := navs[i].Nav/navs[i-1].Nav - 1
+		if math.IsNaN(r) || math.IsInf(r, 0) {
+			continue
+		}
+		returns = append(returns, r)
+	}
+	return returns
+}
+
+func winningShare(returns []float64) float64 {
+	if len(returns) == 0 {
+		return 0
+	}
+	wins := 0
+	for _, r := range returns {
+		if r > 0 {
+			wins++
+		}
+	}
+	return float64(wins) / float64(len(returns))
+}
+
+// drawdownZ scores the drawdown against the max drawdowns of every baseline window of size NAVs. It is 0 when the
+// baseline has fewer windows than the minimum baseline sample or their drawdowns do not vary.
+func drawdownZ(drawdown float64, baselineNavs []model.Nav, size int) float64 {
+	windows := len(baselineNavs) - size + 1
+	if size < 2 || windows < minAuthorHealthBaselineReturns {
+		return 0
+	}
+	drawdowns := make([]float64, windows)
+	for i := range drawdowns {
+		drawdowns[i] = maxDrawdown(baselineNavs[i : i+size])
+	}
+	mean, variance := meanAndVariance(drawdowns)
+	if variance == 0 {
+		return 0
+	}
+	return (drawdown - mean) / math.Sqrt(variance)
+}
+
+func meanAndVariance(values []float64) (mean, variance float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+	if len(values) < 2 {
+		return mean, 0
+	}
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	return mean, variance / float64(len(values)-1)
+}
+
+func twoProportionZ(p1 float64, n1 int, p2 float64, n2 int) float64 {
+	pooled := (p1*float64(n1) + p2*float64(n2)) / float64(n1+n2)
+	se := math.Sqrt(pooled * (1 - pooled) * (1/float64(n1) + 1/float64(n2)))
+	if se == 0 {
+		return 0
+	}
+	return (p1 - p2) / se
+}
+
+func welchT(a, b []float64) float64 {
+	meanA, varA := meanAndVariance(a)
+	meanB, varB := meanAndVariance(b)
+	se := math.Sqrt(varA/float64(len(a)) + varB/float64(len(b)))
+	if se == 0 {
+		return 0
+	}
+	return (meanA - meanB) / se
+}
@@ -0,0 +1,334 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/cache"
+)
+
+const (
+	defaultNetworkWindowDays   = 30
+	maxNetworkWindowDays       = 180
+	defaultNetworkMinShared    = 3
+	defaultSimilarAuthorsLimit = 20
+	maxSimilarAuthorsLimit     = 100
+	minClusterSimilarity       = 0.2
+	maxLabelPropagationRounds  = 20
+	clusterTopTickers          = 5
+	authorNetworkCacheTTL      = 15 * time.Minute
+)
+
+// authorNode is an author of the network with its direction per ticker: 1 long, -1 short, 0 both
+type authorNode struct {
+	username   string
+	tier       string
+	directions map[string]int
+	signals    map[string]int
+}
+
+// authorGraph holds every edge with at least one shared ticker, minShared is applied when reading it
+type authorGraph struct {
+	nodes      map[string]*authorNode
+	edges      map[string][]model.SimilarAuthor
+	computedAt time.Time
+}
+
+type AuthorNetworkService struct {
+	repo  port.AuthorNetworkRepo
+	cache *cache.TTLCache[*authorGraph]
+}
+
+func NewAuthorNetworkService(repo port.AuthorNetworkRepo) *AuthorNetworkService {
+	return &AuthorNetworkService{
+		repo:  repo,
+		cache: cache.NewTTLCache[*authorGraph](authorNetworkCacheTTL),
+	}
+}
+
+// GetSimilarAuthors returns the authors whose signals overlap most with the given author and agree on direction,
+// most similar first. Authors that mostly disagree have a negative similarity and rank last.
+func (s *AuthorNetworkService) GetSimilarAuthors(ctx context.Context, username string, windowDays, minShared, limit int) (model.SimilarAuthorsResult, error) {
+	windowDays, minShared, err := normalizeNetworkParams(windowDays, minShared)
+	if err != nil {
+		return model.SimilarAuthorsResult{}, err
+	}
+	if limit <= 0 {
+		limit = defaultSimilarAuthorsLimit
+	}
+	if limit > maxSimilarAuthorsLimit {
+		limit = maxSimilarAuthorsLimit
+	}
+
+	graph, err := s.graph(ctx, windowDays)
+	if err != nil {
+		return model.SimilarAuthorsResult{}, err
+	}
+	node, ok := graph.nodes[username]
+	if !ok {
+		return model.SimilarAuthorsResult{}, model.ErrAuthorNotInNetwork
+	}
+
+	similar := []model.SimilarAuthor{}
+	for _, edge := range graph.edges[username] {
+		if edge.SharedTickers >= minShared {
+			similar = append(similar, edge)
+		}
+	}
+	sort.Slice(similar, func(i, j int) bool {
+		if similar[i].Similarity != similar[j].Similarity {
+			return similar[i].Similarity > similar[j].Similarity
+		}
+		if similar[i].SharedTickers != similar[j].SharedTickers {
+			return similar[i].SharedTickers > similar[j].SharedTickers
+		}
+		return similar[i].AuthorUsername < similar[j].AuthorUsername
+	})
+	if len(similar) > limit {
+		similar = similar[:limit]
+	}
+
+	return model.SimilarAuthorsResult{
+		AuthorUsername: username,
+		WindowDays:     windowDays,
+		Tickers:        len(node.directions),
+		Similar:        similar,
+	}, nil
+}
+
+// GetClusters groups the authors by label propagation over the edges with a similarity of at least
+// minClusterSimilarity. Authors without such an edge are left out, clusters are listed largest first.
+func (s *AuthorNetworkService) GetClusters(ctx context.Context, windowDays, minShared int) (model.AuthorClusterResult, error) {
+	windowDays, minShared, err := normalizeNetworkParams(windowDays, minShared)
+	if err != nil {
+		return model.AuthorClusterResult{}, err
+	}
+	graph, err := s.graph(ctx, windowDays)
+	if err != nil {
+		return model.AuthorClusterResult{}, err
+	}
+
+	linked := func(edge model.SimilarAuthor) bool {
+		return edge.SharedTickers >= minShared && edge.Similarity >= minClusterSimilarity
+	}
+
+	usernames := make([]string, 0, len(graph.nodes))
+	for username := range graph.nodes {
+		usernames = append(usernames, username)
+	}
+	sort.Strings(usernames)
+
+	labels := make(map[string]string, len(usernames))
+	for _, username := range usernames {
+		labels[username] = username
+	}
+	for round := 0; round < maxLabelPropagationRounds; round++ {
+		changed := false
+		for _, username := range usernames {
+			scores := make(map[string]float64)
+			for _, edge := range graph.edges[username] {
+				if linked(edge) {
+					scores[labels[edge.AuthorUsername]] += edge.Similarity
+				}
+			}
+			best, bestScore := "", 0.0
+			for label, score := range scores {
+				if score > bestScore || (score == bestScore && label < best) {
+					best, bestScore = label, score
+				}
+			}
+			if best != "" && best != labels[username] {
+				labels[username] = best
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+
+	members := make(map[string][]string)
+	for _, username := range usernames {
+		members[labels[username]] = append(members[labels[username]], username)
+	}
+
+	clusters := []model.AuthorCluster{}
+	for _, group := range members {
+		if len(group) < 2 {
+			continue
+		}
+		inCluster := make(map[string]bool, len(group))
+		for _, username := range group {
+			inCluster[username] = true
+		}
+
+		cluster := model.AuthorCluster{Size: len(group)}
+		tickerSignals := make(map[string]int)
+		var similaritySum float64
+		var links int
+		for _, username := range group {
+			node := graph.nodes[username]
+			cluster.Members = append(cluster.Members, model.AuthorClusterMember{AuthorUsername: username, AuthorTier: node.tier})
+			for ticker, count := range node.signals {
+				tickerSignals[ticker] += count
+			}
+			for _, edge := range graph.edges[username] {
+				// Each pair is seen from both ends, count it once
+				if inCluster[edge.AuthorUsername] && username < edge.AuthorUsername && edge.SharedTickers >= minShared {
+					similaritySum += edge.Similarity
+					links++
+				}
+			}
+		}
+		if links > 0 {
+			cluster.Cohesion = similaritySum / float64(links)
+		}
+		cluster.TopTickers = topTickers(tickerSignals, clusterTopTickers)
+		clusters = append(clusters, cluster)
+	}
+
+	sort.Slice(clusters, func(i, j int) bool {
+		if clusters[i].Size != clusters[j].Size {
+			return clusters[i].Size > clusters[j].Size
+		}
+		return clusters[i].Members[0].AuthorUsername < clusters[j].Members[0].AuthorUsername
+	})
+	for i := range clusters {
+		clusters[i].ID = i + 1
+	}
+
+	return model.AuthorClusterResult{
+		WindowDays: windowDays,
+		Clusters:   clusters,
+		ComputedAt: graph.computedAt,
+	}, nil
+}
+
+func normalizeNetworkParams(windowDays, minShared int) (int, int, error) {
+	if windowDays == 0 {
+		windowDays = defaultNetworkWindowDays
+	}
+	if windowDays < 1 || windowDays > maxNetworkWindowDays {
+		return 0, 0, model.ErrInvalidNetworkWindow
+	}
+	if minShared <= 0 {
+		minShared = defaultNetworkMinShared
+	}
+	return windowDays, minShared, nil
+}
+
+// graph returns the author network of the window, cached for authorNetworkCacheTTL
+func (s *AuthorNetworkService) graph(ctx context.Context, windowDays int) (*authorGraph, error) {
+	key := strconv.Itoa(windowDays)
+	if graph, ok := s.cache.Get(key); ok {
+		return graph, nil
+	}
+
+	now := time.Now().UTC()
+	signals, err := s.repo.GetAuthorTickerSignals(ctx, now.AddDate(0, 0, -windowDays))
+	if err != nil {
+		return nil, err
+	}
+
+	graph := &authorGraph{
+		nodes:      make(map[string]*authorNode),
+		edges:      make(map[string][]model.SimilarAuthor),
+		computedAt: now,
+	}
+	for _, signal := range signals {
+		node, ok := graph.nodes[signal.AuthorUsername]
+		if !ok {
+			node = &authorNode{
+				username:   signal.AuthorUsername,
+				tier:       signal.AuthorTier,
+				directions: make(map[string]int),
+				signals:    make(map[string]int),
+			}
+			graph.nodes[signal.AuthorUsername] = node
+		}
+		direction := 0
+		if signal.Longs > signal.Shorts {
+			direction = 1
+		} else if signal.Shorts > signal.Longs {
+			direction = -1
+		}
+		node.directions[signal.Ticker] = direction
+		node.signals[signal.Ticker] = signal.Longs + signal.Shorts
+	}
+
+	nodes := make([]*authorNode, 0, len(graph.nodes))
+	for _, node := range graph.nodes {
+		nodes = append(nodes, node)
+	}
+	for i := 0; i < len(nodes); i++ {
+		for j := i + 1; j < len(nodes); j++ {
+			a, b := nodes[i], nodes[j]
+			edge, ok := authorEdge(a, b)
+			if !ok {
+				continue
+			}
+			edge.AuthorUsername, edge.AuthorTier = b.username, b.tier
+			graph.edges[a.username] = append(graph.edges[a.username], edge)
+			edge.AuthorUsername, edge.AuthorTier = a.username, a.tier
+			graph.edges[b.username] = append(graph.edges[b.username], edge)
+		}
+	}
+
+	s.cache.Set(key, graph)
+	return graph, nil
+}
+
+// authorEdge measures the overlap of the ticker sets of two authors and how often they agree on direction. Tickers
+// one of them traded both ways count as shared but neither agree nor disagree.
+func authorEdge(a, b *authorNode) (model.SimilarAuthor, bool) {
+	small, large := a, b
+	if len(small.directions) > len(large.directions) {
+		small, large = large, small
+	}
+
+	var edge model.SimilarAuthor
+	for ticker, direction := range small.directions {
+		other, ok := large.directions[ticker]
+		if !ok {
+			continue
+		}
+		edge.SharedTickers++
+		switch {
+		case direction*other > 0:
+			edge.Agreements++
+		case direction*other < 0:
+			edge.Disagreements++
+		}
+	}
+	if edge.SharedTickers == 0 {
+		return edge, false
+	}
+
+	edge.Overlap = float64(edge.SharedTickers) / math.Sqrt(float64(len(a.directions)*len(b.directions)))
+	edge.Agreement = float64(edge.Agreements-edge.Disagreements) / float64(edge.SharedTickers)
+	edge.Similarity = edge.Overlap * edge.Agreement
+	return edge, true
+}
+
+// topTickers returns up to n tickers with the most signals, ties by ticker
+func topTickers(signals map[string]int, n int) []string {
+	tickers := make([]string, 0, len(signals))
+	for ticker := range signals {
+		tickers = append(tickers, ticker)
+	}
+	sort.Slice(tickers, func(i, j int) bool {
+		if signals[tickers[i]] != signals[tickers[j]] {
+			return signals[tickers[i]] > signals[tickers[j]]
+		}
+		return tickers[i] < tickers[j]
+	})
+	if len(tickers) > n {
+		tickers = tickers[:n]
+	}
+	return tickers
+}
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"github.com/quantsmithapp/datastation-backend/internal/core/port"
 )
 
@@ -19,3 +21,15 @@ func (s *AuthorTierService) GetAuthorsByTier(tier string) ([]string, error) {
 func (s *AuthorTierService) GetAllTiers() ([]string, error) {
 	return s.repo.GetAllTiers()
 }
+
+func (s *AuthorTierService) UpdateAuthorTier(authorUsername, tier string) error {
+	return s.repo.UpdateAuthorTier(authorUsername, tier)
+}
+
+func (s *AuthorTierService) ReplaceAuthorTier(ctx context.Context, authorUsername string, tier *string) (*string, error) {
+	return s.repo.ReplaceAuthorTier(ctx, authorUsername, tier)
+}
+
+func (s *AuthorTierService) RestoreAuthorTier(ctx context.Context, authorUsername string, replaced, previous *string) (bool, error) {
+	return s.repo.RestoreAuthorTier(ctx, authorUsername, replaced, previous)
+}
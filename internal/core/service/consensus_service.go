@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/quantsmithapp/datastation-backend/config"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
+)
+
+const (
+	defaultConsensusIntervalMinutes   = 60
+	defaultConsensusWindowHours       = 24
+	defaultConsensusHalfLifeHours     = 6
+	defaultConsensusConfidenceAuthors = 3
+	maxConsensusWindowHours           = 720
+	minConsensusWinrateWeight         = 0.1
+)
+
+type ConsensusService struct {
+	repo    port.ConsensusRepo
+	tickers port.TickerResolver
+	config  config.ConsensusConfig
+}
+
+func NewConsensusService(repo port.ConsensusRepo, tickers port.TickerResolver, cfg config.ConsensusConfig) *ConsensusService {
+	if cfg.IntervalMinutes <= 0 {
+		cfg.IntervalMinutes = defaultConsensusIntervalMinutes
+	}
+	if cfg.WindowHours <= 0 || cfg.WindowHours > maxConsensusWindowHours {
+		cfg.WindowHours = defaultConsensusWindowHours
+	}
+	if cfg.HalfLifeHours <= 0 {
+		cfg.HalfLifeHours = defaultConsensusHalfLifeHours
+	}
+	if cfg.ConfidenceAuthors <= 0 {
+		cfg.ConfidenceAuthors = defaultConsensusConfidenceAuthors
+	}
+	return &ConsensusService{
+		repo:    repo,
+		tickers: tickers,
+		config:  cfg,
+	}
+}
+
+// Start stores consensus snapshots on the configured interval until ctx is cancelled
+func (s *ConsensusService) Start(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(s.config.IntervalMinutes) * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Snapshot(ctx); err != nil {
+			logger.Errorf("consensus: snapshot failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// GetConsensus aggregates the latest LONG/SHORT signal of each tracked author per ticker in the window. Tickers are
+// ranked by the absolute score weighted by confidence, strongest consensus first.
+func (s *ConsensusService) GetConsensus(ctx context.Context, query model.ConsensusQuery) ([]model.TickerConsensus, error) {
+	if err := s.normalizeQuery(&query); err != nil {
+		return nil, err
+	}
+	return s.compute(ctx, query, time.Now().UTC())
+}
+
+// Snapshot stores the consensus of every ticker for all tracked authors and for each configured tier
+func (s *ConsensusService) Snapshot(ctx context.Context) error {
+	now := time.Now().UTC()
+	var snapshots []model.ConsensusSnapshot
+	for _, tier := range append([]string{""}, s.config.SnapshotTiers...) {
+		consensus, err := s.compute(ctx, model.ConsensusQuery{WindowHours: s.config.WindowHours, Tier: tier}, now)
+		if err != nil {
+			return err
+		}
+		for _, c := range consensus {
+			snapshots = append(snapshots, model.ConsensusSnapshot{
+				Ticker:       c.Ticker,
+				Tier:         c.Tier,
+				WindowHours:  c.WindowHours,
+				Score:        c.Score,
+				Confidence:   c.Confidence,
+				LongWeight:   c.LongWeight,
+				ShortWeight:  c.ShortWeight,
+				LongAuthors:  c.LongAuthors,
+				ShortAuthors: c.ShortAuthors,
+				CreatedAt:    now,
+			})
+		}
+	}
+	return s.repo.InsertConsensusSnapshots(ctx, snapshots)
+}
+
+// GetConsensusHistory returns the stored snapshots of a ticker, window and tier in [from, to)
+func (s *ConsensusService) GetConsensusHistory(ctx context.Context, query model.ConsensusQuery, from, to time.Time) ([]model.ConsensusSnapshot, error) {
+	if err := s.normalizeQuery(&query); err != nil {
+		return nil, err
+	}
+	if query.Ticker == "" {
+		return nil, model.ErrConsensusTickerRequired
+	}
+	return s.repo.GetConsensusSnapshots(ctx, query.Ticker, query.Tier, query.WindowHours, from, to)
+}
+
+func (s *ConsensusService) normalizeQuery(query *model.ConsensusQuery) error {
+	if query.WindowHours == 0 {
+		query.WindowHours = s.config.WindowHours
+	}
+	if query.WindowHours < 1 || query.WindowHours > maxConsensusWindowHours {
+		return model.ErrInvalidConsensusWindow
+	}
+	query.Ticker = s.tickers.Resolve(query.Ticker)
+	return nil
+}
+
+// compute weighs each signal by the winrate of its author relative to the average tracked author and by an
+// exponential decay on its age. The score is the net weight over the total weight, the confidence is the absolute
+// score scaled by n/(n+ConfidenceAuthors) with n the effective number of authors, so a lone caller never reaches
+// full confidence.
+func (s *ConsensusService) compute(ctx context.Context, query model.ConsensusQuery, now time.Time) ([]model.TickerConsensus, error) {
+	since := now.Add(-time.Duration(query.WindowHours) * time.Hour)
+	signals, err := s.repo.GetLatestAuthorSignals(ctx, query.Ticker, query.Tier, since)
+	if err != nil {
+		return nil, err
+	}
+
+	byTicker := make(map[string]*model.TickerConsensus)
+	sumSquares := make(map[string]float64)
+	for _, signal := range signals {
+		c, ok := byTicker[signal.Ticker]
+		if !ok {
+			c = &model.TickerConsensus{
+				Ticker:       signal.Ticker,
+				Tier:         query.Tier,
+				WindowHours:  query.WindowHours,
+				Contributors: []model.ConsensusContributor{},
+				ComputedAt:   now,
+			}
+			byTicker[signal.Ticker] = c
+		}
+
+		winrateWeight := 1.0
+		if signal.MeanWinrate > 0 {
+			winrateWeight = math.Max(signal.Winrate/signal.MeanWinrate, minConsensusWinrateWeight)
+		}
+		ageHours := math.Max(now.Sub(signal.SignalAt).Hours(), 0)
+		recencyWeight := math.Pow(0.5, ageHours/s.config.HalfLifeHours)
+		weight := winrateWeight * recencyWeight
+
+		switch signal.Action {
+		case model.SignalActionLong:
+			c.LongWeight += weight
+			c.LongAuthors++
+		case model.SignalActionShort:
+			c.ShortWeight += weight
+			c.ShortAuthors++
+		default:
+			continue
+		}
+		sumSquares[signal.Ticker] += weight * weight
+		c.Contributors = append(c.Contributors, model.ConsensusContributor{
+			AuthorUsername: signal.AuthorUsername,
+			AuthorTier:     signal.AuthorTier,
+			Action:         signal.Action,
+			SignalAt:       signal.SignalAt,
+			TweetID:        signal.TweetID,
+			Winrate:        signal.Winrate,
+			WinrateWeight:  winrateWeight,
+			RecencyWeight:  recencyWeight,
+			Weight:         weight,
+		})
+	}
+
+	consensus := make([]model.TickerConsensus, 0, len(byTicker))
+	for ticker, c := range byTicker {
+		total := c.LongWeight + c.ShortWeight
+		if total <= 0 {
+			continue
+		}
+		c.Score = (c.LongWeight - c.ShortWeight) / total
+		effectiveAuthors := total * total / sumSquares[ticker]
+		c.Confidence = math.Abs(c.Score) * effectiveAuthors / (effectiveAuthors + s.config.ConfidenceAuthors)
+
+		sort.Slice(c.Contributors, func(i, j int) bool {
+			return c.Contributors[i].Weight > c.Contributors[j].Weight
+		})
+		consensus = append(consensus, *c)
+	}
+
+	sort.Slice(consensus, func(i, j int) bool {
+		if consensus[i].Confidence != consensus[j].Confidence {
+			return consensus[i].Confidence > consensus[j].Confidence
+		}
+		return consensus[i].Ticker < consensus[j].Ticker
+	})
+	return consensus, nil
+}
@@ -0,0 +1,313 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/cache"
+)
+
+const (
+	correlationCacheTTL = 5 * time.Minute
+	// correlationConcurrency is how many pairs are loaded from Timescale at once per request
+	correlationConcurrency = 4
+	// correlationQuote is the quote of the pairs of the top assets by market cap
+	correlationQuote = "USDT"
+)
+
+type CorrelationService struct {
+	ohlcvRepo port.TimescaleRepo
+	marketCap port.MarketCapRepo
+	tickers   port.TickerResolver
+	cache     *cache.TTLCache[model.CorrelationResult]
+}
+
+// NewCorrelationService correlates the returns of crypto pairs. ohlcvRepo may be nil when Timescale is not available,
+// the service then answers model.ErrOHLCVUnavailable.
+func NewCorrelationService(ohlcvRepo port.TimescaleRepo, marketCap port.MarketCapRepo, tickers port.TickerResolver) *CorrelationService {
+	return &CorrelationService{
+		ohlcvRepo: ohlcvRepo,
+		marketCap: marketCap,
+		tickers:   tickers,
+		cache:     cache.NewTTLCache[model.CorrelationResult](correlationCacheTTL),
+	}
+}
+
+// GetCorrelation computes the correlation matrix of the log returns of the pairs over the latest Lookback candles,
+// with the beta and rolling correlation of each pair to BTCUSDT. Results are cached per request.
+func (s *CorrelationService) GetCorrelation(ctx context.Context, req model.CorrelationRequest) (model.CorrelationResult, error) {
+	if s.ohlcvRepo == nil {
+		return model.CorrelationResult{}, model.ErrOHLCVUnavailable
+	}
+	timeFrame, err := normalizeCorrelationRequest(&req, s.tickers)
+	if err != nil {
+		return model.CorrelationResult{}, err
+	}
+	cacheKey, err := json.Marshal(req)
+	if err != nil {
+		return model.CorrelationResult{}, err
+	}
+	if cached, ok := s.cache.Get(string(cacheKey)); ok {
+		return cached, nil
+	}
+
+	tickers := req.Tickers
+	if len(tickers) == 0 {
+		if tickers, err = s.topTickers(req.Top); err != nil {
+			return model.CorrelationResult{}, err
+		}
+	}
+
+	symbols := tickers
+	if !slices.Contains(tickers, model.CorrelationBenchmark) {
+		symbols = append(append([]string(nil), tickers...), model.CorrelationBenchmark)
+	}
+	// Two more candles so the first return of the lookback has its previous close
+	start := time.Now().UTC().Add(-time.Duration(req.Lookback+2) * timeFrame)
+	candles, err := s.loadCandles(ctx, symbols, req.TimeFrame, start)
+	if err != nil {
+		return model.CorrelationResult{}, err
+	}
+
+	returns := make(map[string]map[int64]float64, len(symbols))
+	timeSet := make(map[int64]bool)
+	for i, symbol := range symbols {
+		returns[symbol] = logReturns(candles[i], timeFrame)
+		for t := range returns[symbol] {
+			timeSet[t] = true
+		}
+	}
+	times := make([]int64, 0, len(timeSet))
+	for t := range timeSet {
+		times = append(times, t)
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+	if len(times) > req.Lookback {
+		times = times[len(times)-req.Lookback:]
+	}
+
+	// Every series is aligned with times, NaN where the pair has no return
+	series := make(map[string][]float64, len(symbols))
+	for _, symbol := range symbols {
+		values := make([]float64, len(times))
+		for i, t := range times {
+			if r, ok := returns[symbol][t]; ok {
+				values[i] = r
+			} else {
+				values[i] = math.NaN()
+			}
+		}
+		series[symbol] = values
+	}
+
+	result := model.CorrelationResult{
+		Benchmark: model.CorrelationBenchmark,
+		TimeFrame: req.TimeFrame,
+		Lookback:  req.Lookback,
+		Window:    req.Window,
+		Times:     make([]time.Time, len(times)),
+		Rolling:   make(map[string][]*float64),
+		Tickers:   make([]string, 0),
+		Missing:   make([]string, 0),
+	}
+	for i, t := range times {
+		result.Times[i] = time.Unix(t, 0).UTC()
+	}
+	if len(times) > 0 {
+		result.Start = result.Times[0]
+		result.End = result.Times[len(times)-1]
+	}
+	for _, ticker := range tickers {
+		if countObservations(series[ticker]) == 0 {
+			result.Missing = append(result.Missing, ticker)
+			continue
+		}
+		result.Tickers = append(result.Tickers, ticker)
+	}
+
+	benchmark := series[model.CorrelationBenchmark]
+	result.Matrix = make([][]*float64, len(result.Tickers))
+	result.Betas = make([]model.TickerBeta, len(result.Tickers))
+	for i, ticker := range result.Tickers {
+		result.Matrix[i] = make([]*float64, len(result.Tickers))
+		for j := 0; j <= i; j++ {
+			corr, _, _ := pearson(series[ticker], series[result.Tickers[j]], req.Window)
+			result.Matrix[i][j] = corr
+			result.Matrix[j][i] = corr
+		}
+
+		corr, beta, n := pearson(series[ticker], benchmark, req.Window)
+		result.Betas[i] = model.TickerBeta{Ticker: ticker, Beta: beta, Correlation: corr, Observations: n}
+
+		rolling := make([]*float64, len(times))
+		for end := req.Window; end <= len(times); end++ {
+			rolling[end-1], _, _ = pearson(series[ticker][end-req.Window:end], benchmark[end-req.Window:end], req.Window)
+		}
+		result.Rolling[ticker] = rolling
+	}
+
+	s.cache.Set(string(cacheKey), result)
+	return result, nil
+}
+
+// topTickers returns the USDT pairs of the top assets by market cap
+func (s *CorrelationService) topTickers(top int) ([]string, error) {
+	marketCap, err := s.marketCap.GetMarketCap()
+	if err != nil {
+		return nil, err
+	}
+	tickers := make([]string, 0, top)
+	for _, asset := range marketCap.AllMarketCap {
+		if len(tickers) == top {
+			break
+		}
+		base := s.tickers.Resolve(asset.BaseAsset)
+		if base == "" || base == correlationQuote || slices.Contains(tickers, base+correlationQuote) {
+			continue
+		}
+		tickers = append(tickers, base+correlationQuote)
+	}
+	return tickers, nil
+}
+
+// loadCandles loads the candles of each symbol from start, at most correlationConcurrency at a time
+func (s *CorrelationService) loadCandles(ctx context.Context, symbols []string, timeFrame string, start time.Time) ([][]model.OHLCVData, error) {
+	candles := make([][]model.OHLCVData, len(symbols))
+	errs := make([]error, len(symbols))
+	slots := make(chan struct{}, correlationConcurrency)
+	var wg sync.WaitGroup
+	for i, symbol := range symbols {
+		wg.Add(1)
+		go func(i int, symbol string) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			if err := ctx.Err(); err != nil {
+				errs[i] = err
+				return
+			}
+			candles[i], errs[i] = s.ohlcvRepo.GetCryptoOHLCV(model.OHLCVRequest{
+				Ticker:    symbol,
+				TimeFrame: timeFrame,
+				StartDate: start,
+			})
+		}(i, symbol)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return candles, nil
+}
+
+// normalizeCorrelationRequest fills in the defaults and resolves the tickers to the USDT pairs of their assets, so BTC,
+// $btc and BINANCE:BTCUSDT all read BTCUSDT
+func normalizeCorrelationRequest(req *model.CorrelationRequest, resolver port.TickerResolver) (time.Duration, error) {
+	var tickers []string
+	for _, asset := range resolveTickers(resolver, req.Tickers) {
+		if ticker := asset + correlationQuote; !slices.Contains(tickers, ticker) {
+			tickers = append(tickers, ticker)
+		}
+	}
+	req.Tickers = tickers
+	if len(req.Tickers) > 0 {
+		req.Top = 0
+	}
+	if len(req.Tickers) == 0 && req.Top <= 0 {
+		return 0, model.ErrCorrelationTickersRequired
+	}
+	if len(req.Tickers) > model.MaxCorrelationTickers || req.Top > model.MaxCorrelationTickers {
+		return 0, model.ErrTooManyCorrelationTickers
+	}
+
+	if req.TimeFrame == "" {
+		req.TimeFrame = model.DefaultCorrelationTimeFrame
+	}
+	timeFrame, ok := model.ParseTimeFrame(req.TimeFrame)
+	if !ok {
+		return 0, model.ErrInvalidCorrelationTimeFrame
+	}
+
+	if req.Lookback == 0 {
+		req.Lookback = model.DefaultCorrelationLookback
+	}
+	if req.Lookback < model.MinCorrelationLookback || req.Lookback > model.MaxCorrelationLookback {
+		return 0, model.ErrInvalidCorrelationLookback
+	}
+	if req.Window == 0 {
+		req.Window = min(model.DefaultCorrelationWindow, req.Lookback)
+	}
+	if req.Window < model.MinCorrelationWindow || req.Window > req.Lookback {
+		return 0, model.ErrInvalidCorrelationWindow
+	}
+	return timeFrame, nil
+}
+
+// logReturns maps the unix time of each candle that directly follows another to its log return
+func logReturns(candles []model.OHLCVData, timeFrame time.Duration) map[int64]float64 {
+	returns := make(map[int64]float64, len(candles))
+	for i := 1; i < len(candles); i++ {
+		prev, cur := candles[i-1], candles[i]
+		if cur.Time.Sub(prev.Time) != timeFrame || prev.Close <= 0 || cur.Close <= 0 {
+			continue
+		}
+		returns[cur.Time.Unix()] = math.Log(cur.Close / prev.Close)
+	}
+	return returns
+}
+
+// pearson returns the correlation of x and y and the beta of x to y over the indexes both have a value at, both nil
+// with fewer than minObservations of them or when either does not vary
+func pearson(x, y []float64, minObservations int) (*float64, *float64, int) {
+	var n int
+	var sumX, sumY float64
+	for i := range x {
+		if math.IsNaN(x[i]) || math.IsNaN(y[i]) {
+			continue
+		}
+		n++
+		sumX += x[i]
+		sumY += y[i]
+	}
+	if n < minObservations || n < 2 {
+		return nil, nil, n
+	}
+
+	meanX, meanY := sumX/float64(n), sumY/float64(n)
+	var cov, varX, varY float64
+	for i := range x {
+		if math.IsNaN(x[i]) || math.IsNaN(y[i]) {
+			continue
+		}
+		dx, dy := x[i]-meanX, y[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	if varX == 0 || varY == 0 {
+		return nil, nil, n
+	}
+	corr := cov / math.Sqrt(varX*varY)
+	beta := cov / varY
+	return &corr, &beta, n
+}
+
+func countObservations(values []float64) int {
+	n := 0
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,327 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
+)
+
+const (
+	defaultDivergenceLookback    = 12
+	defaultDivergenceForward     = 12
+	minDivergenceWindow          = 2
+	maxDivergenceWindow          = 200
+	defaultDivergenceScanTickers = 10
+	maxDivergenceScanTickers     = 20
+	minDivergenceMentions        = 5
+	minDivergenceSentimentShift  = 0.2
+	minDivergenceSentimentLevel  = 0.3
+	minDivergencePriceMovePct    = 2.0
+)
+
+type DivergenceService struct {
+	sentimentService port.TwitterCryptoService
+	ohlcvRepo        port.TimescaleRepo
+	tickers          port.TickerResolver
+}
+
+// NewDivergenceService aligns tweet sentiment with OHLCV. ohlcvRepo may be nil when Timescale is not available, the
+// service then answers model.ErrOHLCVUnavailable.
+func NewDivergenceService(sentimentService port.TwitterCryptoService, ohlcvRepo port.TimescaleRepo, tickers port.TickerResolver) *DivergenceService {
+	return &DivergenceService{
+		sentimentService: sentimentService,
+		ohlcvRepo:        ohlcvRepo,
+		tickers:          tickers,
+	}
+}
+
+// divergenceBucket is one aligned bucket of price and sentiment, close is NaN when the bucket has no candle
+type divergenceBucket struct {
+	time     time.Time
+	close    float64
+	net      int // positive - negative mentions
+	mentions int
+}
+
+// GetTickerDivergence detects divergences between the price and the tweet sentiment of one ticker in [From, To).
+//
+// Price and sentiment are compared over the last Lookback buckets, with sentiment as the mention weighted net
+// sentiment of the window. Consecutive buckets with the same divergence are merged into one event.
+func (s *DivergenceService) GetTickerDivergence(ctx context.Context, req model.DivergenceRequest) (model.TickerDivergence, error) {
+	if s.ohlcvRepo == nil {
+		return model.TickerDivergence{}, model.ErrOHLCVUnavailable
+	}
+	if err := s.normalizeRequest(&req); err != nil {
+		return model.TickerDivergence{}, err
+	}
+	bucket := sentimentBucketDurations[req.Bucket]
+
+	// Two lookbacks of warm-up so the first bucket in range can compare its window with the previous one
+	warmFrom := req.From.UTC().Truncate(bucket).Add(-2 * time.Duration(req.Lookback) * bucket)
+	series, err := s.sentimentService.GetSentimentTimeSeries(ctx, model.SentimentTimeSeriesQuery{
+		Tickers: []string{req.Ticker},
+		Bucket:  req.Bucket,
+		From:    warmFrom,
+		To:      req.To,
+		Authors: req.Authors,
+	})
+	if err != nil {
+		return model.TickerDivergence{}, err
+	}
+
+	priceTo := req.To.Add(time.Duration(req.Forward) * bucket)
+	if now := time.Now(); priceTo.After(now) {
+		priceTo = now
+	}
+	candles, err := s.ohlcvRepo.GetCryptoOHLCV(model.OHLCVRequest{
+		Ticker:    req.Ticker + "USDT",
+		TimeFrame: req.Bucket,
+		StartDate: warmFrom,
+		EndDate:   &priceTo,
+	})
+	if err != nil {
+		return model.TickerDivergence{}, err
+	}
+	closes := make(map[int64]float64, len(candles))
+	for _, candle := range candles {
+		closes[candle.Time.UTC().Truncate(bucket).Unix()] = candle.Close
+	}
+
+	var buckets []divergenceBucket
+	if len(series) > 0 {
+		for _, point := range series[0].Points {
+			close, ok := closes[point.Time.Unix()]
+			if !ok || close <= 0 {
+				close = math.NaN()
+			}
+			buckets = append(buckets, divergenceBucket{
+				time:     point.Time,
+				close:    close,
+				net:      point.Positive - point.Negative,
+				mentions: point.Mentions,
+			})
+		}
+	}
+
+	events := detectDivergences(req.Ticker, buckets, req.Lookback, req.From)
+	for i := range events {
+		end := events[i].EndTime
+		endClose, ok := closes[end.Unix()]
+		forwardClose, forwardOK := closes[end.Add(time.Duration(req.Forward)*bucket).Unix()]
+		if ok && forwardOK && endClose > 0 {
+			forwardReturn := (forwardClose/endClose - 1) * 100
+			events[i].ForwardReturnPct = &forwardReturn
+		}
+	}
+
+	return model.TickerDivergence{
+		Ticker:   req.Ticker,
+		Bucket:   req.Bucket,
+		Lookback: req.Lookback,
+		Forward:  req.Forward,
+		Events:   events,
+	}, nil
+}
+
+// Scan runs GetTickerDivergence over the most mentioned tickers of the range and returns their events, strongest
+// first. activeOnly keeps the events that ended within the last lookback of the range.
+func (s *DivergenceService) Scan(ctx context.Context, req model.DivergenceRequest, tickerLimit int, activeOnly bool) ([]model.DivergenceEvent, error) {
+	if s.ohlcvRepo == nil {
+		return nil, model.ErrOHLCVUnavailable
+	}
+	if tickerLimit <= 0 {
+		tickerLimit = defaultDivergenceScanTickers
+	}
+	if tickerLimit > maxDivergenceScanTickers {
+		tickerLimit = maxDivergenceScanTickers
+	}
+	// Validate once with a placeholder ticker so a bad request fails before any query
+	check := req
+	check.Ticker = "BTC"
+	if err := s.normalizeRequest(&check); err != nil {
+		return nil, err
+	}
+	bucket := sentimentBucketDurations[check.Bucket]
+
+	tickers, err := s.sentimentService.GetTopMentionedTickers(ctx, check.From, check.To, tickerLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	activeSince := check.To.Add(-time.Duration(check.Lookback) * bucket)
+	events := []model.DivergenceEvent{}
+	for _, ticker := range tickers {
+		tickerReq := check
+		tickerReq.Ticker = ticker
+		divergence, err := s.GetTickerDivergence(ctx, tickerReq)
+		if err != nil {
+			// Tickers without OHLCV are skipped, the scan covers whatever has both sources
+			logger.Warnf("divergence: skipping %s: %v", ticker, err)
+			continue
+		}
+		for _, event := range divergence.Events {
+			if activeOnly && event.EndTime.Before(activeSince) {
+				continue
+			}
+			events = append(events, event)
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].Strength != events[j].Strength {
+			return events[i].Strength > events[j].Strength
+		}
+		return events[i].EndTime.After(events[j].EndTime)
+	})
+	return events, nil
+}
+
+func (s *DivergenceService) normalizeRequest(req *model.DivergenceRequest) error {
+	req.Ticker = s.tickers.Resolve(req.Ticker)
+	if req.Ticker == "" {
+		return model.ErrDivergenceTickerRequired
+	}
+	if _, ok := sentimentBucketDurations[req.Bucket]; !ok {
+		return model.ErrInvalidSentimentBucket
+	}
+	if req.Lookback == 0 {
+		req.Lookback = defaultDivergenceLookback
+	}
+	if req.Forward == 0 {
+		req.Forward = defaultDivergenceForward
+	}
+	if req.Lookback < minDivergenceWindow || req.Lookback > maxDivergenceWindow ||
+		req.Forward < minDivergenceWindow || req.Forward > maxDivergenceWindow {
+		return model.ErrInvalidDivergenceWindow
+	}
+	if !req.From.Before(req.To) {
+		return model.ErrSentimentInvalidRange
+	}
+	return nil
+}
+
+// detectDivergences scans the aligned buckets and merges consecutive buckets of the same type into one event.
+// Only events ending at or after from are returned.
+//
+//   - price_high_sentiment_falling: close is the highest of the lookback while net sentiment fell (bearish)
+//   - price_low_sentiment_rising: close is the lowest of the lookback while net sentiment rose (bullish)
+//   - negative_sentiment_price_up: heavy negative sentiment while the price rose, the crowd is leaning against the
+//     move (bullish)
+//   - positive_sentiment_price_down: heavy positive sentiment while the price fell (bearish)
+func detectDivergences(ticker string, buckets []divergenceBucket, lookback int, from time.Time) []model.DivergenceEvent {
+	// rolling[i] is the net sentiment and mention count of the lookback ending at bucket i
+	rollingNet := make([]float64, len(buckets))
+	rollingMentions := make([]int, len(buckets))
+	var sumNet, sumMentions int
+	for i, b := range buckets {
+		sumNet += b.net
+		sumMentions += b.mentions
+		if i >= lookback {
+			sumNet -= buckets[i-lookback].net
+			sumMentions -= buckets[i-lookback].mentions
+		}
+		rollingMentions[i] = sumMentions
+		if sumMentions > 0 {
+			rollingNet[i] = float64(sumNet) / float64(sumMentions)
+		}
+	}
+
+	open := make(map[string]*model.DivergenceEvent)
+	lastSeen := make(map[string]int)
+	var events []model.DivergenceEvent
+	flush := func(divergenceType string) {
+		if event, ok := open[divergenceType]; ok {
+			if !event.EndTime.Before(from) {
+				events = append(events, *event)
+			}
+			delete(open, divergenceType)
+		}
+	}
+
+	for i := 2 * lookback; i < len(buckets); i++ {
+		b, prev := buckets[i], buckets[i-lookback]
+		if math.IsNaN(b.close) || math.IsNaN(prev.close) || rollingMentions[i] < minDivergenceMentions {
+			continue
+		}
+
+		newHigh, newLow := true, true
+		for j := i - lookback; j < i; j++ {
+			if math.IsNaN(buckets[j].close) {
+				continue
+			}
+			newHigh = newHigh && b.close >= buckets[j].close
+			newLow = newLow && b.close <= buckets[j].close
+		}
+		priceChange := (b.close/prev.close - 1) * 100
+		net := rollingNet[i]
+		sentimentChange := net - rollingNet[i-lookback]
+		comparable := rollingMentions[i-lookback] >= minDivergenceMentions
+
+		matches := map[string]float64{}
+		if comparable && newHigh && sentimentChange <= -minDivergenceSentimentShift {
+			matches[model.DivergencePriceHighSentimentFalling] = -sentimentChange / 2
+		}
+		if comparable && newLow && sentimentChange >= minDivergenceSentimentShift {
+			matches[model.DivergencePriceLowSentimentRising] = sentimentChange / 2
+		}
+		if net <= -minDivergenceSentimentLevel && priceChange >= minDivergencePriceMovePct {
+			matches[model.DivergenceNegativeSentimentPriceUp] = -net
+		}
+		if net >= minDivergenceSentimentLevel && priceChange <= -minDivergencePriceMovePct {
+			matches[model.DivergencePositiveSentimentPriceDown] = net
+		}
+
+		for divergenceType, strength := range matches {
+			event, ok := open[divergenceType]
+			if !ok || lastSeen[divergenceType] != i-1 {
+				flush(divergenceType)
+				event = &model.DivergenceEvent{
+					Ticker:            ticker,
+					Type:              divergenceType,
+					StartTime:         b.time,
+					ExpectedDirection: divergenceDirection(divergenceType),
+				}
+				open[divergenceType] = event
+			}
+			lastSeen[divergenceType] = i
+			event.EndTime = b.time
+			event.Strength = math.Max(event.Strength, math.Min(strength, 1))
+			event.PriceChangePct = priceChange
+			event.SentimentChange = sentimentChange
+			event.NetSentiment = net
+			event.Mentions = rollingMentions[i]
+		}
+	}
+	for _, divergenceType := range []string{
+		model.DivergencePriceHighSentimentFalling,
+		model.DivergencePriceLowSentimentRising,
+		model.DivergenceNegativeSentimentPriceUp,
+		model.DivergencePositiveSentimentPriceDown,
+	} {
+		flush(divergenceType)
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].StartTime.Equal(events[j].StartTime) {
+			return events[i].StartTime.Before(events[j].StartTime)
+		}
+		return events[i].Type < events[j].Type
+	})
+	if events == nil {
+		events = []model.DivergenceEvent{}
+	}
+	return events
+}
+
+func divergenceDirection(divergenceType string) int {
+	switch divergenceType {
+	case model.DivergencePriceLowSentimentRising, model.DivergenceNegativeSentimentPriceUp:
+		return 1
+	}
+	return -1
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+
+	"github.com/quantsmithapp/datastation-backend/config"
+)
+
+const defaultSMTPPort = 587
+
+type emailService struct {
+	addr string
+	auth smtp.Auth
+	from *mail.Address
+}
+
+// NewEmailService sends through the configured SMTP relay, it fails when no relay is configured
+func NewEmailService(cfg config.EmailConfig) (*emailService, error) {
+	if cfg.Host == "" {
+		return nil, errors.New("email host is not configured")
+	}
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid email sender: %w", err)
+	}
+	if cfg.Port == 0 {
+		cfg.Port = defaultSMTPPort
+	}
+
+	service := &emailService{
+		addr: cfg.Host + ":" + strconv.Itoa(cfg.Port),
+		from: from,
+	}
+	if cfg.Username != "" {
+		service.auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+	return service, nil
+}
+
+// SendEmail sends a plain text email. net/smtp takes no context, so ctx is only checked before sending.
+func (s *emailService) SendEmail(ctx context.Context, to, subject, body string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	recipient, err := mail.ParseAddress(to)
+	if err != nil {
+		return fmt.Errorf("invalid email recipient: %w", err)
+	}
+
+	var msg strings.Builder
+	msg.WriteString("From: " + s.from.String() + "\r\n")
+	msg.WriteString("To: " + recipient.String() + "\r\n")
+	msg.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", subject) + "\r\n")
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+
+	if err := smtp.SendMail(s.addr, s.auth, s.from.Address, []string{recipient.Address}, []byte(msg.String())); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/cache"
+)
+
+// indicatorCacheTTL is short since the last candle keeps changing until it closes
+const indicatorCacheTTL = time.Minute
+
+type IndicatorService struct {
+	ohlcvRepo port.TimescaleRepo
+	cache     *cache.TTLCache[model.IndicatorResult]
+}
+
+// NewIndicatorService computes indicators over OHLCV. ohlcvRepo may be nil when Timescale is not available, the
+// service then answers model.ErrOHLCVUnavailable.
+func NewIndicatorService(ohlcvRepo port.TimescaleRepo) *IndicatorService {
+	return &IndicatorService{
+		ohlcvRepo: ohlcvRepo,
+		cache:     cache.NewTTLCache[model.IndicatorResult](indicatorCacheTTL),
+	}
+}
+
+// GetIndicators computes the indicators of one ticker over the candles from StartDate to EndDate, or the latest
+// candle. Candles before StartDate are loaded first so every indicator is warmed up and its first value returned
+// matches a computation over the full history. Results are cached per ticker, timeframe, range and parameter set.
+func (s *IndicatorService) GetIndicators(ctx context.Context, req model.IndicatorRequest) (model.IndicatorResult, error) {
+	if s.ohlcvRepo == nil {
+		return model.IndicatorResult{}, model.ErrOHLCVUnavailable
+	}
+	timeFrame, err := normalizeIndicatorRequest(&req)
+	if err != nil {
+		return model.IndicatorResult{}, err
+	}
+	loc := time.UTC
+	if req.Timezone != "" {
+		if loc, err = time.LoadLocation(req.Timezone); err != nil {
+			return model.IndicatorResult{}, model.ErrInvalidOHLCVTimezone
+		}
+	}
+	cacheKey, err := json.Marshal(req)
+	if err != nil {
+		return model.IndicatorResult{}, err
+	}
+	if cached, ok := s.cache.Get(string(cacheKey)); ok {
+		return cached, nil
+	}
+
+	warmup := 0
+	for _, spec := range req.Indicators {
+		warmup = max(warmup, indicatorWarmup(spec, timeFrame))
+	}
+	// One more candle in case the first one loaded is partial. Forex has no candles on weekends, so reach back a
+	// week for every five days of candles and over one more weekend.
+	lookback := time.Duration(warmup+1) * timeFrame
+	if req.Market == model.IndicatorMarketForex {
+		lookback = lookback*7/5 + 72*time.Hour
+	}
+	ohlcvReq := req.OHLCVRequest
+	ohlcvReq.StartDate = req.StartDate.Add(-lookback)
+	ohlcvReq.AllPair = false
+
+	var candles []model.OHLCVData
+	if req.Market == model.IndicatorMarketForex {
+		candles, err = s.ohlcvRepo.GetForexOHLCV(ohlcvReq)
+	} else {
+		candles, err = s.ohlcvRepo.GetCryptoOHLCV(ohlcvReq)
+	}
+	if err != nil {
+		return model.IndicatorResult{}, err
+	}
+
+	first := sort.Search(len(candles), func(i int) bool { return !candles[i].Time.Before(req.StartDate) })
+	result := model.IndicatorResult{
+		Ticker:     req.Ticker,
+		TimeFrame:  req.TimeFrame,
+		Times:      make([]time.Time, 0, len(candles)-first),
+		Indicators: make([]model.IndicatorSeries, 0, len(req.Indicators)),
+	}
+	for _, c := range candles[first:] {
+		result.Times = append(result.Times, c.Time)
+	}
+	for _, spec := range req.Indicators {
+		series := model.IndicatorSeries{
+			Key:           spec.Key(),
+			IndicatorSpec: spec,
+			Lines:         make(map[string][]*float64),
+		}
+		for name, values := range computeIndicator(spec, candles, timeFrame, loc) {
+			line := make([]*float64, len(values)-first)
+			for i, v := range values[first:] {
+				if !math.IsNaN(v) && !math.IsInf(v, 0) {
+					line[i] = &v
+				}
+			}
+			series.Lines[name] = line
+		}
+		result.Indicators = append(result.Indicators, series)
+	}
+
+	s.cache.Set(string(cacheKey), result)
+	return result, nil
+}
+
+// normalizeIndicatorRequest validates the request, fills in default parameters and returns the timeframe duration
+func normalizeIndicatorRequest(req *model.IndicatorRequest) (time.Duration, error) {
+	if req.Market == "" {
+		req.Market = model.IndicatorMarketCrypto
+	}
+	req.Ticker = strings.ToUpper(strings.TrimSpace(req.Ticker))
+	if req.Ticker == "" {
+		return 0, model.ErrIndicatorTickerRequired
+	}
+	timeFrame, ok := model.ParseTimeFrame(req.TimeFrame)
+	if !ok {
+		return 0, model.ErrInvalidIndicatorTimeFrame
+	}
+
+	end := time.Now()
+	if req.EndDate != nil {
+		end = *req.EndDate
+	}
+	if !end.After(req.StartDate) {
+		return 0, model.ErrInvalidIndicatorRange
+	}
+	if end.Sub(req.StartDate)/timeFrame > model.MaxIndicatorCandles {
+		return 0, model.ErrIndicatorRangeTooLarge
+	}
+
+	if len(req.Indicators) == 0 {
+		return 0, model.ErrIndicatorsRequired
+	}
+	if len(req.Indicators) > model.MaxIndicatorsPerRequest {
+		return 0, model.ErrTooManyIndicators
+	}
+	for i := range req.Indicators {
+		spec := &req.Indicators[i]
+		spec.Name = strings.ToLower(strings.TrimSpace(spec.Name))
+		defaults, ok := indicatorDefaults[spec.Name]
+		if !ok {
+			return 0, model.ErrInvalidIndicator
+		}
+		if len(spec.Params) > len(defaults) {
+			return 0, model.ErrInvalidIndicatorParams
+		}
+		params := append([]float64{}, spec.Params...)
+		spec.Params = append(params, defaults[len(params):]...)
+		if err := validateIndicatorParams(*spec); err != nil {
+			return 0, err
+		}
+	}
+	return timeFrame, nil
+}
+
+func validateIndicatorParams(spec model.IndicatorSpec) error {
+	for i, p := range spec.Params {
+		if spec.Name == model.IndicatorBollinger && i == 1 {
+			if p <= 0 || p > 10 {
+				return model.ErrInvalidIndicatorParams
+			}
+			continue
+		}
+		// A zero VWAP period resets every day, volatility needs two returns for a deviation
+		minPeriod := 1.0
+		switch spec.Name {
+		case model.IndicatorVWAP:
+			minPeriod = 0
+		case model.IndicatorVolatility:
+			minPeriod = 2
+		}
+		if p != math.Trunc(p) || p < minPeriod || p > model.MaxIndicatorPeriod {
+			return model.ErrInvalidIndicatorParams
+		}
+	}
+	if spec.Name == model.IndicatorMACD && spec.Params[0] >= spec.Params[1] {
+		return model.ErrInvalidIndicatorParams
+	}
+	return nil
+}
@@ -0,0 +1,313 @@
+package service
+
+import (
+	"math"
+	"time"
+
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
+
+// Indicator math over candles sorted by time. Every function returns series as long as the candles with NaN where
+// the indicator is not defined yet.
+
+// smoothingConvergence is how many time constants exponential smoothing is warmed up for, the seed then weighs
+// less than e^-10 in the first value returned
+const smoothingConvergence = 10
+
+// indicatorDefaults are the parameters of each indicator when omitted
+var indicatorDefaults = map[string][]float64{
+	model.IndicatorSMA:        {20},
+	model.IndicatorEMA:        {20},
+	model.IndicatorRSI:        {14},
+	model.IndicatorMACD:       {12, 26, 9},
+	model.IndicatorBollinger:  {20, 2},
+	model.IndicatorATR:        {14},
+	model.IndicatorVWAP:       {0},
+	model.IndicatorStochastic: {14, 3, 3},
+	model.IndicatorVolatility: {20},
+}
+
+// emaWarmup is the number of candles an EMA of the period needs, its alpha is 2 / (period + 1)
+func emaWarmup(period int) int {
+	return period - 1 + smoothingConvergence*(period+1)/2
+}
+
+// wilderWarmup is the number of candles Wilder's smoothing of the period needs, its alpha is 1 / period
+func wilderWarmup(period int) int {
+	return period - 1 + smoothingConvergence*period
+}
+
+// indicatorWarmup returns how many candles before the first one returned the indicator needs to be exact
+func indicatorWarmup(spec model.IndicatorSpec, timeFrame time.Duration) int {
+	p := make([]int, len(spec.Params))
+	for i, param := range spec.Params {
+		p[i] = int(param)
+	}
+	switch spec.Name {
+	case model.IndicatorSMA, model.IndicatorBollinger:
+		return p[0] - 1
+	case model.IndicatorEMA:
+		return emaWarmup(p[0])
+	case model.IndicatorRSI, model.IndicatorATR:
+		// Changes and true ranges start at the second candle
+		return 1 + wilderWarmup(p[0])
+	case model.IndicatorMACD:
+		return emaWarmup(p[1]) + emaWarmup(p[2])
+	case model.IndicatorVWAP:
+		if p[0] == 0 {
+			// Enough to reach back to the start of the day
+			return int((24*time.Hour + timeFrame - 1) / timeFrame)
+		}
+		return p[0] - 1
+	case model.IndicatorStochastic:
+		return p[0] - 1 + p[1] - 1 + p[2] - 1
+	case model.IndicatorVolatility:
+		return p[0]
+	}
+	return 0
+}
+
+// computeIndicator returns the output lines of the indicator, its parameters must be validated
+func computeIndicator(spec model.IndicatorSpec, candles []model.OHLCVData, timeFrame time.Duration, loc *time.Location) map[string][]float64 {
+	p := spec.Params
+	closes := make([]float64, len(candles))
+	for i, c := range candles {
+		closes[i] = c.Close
+	}
+
+	switch spec.Name {
+	case model.IndicatorSMA:
+		return map[string][]float64{"value": sma(closes, int(p[0]))}
+	case model.IndicatorEMA:
+		return map[string][]float64{"value": ema(closes, int(p[0]))}
+	case model.IndicatorRSI:
+		return map[string][]float64{"value": rsi(closes, int(p[0]))}
+	case model.IndicatorMACD:
+		macd, signal, histogram := macd(closes, int(p[0]), int(p[1]), int(p[2]))
+		return map[string][]float64{"macd": macd, "signal": signal, "histogram": histogram}
+	case model.IndicatorBollinger:
+		middle, upper, lower := bollinger(closes, int(p[0]), p[1])
+		return map[string][]float64{"middle": middle, "upper": upper, "lower": lower}
+	case model.IndicatorATR:
+		return map[string][]float64{"value": atr(candles, int(p[0]))}
+	case model.IndicatorVWAP:
+		return map[string][]float64{"value": vwap(candles, int(p[0]), loc)}
+	case model.IndicatorStochastic:
+		k, d := stochastic(candles, int(p[0]), int(p[1]), int(p[2]))
+		return map[string][]float64{"k": k, "d": d}
+	case model.IndicatorVolatility:
+		return map[string][]float64{"value": volatility(closes, int(p[0]), timeFrame)}
+	}
+	return nil
+}
+
+func nanSeries(n int) []float64 {
+	out := make([]float64, n)
+	for i := range out {
+		out[i] = math.NaN()
+	}
+	return out
+}
+
+// firstValid returns the index of the first value that is not NaN, len(values) when there is none
+func firstValid(values []float64) int {
+	for i, v := range values {
+		if !math.IsNaN(v) {
+			return i
+		}
+	}
+	return len(values)
+}
+
+// sma is the simple moving average, leading NaN values are skipped
+func sma(values []float64, period int) []float64 {
+	out := nanSeries(len(values))
+	sum := 0.0
+	start := firstValid(values)
+	for i := start; i < len(values); i++ {
+		sum += values[i]
+		if i-start >= period {
+			sum -= values[i-period]
+		}
+		if i-start >= period-1 {
+			out[i] = sum / float64(period)
+		}
+	}
+	return out
+}
+
+// smooth applies exponential smoothing seeded with the simple average of the first period values, leading NaN
+// values are skipped
+func smooth(values []float64, period int, alpha float64) []float64 {
+	out := nanSeries(len(values))
+	start := firstValid(values)
+	if len(values)-start < period {
+		return out
+	}
+	avg := 0.0
+	for i := start; i < start+period; i++ {
+		avg += values[i]
+	}
+	avg /= float64(period)
+	out[start+period-1] = avg
+	for i := start + period; i < len(values); i++ {
+		avg += alpha * (values[i] - avg)
+		out[i] = avg
+	}
+	return out
+}
+
+func ema(values []float64, period int) []float64 {
+	return smooth(values, period, 2/float64(period+1))
+}
+
+func wilder(values []float64, period int) []float64 {
+	return smooth(values, period, 1/float64(period))
+}
+
+// rsi is Wilder's relative strength index
+func rsi(closes []float64, period int) []float64 {
+	gains := nanSeries(len(closes))
+	losses := nanSeries(len(closes))
+	for i := 1; i < len(closes); i++ {
+		change := closes[i] - closes[i-1]
+		gains[i] = math.Max(change, 0)
+		losses[i] = math.Max(-change, 0)
+	}
+	avgGains := wilder(gains, period)
+	avgLosses := wilder(losses, period)
+
+	out := nanSeries(len(closes))
+	for i := range closes {
+		gain, loss := avgGains[i], avgLosses[i]
+		switch {
+		case math.IsNaN(gain):
+		case loss == 0 && gain == 0:
+			out[i] = 50
+		case loss == 0:
+			out[i] = 100
+		default:
+			out[i] = 100 - 100/(1+gain/loss)
+		}
+	}
+	return out
+}
+
+func macd(closes []float64, fast, slow, signalPeriod int) (line, signal, histogram []float64) {
+	fastEMA := ema(closes, fast)
+	slowEMA := ema(closes, slow)
+	line = make([]float64, len(closes))
+	for i := range closes {
+		line[i] = fastEMA[i] - slowEMA[i]
+	}
+	signal = ema(line, signalPeriod)
+	histogram = make([]float64, len(closes))
+	for i := range closes {
+		histogram[i] = line[i] - signal[i]
+	}
+	return line, signal, histogram
+}
+
+// bollinger returns the simple moving average and the bands the population standard deviation times deviations away
+func bollinger(closes []float64, period int, deviations float64) (middle, upper, lower []float64) {
+	middle = sma(closes, period)
+	upper = nanSeries(len(closes))
+	lower = nanSeries(len(closes))
+	for i := period - 1; i < len(closes); i++ {
+		variance := 0.0
+		for _, c := range closes[i-period+1 : i+1] {
+			variance += (c - middle[i]) * (c - middle[i])
+		}
+		std := math.Sqrt(variance / float64(period))
+		upper[i] = middle[i] + deviations*std
+		lower[i] = middle[i] - deviations*std
+	}
+	return middle, upper, lower
+}
+
+// atr is Wilder's average true range, the first candle has no previous close and no true range
+func atr(candles []model.OHLCVData, period int) []float64 {
+	trueRanges := nanSeries(len(candles))
+	for i := 1; i < len(candles); i++ {
+		prevClose := candles[i-1].Close
+		c := candles[i]
+		trueRanges[i] = math.Max(c.High-c.Low, math.Max(math.Abs(c.High-prevClose), math.Abs(c.Low-prevClose)))
+	}
+	return wilder(trueRanges, period)
+}
+
+// vwap is the volume weighted typical price, (high + low + close) / 3, over the last period candles or, with a zero
+// period, since the start of the candle's day in loc
+func vwap(candles []model.OHLCVData, period int, loc *time.Location) []float64 {
+	out := nanSeries(len(candles))
+	var priceVolume, volume float64
+	var day time.Time
+	for i, c := range candles {
+		if period == 0 {
+			year, month, d := c.Time.In(loc).Date()
+			if candleDay := time.Date(year, month, d, 0, 0, 0, 0, loc); !candleDay.Equal(day) {
+				day, priceVolume, volume = candleDay, 0, 0
+			}
+		} else if i >= period {
+			old := candles[i-period]
+			priceVolume -= (old.High + old.Low + old.Close) / 3 * old.Volume
+			volume -= old.Volume
+		}
+		priceVolume += (c.High + c.Low + c.Close) / 3 * c.Volume
+		volume += c.Volume
+
+		// The first day is partial unless the candles start at midnight, the warm-up covers it
+		if (period == 0 || i >= period-1) && volume > 0 {
+			out[i] = priceVolume / volume
+		}
+	}
+	return out
+}
+
+// stochastic returns %K, the close within the high-low range of kPeriod candles smoothed over smoothK, and %D, the
+// moving average of %K over dPeriod
+func stochastic(candles []model.OHLCVData, kPeriod, smoothK, dPeriod int) (k, d []float64) {
+	raw := nanSeries(len(candles))
+	for i := kPeriod - 1; i < len(candles); i++ {
+		high, low := math.Inf(-1), math.Inf(1)
+		for _, c := range candles[i-kPeriod+1 : i+1] {
+			high = math.Max(high, c.High)
+			low = math.Min(low, c.Low)
+		}
+		if high == low {
+			raw[i] = 50
+			continue
+		}
+		raw[i] = 100 * (candles[i].Close - low) / (high - low)
+	}
+	k = sma(raw, smoothK)
+	return k, sma(k, dPeriod)
+}
+
+// volatility is the sample standard deviation of the log returns of period candles, annualized over a calendar year
+// since crypto trades every day
+func volatility(closes []float64, period int, timeFrame time.Duration) []float64 {
+	out := nanSeries(len(closes))
+	if period < 2 {
+		return out
+	}
+	returns := make([]float64, len(closes))
+	for i := 1; i < len(closes); i++ {
+		returns[i] = math.Log(closes[i] / closes[i-1])
+	}
+	annualize := math.Sqrt(float64(365*24*time.Hour) / float64(timeFrame))
+	for i := period; i < len(closes); i++ {
+		window := returns[i-period+1 : i+1]
+		mean := 0.0
+		for _, r := range window {
+			mean += r
+		}
+		mean /= float64(period)
+		variance := 0.0
+		for _, r := range window {
+			variance += (r - mean) * (r - mean)
+		}
+		out[i] = math.Sqrt(variance/float64(period-1)) * annualize
+	}
+	return out
+}
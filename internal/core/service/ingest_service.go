@@ -0,0 +1,370 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
+
+const (
+	ingestAPIKeyPrefix    = "dsk_"
+	ingestAPIKeyBytes     = 32
+	ingestAPIKeyShownSize = 12
+	// signalTickerQuote is the quote of the pairs signal tickers are stored as, e.g. BTCUSDT
+	signalTickerQuote = "USDT"
+)
+
+var (
+	tweetSentiments  = map[string]bool{"Positive": true, "Neutral": true, "Negative": true}
+	signalSentiments = map[string]bool{"Bullish": true, "Bearish": true, "Neutral": true}
+)
+
+type IngestService struct {
+	repo    port.IngestRepo
+	tickers port.TickerResolver
+	events  port.SignalEventBus
+}
+
+func NewIngestService(repo port.IngestRepo, tickers port.TickerResolver, events port.SignalEventBus) *IngestService {
+	return &IngestService{
+		repo:    repo,
+		tickers: tickers,
+		events:  events,
+	}
+}
+
+// CreateAPIKey generates a random key for the given scopes. The plain key is only part of this response.
+func (s *IngestService) CreateAPIKey(ctx context.Context, req model.IngestAPIKeyRequest, createdBy string) (model.CreatedIngestAPIKey, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return model.CreatedIngestAPIKey{}, model.ErrIngestAPIKeyNameRequired
+	}
+	if len(req.Scopes) == 0 {
+		return model.CreatedIngestAPIKey{}, model.ErrInvalidIngestScope
+	}
+	for _, scope := range req.Scopes {
+		if !isIngestScope(scope) {
+			return model.CreatedIngestAPIKey{}, model.ErrInvalidIngestScope
+		}
+	}
+
+	secret := make([]byte, ingestAPIKeyBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return model.CreatedIngestAPIKey{}, fmt.Errorf("failed to generate API key: %w", err)
+	}
+	key := ingestAPIKeyPrefix + hex.EncodeToString(secret)
+
+	created, err := s.repo.CreateAPIKey(ctx, model.IngestAPIKey{
+		Name:      req.Name,
+		KeyPrefix: key[:ingestAPIKeyShownSize],
+		Scopes:    req.Scopes,
+		CreatedBy: createdBy,
+	}, hashIngestAPIKey(key))
+	if err != nil {
+		return model.CreatedIngestAPIKey{}, err
+	}
+	return model.CreatedIngestAPIKey{IngestAPIKey: created, Key: key}, nil
+}
+
+func (s *IngestService) GetAPIKeys(ctx context.Context) ([]model.IngestAPIKey, error) {
+	return s.repo.GetAPIKeys(ctx)
+}
+
+func (s *IngestService) RevokeAPIKey(ctx context.Context, id string) error {
+	return s.repo.RevokeAPIKey(ctx, id)
+}
+
+func (s *IngestService) AuthenticateAPIKey(ctx context.Context, key string) (model.IngestAPIKey, error) {
+	if !strings.HasPrefix(key, ingestAPIKeyPrefix) {
+		return model.IngestAPIKey{}, model.ErrInvalidIngestAPIKey
+	}
+	return s.repo.UseAPIKey(ctx, hashIngestAPIKey(key))
+}
+
+// IngestTweets upserts the valid tweets by ID and rejects the others with the reason
+func (s *IngestService) IngestTweets(ctx context.Context, tweets []model.TwitterCryptoTweet) (model.IngestResult, error) {
+	if len(tweets) == 0 || len(tweets) > model.MaxIngestBatchSize {
+		return model.IngestResult{}, model.ErrInvalidIngestBatch
+	}
+
+	result := model.IngestResult{Rejected: []model.IngestRejection{}}
+	seen := make(map[string]bool, len(tweets))
+	var accepted []model.TwitterCryptoTweet
+	for i, tweet := range tweets {
+		tweet.ID = strings.TrimSpace(tweet.ID)
+		tweet.AuthorUsername = strings.TrimSpace(tweet.AuthorUsername)
+		if reason := validateTweet(tweet, seen); reason != "" {
+			result.Rejected = append(result.Rejected, model.IngestRejection{Index: i, ID: tweet.ID, Error: reason})
+			continue
+		}
+		seen[tweet.ID] = true
+		if tweet.TweetCreatedDate.IsZero() {
+			tweet.TweetCreatedDate = tweet.TweetCreatedAt.UTC().Truncate(24 * time.Hour)
+		}
+		if math.IsNaN(float64(tweet.ViewCount)) {
+			tweet.ViewCount = 0
+		}
+		accepted = append(accepted, tweet)
+	}
+	if len(accepted) == 0 {
+		return result, nil
+	}
+
+	created, err := s.repo.UpsertTweets(ctx, accepted)
+	if err != nil {
+		return model.IngestResult{}, err
+	}
+	now := time.Now().UTC()
+	for i, tweet := range accepted {
+		countIngested(&result, created[i])
+		s.events.Publish(model.SignalEvent{
+			Kind:           model.SignalEventTweet,
+			TweetID:        tweet.ID,
+			AuthorUsername: tweet.AuthorUsername,
+			Tickers:        s.splitTickers(tweet.TickersRuleBased),
+			Created:        created[i],
+			At:             now,
+		})
+	}
+	return result, nil
+}
+
+// IngestSentiments upserts the valid tweet sentiments by tweet ID and rejects the others with the reason
+func (s *IngestService) IngestSentiments(ctx context.Context, sentiments []model.TwitterCryptoSentiment) (model.IngestResult, error) {
+	if len(sentiments) == 0 || len(sentiments) > model.MaxIngestBatchSize {
+		return model.IngestResult{}, model.ErrInvalidIngestBatch
+	}
+
+	result := model.IngestResult{Rejected: []model.IngestRejection{}}
+	seen := make(map[string]bool, len(sentiments))
+	var accepted []model.TwitterCryptoSentiment
+	for i, sentiment := range sentiments {
+		sentiment.ID = strings.TrimSpace(sentiment.ID)
+		sentiment.AuthorUsername = strings.TrimSpace(sentiment.AuthorUsername)
+		if reason := validateSentiment(sentiment, seen); reason != "" {
+			result.Rejected = append(result.Rejected, model.IngestRejection{Index: i, ID: sentiment.ID, Error: reason})
+			continue
+		}
+		seen[sentiment.ID] = true
+		accepted = append(accepted, sentiment)
+	}
+	if len(accepted) == 0 {
+		return result, nil
+	}
+
+	created, err := s.repo.UpsertSentiments(ctx, accepted)
+	if err != nil {
+		return model.IngestResult{}, err
+	}
+	now := time.Now().UTC()
+	for i, sentiment := range accepted {
+		countIngested(&result, created[i])
+		event := model.SignalEvent{
+			Kind:           model.SignalEventSentiment,
+			TweetID:        sentiment.ID,
+			AuthorUsername: sentiment.AuthorUsername,
+			Tickers:        s.splitTickers(sentiment.ResponseTickers),
+			Sentiment:      sentiment.ResponseSentiment,
+			Created:        created[i],
+			At:             now,
+		}
+		if sentiment.ResponseScore != nil {
+			score := float64(*sentiment.ResponseScore)
+			event.Score = &score
+		}
+		s.events.Publish(event)
+	}
+	return result, nil
+}
+
+// IngestSignals replaces the signals of each valid tweet and prompt version with the given set, normalizing tickers to the
+// USDT pair of their canonical asset like the stored signals. Signals can only be attached to tweets that were ingested
+// before.
+func (s *IngestService) IngestSignals(ctx context.Context, tweets []model.IngestTweetSignals) (model.IngestResult, error) {
+	if len(tweets) == 0 || len(tweets) > model.MaxIngestBatchSize {
+		return model.IngestResult{}, model.ErrInvalidIngestBatch
+	}
+
+	tweetIDs := make([]string, len(tweets))
+	for i := range tweets {
+		tweets[i].TweetID = strings.TrimSpace(tweets[i].TweetID)
+		tweets[i].PromptVersion = strings.TrimSpace(tweets[i].PromptVersion)
+		tweetIDs[i] = tweets[i].TweetID
+	}
+	authors, err := s.repo.GetTweetAuthors(ctx, tweetIDs)
+	if err != nil {
+		return model.IngestResult{}, err
+	}
+
+	result := model.IngestResult{Rejected: []model.IngestRejection{}}
+	seen := make(map[string]bool, len(tweets))
+	var accepted []model.IngestTweetSignals
+	for i, tweet := range tweets {
+		reason := validateTweetSignals(tweet, seen)
+		if _, ok := authors[tweet.TweetID]; reason == "" && !ok {
+			reason = "tweet not found, ingest the tweet first"
+		}
+		if reason != "" {
+			result.Rejected = append(result.Rejected, model.IngestRejection{Index: i, ID: tweet.TweetID, Error: reason})
+			continue
+		}
+		seen[tweet.TweetID+"|"+tweet.PromptVersion] = true
+
+		signals := make([]model.TwitterCryptoSignal, len(tweet.Signals))
+		for j, signal := range tweet.Signals {
+			signal.SignalAction = model.SignalActionType(strings.ToUpper(string(signal.SignalAction)))
+			if signal.SignalTicker != nil {
+				ticker := signalPairTicker(s.tickers.Resolve(*signal.SignalTicker))
+				signal.SignalTicker = &ticker
+			}
+			signals[j] = signal
+		}
+		tweet.Signals = signals
+		accepted = append(accepted, tweet)
+	}
+	if len(accepted) == 0 {
+		return result, nil
+	}
+
+	created, err := s.repo.ReplaceSignals(ctx, accepted)
+	if err != nil {
+		return model.IngestResult{}, err
+	}
+	now := time.Now().UTC()
+	for i, tweet := range accepted {
+		countIngested(&result, created[i])
+		for _, signal := range tweet.Signals {
+			event := model.SignalEvent{
+				Kind:           model.SignalEventSignal,
+				TweetID:        tweet.TweetID,
+				AuthorUsername: authors[tweet.TweetID],
+				Tickers:        []string{},
+				Action:         signal.SignalAction,
+				Score:          signal.SignalScore,
+				Created:        created[i],
+				At:             now,
+			}
+			if signal.SignalTicker != nil && *signal.SignalTicker != "" && *signal.SignalTicker != "NONE" {
+				event.Tickers = []string{s.tickers.Resolve(*signal.SignalTicker)}
+			}
+			if signal.SignalSentiment != nil {
+				event.Sentiment = *signal.SignalSentiment
+			}
+			s.events.Publish(event)
+		}
+	}
+	return result, nil
+}
+
+func countIngested(result *model.IngestResult, created bool) {
+	result.Accepted++
+	if created {
+		result.Created++
+	} else {
+		result.Updated++
+	}
+}
+
+func validateTweet(tweet model.TwitterCryptoTweet, seen map[string]bool) string {
+	switch {
+	case tweet.ID == "":
+		return "id is required"
+	case seen[tweet.ID]:
+		return "duplicate id in batch"
+	case tweet.AuthorUsername == "":
+		return "author_username is required"
+	case tweet.Text == "" && tweet.MediaURL == "":
+		return "text or media_url is required"
+	case tweet.TweetCreatedAt.IsZero():
+		return "tweet_created_at is required"
+	case tweet.RetweetCount < 0 || tweet.ReplyCount < 0 || tweet.LikeCount < 0 || tweet.QuoteCount < 0 || tweet.BookmarkCount < 0 || tweet.ViewCount < 0:
+		return "counts must not be negative"
+	}
+	return ""
+}
+
+func validateSentiment(sentiment model.TwitterCryptoSentiment, seen map[string]bool) string {
+	switch {
+	case sentiment.ID == "":
+		return "id is required"
+	case seen[sentiment.ID]:
+		return "duplicate id in batch"
+	case sentiment.AuthorUsername == "":
+		return "author_username is required"
+	case !tweetSentiments[sentiment.ResponseSentiment]:
+		return "response_sentiment must be Positive, Neutral or Negative"
+	}
+	return ""
+}
+
+func validateTweetSignals(tweet model.IngestTweetSignals, seen map[string]bool) string {
+	switch {
+	case tweet.TweetID == "":
+		return "tweet_id is required"
+	case seen[tweet.TweetID+"|"+tweet.PromptVersion]:
+		return "duplicate tweet_id and prompt_version in batch"
+	}
+	for i, signal := range tweet.Signals {
+		action := model.SignalActionType(strings.ToUpper(string(signal.SignalAction)))
+		switch action {
+		case model.SignalActionLong, model.SignalActionShort:
+			if signal.SignalTicker == nil || strings.TrimSpace(*signal.SignalTicker) == "" {
+				return fmt.Sprintf("signals[%d]: signal_ticker is required for %s", i, action)
+			}
+		case model.SignalActionNatural:
+		default:
+			return fmt.Sprintf("signals[%d]: signal_action must be LONG, SHORT or NONE", i)
+		}
+		if signal.SignalSentiment != nil && !signalSentiments[*signal.SignalSentiment] {
+			return fmt.Sprintf("signals[%d]: signal_sentiment must be Bullish, Bearish or Neutral", i)
+		}
+		if signal.SignalScore != nil && *signal.SignalScore != math.Trunc(*signal.SignalScore) {
+			return fmt.Sprintf("signals[%d]: signal_score must be a whole number", i)
+		}
+	}
+	return ""
+}
+
+// signalPairTicker returns the USDT pair of a canonical asset, the form twitter_crypto_signal stores tickers in
+func signalPairTicker(asset string) string {
+	if asset == "" || asset == "NONE" || asset == signalTickerQuote {
+		return asset
+	}
+	return asset + signalTickerQuote
+}
+
+// splitTickers resolves a raw ticker list such as "BTC, $ETH" or ["BTC","ETH"] to canonical tickers
+func (s *IngestService) splitTickers(raw string) []string {
+	fields := strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ',' || r == ';' || r == ' '
+	})
+	tickers := []string{}
+	for _, ticker := range resolveTickers(s.tickers, fields) {
+		if ticker != "NONE" {
+			tickers = append(tickers, ticker)
+		}
+	}
+	return tickers
+}
+
+func isIngestScope(scope string) bool {
+	for _, s := range model.IngestScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func hashIngestAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
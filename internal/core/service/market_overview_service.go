@@ -1,20 +1,21 @@
 package service
 
 import (
-	"strings"
-	"sync"
-
 	"github.com/quantsmithapp/datastation-backend/internal/core/port"
 	"github.com/quantsmithapp/datastation-backend/internal/model"
 )
 
 type MarketOverviewService struct {
 	marketOverviewRepo port.MarketOverviewRepo
+	tickers            port.TickerResolver
+	prices             port.PriceCache
 }
 
-func NewMarketOverviewService(marketOverviewRepo port.MarketOverviewRepo) *MarketOverviewService {
+func NewMarketOverviewService(marketOverviewRepo port.MarketOverviewRepo, tickers port.TickerResolver, prices port.PriceCache) *MarketOverviewService {
 	return &MarketOverviewService{
 		marketOverviewRepo: marketOverviewRepo,
+		tickers:            tickers,
+		prices:             prices,
 	}
 }
 
@@ -65,80 +66,12 @@ func (s *MarketOverviewService) GetMarketOverviewTable(tier string, timeframe st
 	return marketOverviewTableForAWithAlphaSentiment, nil
 }
 
-func (s *MarketOverviewService) GetEnrichedMarketOverviewTable(tier string, timeframe string, scannerResult map[string]interface{}) ([]model.EnrichedMarketOverview, error) {
+func (s *MarketOverviewService) GetEnrichedMarketOverviewTable(tier string, timeframe string) ([]model.EnrichedMarketOverview, error) {
 	marketOverviewTable, err := s.GetMarketOverviewTable(tier, timeframe)
 	if err != nil {
 		return nil, err
 	}
 
-	// Create channels for processing
-	enrichedDataChan := make(chan model.EnrichedMarketOverview, len(marketOverviewTable))
-	var wg sync.WaitGroup
-
-	// Process each market overview entry
-	for _, entry := range marketOverviewTable {
-		wg.Add(1)
-		go func(entry model.MarketOverviewTableResponse) {
-			defer wg.Done()
-
-			enriched := model.EnrichedMarketOverview{
-				Ticker:          strings.TrimSuffix(entry.Ticker, "USDT"),
-				LongCount:       entry.LongCount,
-				ShortCount:      entry.ShortCount,
-				TotalSignals:    entry.TotalSignals,
-				LongShortRatio:  entry.LongShortRatio,
-				LongShortString: entry.LongShortString,
-				XSentiment:      entry.Sentiment,
-				AlphaSentiment:  entry.AlphaSentiment,
-				PriceClose:      nil,
-				PriceChange24h:  nil,
-				MarketCap:       nil,
-				Volume24h:       nil,
-			}
-
-			// Find matching scanner data
-			if scannerData, ok := scannerResult["data"].([]interface{}); ok {
-				for _, item := range scannerData {
-					if data, ok := item.(map[string]interface{}); ok {
-						if d, ok := data["d"].([]interface{}); ok && len(d) >= 7 {
-							symbol, ok := d[0].(string)
-							if !ok {
-								continue
-							}
-
-							if strings.EqualFold(symbol, enriched.Ticker) {
-								if priceClose, ok := d[3].(float64); ok {
-									enriched.PriceClose = &priceClose
-								}
-
-								if priceChange24h, ok := d[4].(float64); ok {
-									enriched.PriceChange24h = &priceChange24h
-								}
-
-								if marketCap, ok := d[5].(float64); ok {
-									enriched.MarketCap = &marketCap
-								}
-
-								if volume24h, ok := d[6].(float64); ok {
-									enriched.Volume24h = &volume24h
-								}
-								break
-							}
-						}
-					}
-				}
-			}
-
-			enrichedDataChan <- enriched
-		}(entry)
-	}
-
-	// Close channel when all goroutines are done
-	go func() {
-		wg.Wait()
-		close(enrichedDataChan)
-	}()
-
 	// Get news overview
 	newsOverview, err := s.marketOverviewRepo.GetNewsOverview(timeframe)
 	if err != nil {
@@ -151,9 +84,26 @@ func (s *MarketOverviewService) GetEnrichedMarketOverviewTable(tier string, time
 		newsMap[news.Coin] = news
 	}
 
-	// Collect results and combine with news data
+	// Prices come from the price cache, tickers without one are left without price columns
 	enrichedData := make([]model.EnrichedMarketOverview, 0, len(marketOverviewTable))
-	for data := range enrichedDataChan {
+	for _, entry := range marketOverviewTable {
+		data := model.EnrichedMarketOverview{
+			Ticker:          s.tickers.Resolve(entry.Ticker),
+			LongCount:       entry.LongCount,
+			ShortCount:      entry.ShortCount,
+			TotalSignals:    entry.TotalSignals,
+			LongShortRatio:  entry.LongShortRatio,
+			LongShortString: entry.LongShortString,
+			XSentiment:      entry.Sentiment,
+			AlphaSentiment:  entry.AlphaSentiment,
+		}
+		if price, ok := s.prices.GetPrice(data.Ticker); ok {
+			data.PriceClose = price.Price
+			data.PriceChange24h = price.Change24h
+			data.MarketCap = price.MarketCap
+			data.Volume24h = price.Volume24h
+		}
+
 		// Add news overview if available
 		if news, exists := newsMap[data.Ticker]; exists {
 			newsOverviewRatio := float32(news.PositiveRatio)
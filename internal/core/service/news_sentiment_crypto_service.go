@@ -2,20 +2,276 @@ package service
 
 import (
 	"context"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/quantsmithapp/datastation-backend/internal/core/port"
 	"github.com/quantsmithapp/datastation-backend/internal/model"
 )
 
+const (
+	defaultNewsSentimentDays = 7
+	defaultNewsTickerLimit   = 20
+	maxNewsTickerLimit       = 100
+	defaultNewsHeadlines     = 3
+	maxNewsHeadlines         = 10
+)
+
 type NewsSentimentCryptoService struct {
-	repo port.NewsSentimentCryptoRepo
+	repo    port.NewsSentimentCryptoRepo
+	tickers port.TickerResolver
 }
 
-func NewNewsSentimentCryptoService(repo port.NewsSentimentCryptoRepo) *NewsSentimentCryptoService {
-	return &NewsSentimentCryptoService{repo: repo}
+func NewNewsSentimentCryptoService(repo port.NewsSentimentCryptoRepo, tickers port.TickerResolver) *NewsSentimentCryptoService {
+	return &NewsSentimentCryptoService{repo: repo, tickers: tickers}
 }
 
 func (s *NewsSentimentCryptoService) GetNewsSentiment(ctx context.Context, date time.Time) ([]model.NewsSentimentEntities, error) {
 	return s.repo.GetNewsSentiment(ctx, date)
 }
+
+func (s *NewsSentimentCryptoService) GetNewsSources(ctx context.Context) ([]string, error) {
+	return s.repo.GetNewsSources(ctx)
+}
+
+// QueryNewsSentiment counts the news mentioning each ticker per bucket and source. Without a ticker filter the
+// req.Limit most mentioned tickers are returned. Buckets cover the whole range, empty ones included, so they chart as is.
+func (s *NewsSentimentCryptoService) QueryNewsSentiment(ctx context.Context, req model.NewsSentimentRequest) (model.NewsSentimentResult, error) {
+	loc, err := s.normalizeNewsSentimentRequest(&req)
+	if err != nil {
+		return model.NewsSentimentResult{}, err
+	}
+
+	mentions, err := s.repo.GetNewsMentions(ctx, req)
+	if err != nil {
+		return model.NewsSentimentResult{}, err
+	}
+
+	byTicker := make(map[string][]model.NewsArticleMention)
+	for _, mention := range mentions {
+		byTicker[mention.Ticker] = append(byTicker[mention.Ticker], mention)
+	}
+	tickers := make([]string, 0, len(byTicker))
+	for ticker := range byTicker {
+		tickers = append(tickers, ticker)
+	}
+	sort.Slice(tickers, func(i, j int) bool {
+		if len(byTicker[tickers[i]]) != len(byTicker[tickers[j]]) {
+			return len(byTicker[tickers[i]]) > len(byTicker[tickers[j]])
+		}
+		return tickers[i] < tickers[j]
+	})
+	if len(tickers) > req.Limit {
+		tickers = tickers[:req.Limit]
+	}
+
+	result := model.NewsSentimentResult{
+		From:     req.From,
+		To:       req.To,
+		Bucket:   req.Bucket,
+		Timezone: req.Timezone,
+		Tickers:  make([]model.NewsTickerSentiment, 0, len(tickers)),
+	}
+	for _, ticker := range tickers {
+		result.Tickers = append(result.Tickers, newsTickerSentiment(ticker, byTicker[ticker], req, loc))
+	}
+	return result, nil
+}
+
+func newsTickerSentiment(ticker string, mentions []model.NewsArticleMention, req model.NewsSentimentRequest, loc *time.Location) model.NewsTickerSentiment {
+	var buckets []model.NewsSentimentBucket
+	bucketIdx := make(map[time.Time]int)
+	for start := newsBucketStart(req.From, req.Bucket, loc); start.Before(req.To); start = nextNewsBucket(start, req.Bucket, loc) {
+		bucketIdx[start] = len(buckets)
+		buckets = append(buckets, model.NewsSentimentBucket{Start: start})
+	}
+
+	totals := model.NewsSentimentCounts{}
+	sources := make(map[string]*model.NewsSourceSentiment)
+	bucketSources := make([]map[string]*model.NewsSourceSentiment, len(buckets))
+	bucketMentions := make([][]model.NewsArticleMention, len(buckets))
+	for _, mention := range mentions {
+		i, ok := bucketIdx[newsBucketStart(mention.PublishedAt, req.Bucket, loc)]
+		if !ok {
+			continue
+		}
+		countNewsSentiment(&totals, mention.Sentiment)
+		countNewsSentiment(&buckets[i].NewsSentimentCounts, mention.Sentiment)
+		countNewsSource(sources, mention)
+		if bucketSources[i] == nil {
+			bucketSources[i] = make(map[string]*model.NewsSourceSentiment)
+		}
+		countNewsSource(bucketSources[i], mention)
+		bucketMentions[i] = append(bucketMentions[i], mention)
+	}
+
+	finishNewsSentimentCounts(&totals)
+	for i := range buckets {
+		finishNewsSentimentCounts(&buckets[i].NewsSentimentCounts)
+		buckets[i].Sources = sortedNewsSources(bucketSources[i])
+		buckets[i].TopHeadlines = topNewsHeadlines(bucketMentions[i], buckets[i].NetSentiment, req.Headlines)
+	}
+	return model.NewsTickerSentiment{
+		Ticker:  ticker,
+		Totals:  totals,
+		Sources: sortedNewsSources(sources),
+		Buckets: buckets,
+	}
+}
+
+func countNewsSentiment(counts *model.NewsSentimentCounts, sentiment string) {
+	switch sentiment {
+	case "positive":
+		counts.Positive++
+	case "negative":
+		counts.Negative++
+	default:
+		counts.Neutral++
+	}
+	counts.Total++
+}
+
+func finishNewsSentimentCounts(counts *model.NewsSentimentCounts) {
+	counts.NetSentiment = counts.Positive - counts.Negative
+	if counts.Total > 0 {
+		counts.BullishRatio = float64(counts.NetSentiment) / float64(counts.Total)
+	}
+}
+
+func countNewsSource(sources map[string]*model.NewsSourceSentiment, mention model.NewsArticleMention) {
+	source, ok := sources[mention.Source]
+	if !ok {
+		source = &model.NewsSourceSentiment{Source: mention.Source}
+		sources[mention.Source] = source
+	}
+	countNewsSentiment(&source.NewsSentimentCounts, mention.Sentiment)
+}
+
+func sortedNewsSources(sources map[string]*model.NewsSourceSentiment) []model.NewsSourceSentiment {
+	sorted := make([]model.NewsSourceSentiment, 0, len(sources))
+	for _, source := range sources {
+		finishNewsSentimentCounts(&source.NewsSentimentCounts)
+		sorted = append(sorted, *source)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Total != sorted[j].Total {
+			return sorted[i].Total > sorted[j].Total
+		}
+		return sorted[i].Source < sorted[j].Source
+	})
+	return sorted
+}
+
+// topNewsHeadlines picks the latest articles on the side of the net sentiment, the ones driving the bucket. A bucket
+// without a net sentiment takes the latest positive and negative articles.
+func topNewsHeadlines(mentions []model.NewsArticleMention, netSentiment, limit int) []model.NewsHeadline {
+	headlines := make([]model.NewsHeadline, 0, limit)
+	for i := len(mentions) - 1; i >= 0 && len(headlines) < limit; i-- {
+		mention := mentions[i]
+		switch {
+		case netSentiment > 0 && mention.Sentiment != "positive",
+			netSentiment < 0 && mention.Sentiment != "negative",
+			netSentiment == 0 && mention.Sentiment != "positive" && mention.Sentiment != "negative":
+			continue
+		}
+		headlines = append(headlines, model.NewsHeadline{
+			ID:          mention.ID,
+			Title:       mention.Title,
+			Source:      mention.Source,
+			Sentiment:   mention.Sentiment,
+			PublishedAt: mention.PublishedAt,
+		})
+	}
+	return headlines
+}
+
+func newsBucketStart(t time.Time, bucket string, loc *time.Location) time.Time {
+	local := t.In(loc)
+	year, month, day := local.Date()
+	switch bucket {
+	case model.NewsBucket4Hours:
+		return time.Date(year, month, day, local.Hour()-local.Hour()%4, 0, 0, 0, loc)
+	case model.NewsBucket12Hours:
+		return time.Date(year, month, day, local.Hour()-local.Hour()%12, 0, 0, 0, loc)
+	case model.NewsBucketDay:
+		return time.Date(year, month, day, 0, 0, 0, 0, loc)
+	case model.NewsBucketWeek:
+		// Weeks start on Monday
+		return time.Date(year, month, day-(int(local.Weekday())+6)%7, 0, 0, 0, 0, loc)
+	default:
+		return time.Date(year, month, day, local.Hour(), 0, 0, 0, loc)
+	}
+}
+
+// nextNewsBucket returns the start of the bucket after start on the local wall clock, the way newsBucketStart keys
+// articles. Adding a duration would drift off those keys after a DST change.
+func nextNewsBucket(start time.Time, bucket string, loc *time.Location) time.Time {
+	local := start.In(loc)
+	year, month, day := local.Date()
+	hour, step := local.Hour(), 1
+	switch bucket {
+	case model.NewsBucket4Hours:
+		hour, step = hour-hour%4, 4
+	case model.NewsBucket12Hours:
+		hour, step = hour-hour%12, 12
+	case model.NewsBucketDay:
+		return time.Date(year, month, day+1, 0, 0, 0, 0, loc)
+	case model.NewsBucketWeek:
+		return time.Date(year, month, day+7, 0, 0, 0, 0, loc)
+	}
+
+	next := time.Date(year, month, day, hour+step, 0, 0, 0, loc)
+	for !next.After(start) {
+		// time.Date moves an hour the clocks skip back by the DST offset, which newsBucketStart keys the same way, so
+		// the bucket after it starts a step later
+		hour += step
+		next = time.Date(year, month, day, hour+step, 0, 0, 0, loc)
+	}
+	return next
+}
+
+func (s *NewsSentimentCryptoService) normalizeNewsSentimentRequest(req *model.NewsSentimentRequest) (*time.Location, error) {
+	if req.Timezone == "" {
+		req.Timezone = "UTC"
+	}
+	loc, err := time.LoadLocation(req.Timezone)
+	if err != nil {
+		return nil, model.ErrInvalidNewsTimezone
+	}
+	switch req.Bucket {
+	case "":
+		req.Bucket = model.NewsBucketDay
+	case model.NewsBucketHour, model.NewsBucket4Hours, model.NewsBucket12Hours, model.NewsBucketDay, model.NewsBucketWeek:
+	default:
+		return nil, model.ErrInvalidNewsBucket
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultNewsTickerLimit
+	}
+	if req.Headlines == 0 {
+		req.Headlines = defaultNewsHeadlines
+	}
+	if req.Limit < 1 || req.Limit > maxNewsTickerLimit || req.Headlines < 1 || req.Headlines > maxNewsHeadlines {
+		return nil, model.ErrInvalidNewsLimit
+	}
+	if req.To.IsZero() {
+		req.To = time.Now().UTC()
+	}
+	if req.From.IsZero() {
+		req.From = req.To.AddDate(0, 0, -defaultNewsSentimentDays)
+	}
+	if !req.From.Before(req.To) || req.To.Sub(req.From) > model.MaxNewsSentimentDays*24*time.Hour {
+		return nil, model.ErrInvalidNewsRange
+	}
+
+	req.Tickers = resolveTickers(s.tickers, req.Tickers)
+	sources := make([]string, 0, len(req.Sources))
+	for _, source := range req.Sources {
+		if source = strings.TrimSpace(source); source != "" {
+			sources = append(sources, source)
+		}
+	}
+	req.Sources = sources
+	return loc, nil
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
+
+var newsTestBuckets = []string{
+	model.NewsBucketHour,
+	model.NewsBucket4Hours,
+	model.NewsBucket12Hours,
+	model.NewsBucketDay,
+	model.NewsBucketWeek,
+}
+
+// TestNewsBucketsAcrossDST checks that every article published around both New York DST changes of 2026 lands in a
+// generated bucket, so no article is dropped and each bucket is a key newsBucketStart returns
+func TestNewsBucketsAcrossDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("no tz database: %v", err)
+	}
+
+	ranges := map[string][2]time.Time{
+		"spring forward": {time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC)},
+		"fall back":      {time.Date(2026, 10, 29, 0, 0, 0, 0, time.UTC), time.Date(2026, 11, 5, 0, 0, 0, 0, time.UTC)},
+	}
+	for name, r := range ranges {
+		var mentions []model.NewsArticleMention
+		for at := r[0]; at.Before(r[1]); at = at.Add(15 * time.Minute) {
+			mentions = append(mentions, model.NewsArticleMention{Sentiment: "positive", PublishedAt: at})
+		}
+
+		for _, bucket := range newsTestBuckets {
+			req := model.NewsSentimentRequest{From: r[0], To: r[1], Bucket: bucket}
+			// Weeks and days start before From, only the articles inside the range are fetched
+			result := newsTickerSentiment("BTC", mentions, req, loc)
+
+			if result.Totals.Total != len(mentions) {
+				t.Errorf("%s, %s buckets: counted %d articles, want %d", name, bucket, result.Totals.Total, len(mentions))
+			}
+			for i, b := range result.Buckets {
+				if key := newsBucketStart(b.Start, bucket, loc); !key.Equal(b.Start) {
+					t.Errorf("%s, %s buckets: bucket %v is keyed %v", name, bucket, b.Start, key)
+				}
+				if i > 0 && !b.Start.After(result.Buckets[i-1].Start) {
+					t.Errorf("%s, %s buckets: bucket %v follows %v", name, bucket, b.Start, result.Buckets[i-1].Start)
+				}
+			}
+		}
+	}
+}
+
+func TestNextNewsBucketKeepsWallClock(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("no tz database: %v", err)
+	}
+
+	// The 4 hour buckets of 8 March 2026 start at 0:00 EST, then 4:00 EDT, 3 hours later
+	start := time.Date(2026, 3, 8, 0, 0, 0, 0, loc)
+	if next := nextNewsBucket(start, model.NewsBucket4Hours, loc); !next.Equal(time.Date(2026, 3, 8, 4, 0, 0, 0, loc)) {
+		t.Errorf("4 hour bucket after %v is %v, want 4:00 EDT", start, next)
+	}
+
+	// The 12 hour buckets of 1 November 2026 start at 0:00 EDT, then 12:00 EST, 13 hours later
+	start = time.Date(2026, 11, 1, 0, 0, 0, 0, loc)
+	if next := nextNewsBucket(start, model.NewsBucket12Hours, loc); !next.Equal(time.Date(2026, 11, 1, 12, 0, 0, 0, loc)) {
+		t.Errorf("12 hour bucket after %v is %v, want 12:00 EST", start, next)
+	}
+}
@@ -2,17 +2,55 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/quantsmithapp/datastation-backend/internal/core/port"
 	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
 )
 
 type CryptoNotificationService struct {
-	repo port.NotificationRepo
+	repo            port.NotificationRepo
+	telegramService port.TelegramService
 }
 
-func NewCryptoNotificationService(repo port.NotificationRepo) *CryptoNotificationService {
-	return &CryptoNotificationService{repo: repo}
+// NewCryptoNotificationService manages notification groups. telegramService may be nil, new signals are then not sent
+// to the followers of their author.
+func NewCryptoNotificationService(repo port.NotificationRepo, telegramService port.TelegramService) *CryptoNotificationService {
+	return &CryptoNotificationService{repo: repo, telegramService: telegramService}
+}
+
+// NotifySignal sends a new LONG or SHORT signal to the Telegram of every user with its author in a notification group.
+// It subscribes to ingestion events, replays of a stored signal are skipped.
+func (s *CryptoNotificationService) NotifySignal(event model.SignalEvent) {
+	if s.telegramService == nil || event.Kind != model.SignalEventSignal || !event.Created ||
+		(event.Action != model.SignalActionLong && event.Action != model.SignalActionShort) {
+		return
+	}
+
+	ctx := context.Background()
+	telegrams, err := s.repo.GetAuthorFollowerTelegrams(ctx, event.AuthorUsername)
+	if err != nil {
+		logger.Errorf("notification: failed to get followers of %s: %v", event.AuthorUsername, err)
+		return
+	}
+
+	text := fmt.Sprintf("🔔 New signal from @%s\n%s %s", event.AuthorUsername, event.Action, strings.Join(event.Tickers, ", "))
+	if event.Sentiment != "" {
+		text += " (" + event.Sentiment + ")"
+	}
+	for _, telegram := range telegrams {
+		chatID, err := strconv.ParseInt(telegram.ChatID, 10, 64)
+		if err != nil {
+			logger.Errorf("notification: invalid telegram chat id %q: %v", telegram.ChatID, err)
+			continue
+		}
+		if err := s.telegramService.SendMessage(ctx, text, chatID); err != nil {
+			logger.Errorf("notification: failed to send signal of %s to chat %d: %v", event.AuthorUsername, chatID, err)
+		}
+	}
 }
 
 func (s *CryptoNotificationService) GetNotificationGroupList(ctx context.Context, uid string) ([]model.NotificationGroupList, error) {
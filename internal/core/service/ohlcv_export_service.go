@@ -0,0 +1,405 @@
+package service
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
+)
+
+const (
+	// ohlcvExportJobTTL is how long finished jobs and their files are kept
+	ohlcvExportJobTTL = 24 * time.Hour
+	// ohlcvExportSweepInterval is how often expired jobs and their files are removed
+	ohlcvExportSweepInterval = time.Hour
+	// ohlcvExportConcurrency is how many jobs run at once, later jobs wait pending
+	ohlcvExportConcurrency = 2
+	ohlcvExportRowGroup    = 100000
+	ohlcvExportBatch       = 1000
+)
+
+var ohlcvExportCSVHeader = []string{"tf", "time", "ticker", "open", "high", "low", "close", "volume"}
+
+type OHLCVExportService struct {
+	ohlcvRepo port.TimescaleRepo
+	dir       string
+	slots     chan struct{}
+
+	mu   sync.Mutex
+	jobs map[string]*model.OHLCVExportJob
+}
+
+// NewOHLCVExportService exports OHLCV as gzip CSV or Parquet. ohlcvRepo may be nil when Timescale is not available,
+// the service then answers model.ErrOHLCVUnavailable. Jobs are kept in memory with their files in a temporary
+// directory, so they do not survive a restart.
+func NewOHLCVExportService(ohlcvRepo port.TimescaleRepo) *OHLCVExportService {
+	dir := filepath.Join(os.TempDir(), "ohlcv-exports")
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		logger.Errorf("ohlcv export: failed to create export directory: %v", err)
+	}
+	return &OHLCVExportService{
+		ohlcvRepo: ohlcvRepo,
+		dir:       dir,
+		slots:     make(chan struct{}, ohlcvExportConcurrency),
+		jobs:      make(map[string]*model.OHLCVExportJob),
+	}
+}
+
+// Start removes expired jobs and their files on an interval until ctx is cancelled
+func (s *OHLCVExportService) Start(ctx context.Context) {
+	ticker := time.NewTicker(ohlcvExportSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.mu.Lock()
+			s.removeExpiredJobs()
+			s.mu.Unlock()
+		}
+	}
+}
+
+// PrepareExport validates an export that is streamed in the response. Exports of all pairs or of more than
+// model.MaxOHLCVExportDirectRows candles answer model.ErrExportTooLarge and must run as jobs.
+func (s *OHLCVExportService) PrepareExport(req model.OHLCVExportRequest) (model.OHLCVExportRequest, error) {
+	if s.ohlcvRepo == nil {
+		return req, model.ErrOHLCVUnavailable
+	}
+	if err := normalizeOHLCVExportRequest(&req); err != nil {
+		return req, err
+	}
+	if len(req.Tickers) == 0 || estimateOHLCVExportRows(req) > model.MaxOHLCVExportDirectRows {
+		return req, model.ErrExportTooLarge
+	}
+	return req, nil
+}
+
+// WriteExport writes a request prepared by PrepareExport to w in its format, reading the candles one by one. It
+// returns the number of candles written.
+func (s *OHLCVExportService) WriteExport(ctx context.Context, req model.OHLCVExportRequest, w io.Writer) (int64, error) {
+	if req.Format == model.OHLCVExportParquet {
+		return s.writeParquet(ctx, req, w)
+	}
+	return s.writeCSV(ctx, req, w)
+}
+
+func (s *OHLCVExportService) writeCSV(ctx context.Context, req model.OHLCVExportRequest, w io.Writer) (int64, error) {
+	gz := gzip.NewWriter(w)
+	writer := csv.NewWriter(gz)
+	if err := writer.Write(ohlcvExportCSVHeader); err != nil {
+		return 0, err
+	}
+
+	formatFloat := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+	rows, err := s.streamExport(ctx, req, func(row model.OHLCVExportRow) error {
+		return writer.Write([]string{
+			row.TimeFrame,
+			row.Time.Format(time.RFC3339),
+			row.Ticker,
+			formatFloat(row.Open),
+			formatFloat(row.High),
+			formatFloat(row.Low),
+			formatFloat(row.Close),
+			formatFloat(row.Volume),
+		})
+	})
+	if err != nil {
+		return rows, err
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return rows, err
+	}
+	return rows, gz.Close()
+}
+
+func (s *OHLCVExportService) writeParquet(ctx context.Context, req model.OHLCVExportRequest, w io.Writer) (int64, error) {
+	writer := parquet.NewGenericWriter[model.OHLCVExportRow](w,
+		parquet.Compression(&parquet.Snappy),
+		parquet.MaxRowsPerRowGroup(ohlcvExportRowGroup),
+	)
+
+	batch := make([]model.OHLCVExportRow, 0, ohlcvExportBatch)
+	rows, err := s.streamExport(ctx, req, func(row model.OHLCVExportRow) error {
+		batch = append(batch, row)
+		if len(batch) < ohlcvExportBatch {
+			return nil
+		}
+		_, err := writer.Write(batch)
+		batch = batch[:0]
+		return err
+	})
+	if err != nil {
+		return rows, err
+	}
+
+	if _, err := writer.Write(batch); err != nil {
+		return rows, err
+	}
+	return rows, writer.Close()
+}
+
+// streamExport passes the candles of each timeframe to fn by time, then ticker
+func (s *OHLCVExportService) streamExport(ctx context.Context, req model.OHLCVExportRequest, fn func(model.OHLCVExportRow) error) (int64, error) {
+	stream := s.ohlcvRepo.StreamCryptoOHLCV
+	if req.Market == model.IndicatorMarketForex {
+		stream = s.ohlcvRepo.StreamForexOHLCV
+	}
+
+	var rows int64
+	for _, timeFrame := range req.TimeFrames {
+		ohlcvReq := model.OHLCVRequest{
+			TimeFrame: timeFrame,
+			StartDate: req.StartDate,
+			EndDate:   req.EndDate,
+			AllPair:   true,
+			Tickers:   req.Tickers,
+			Timezone:  req.Timezone,
+		}
+		err := stream(ctx, ohlcvReq, func(data model.OHLCVData) error {
+			rows++
+			return fn(model.OHLCVExportRow{
+				TimeFrame: timeFrame,
+				Time:      data.Time,
+				Ticker:    data.Ticker,
+				Open:      data.Open,
+				High:      data.High,
+				Low:       data.Low,
+				Close:     data.Close,
+				Volume:    data.Volume,
+			})
+		})
+		if err != nil {
+			return rows, err
+		}
+	}
+	return rows, nil
+}
+
+// CreateJob queues the export and writes it to a file in the background. A user can have at most
+// model.MaxOHLCVExportActiveJobs jobs pending or running, further jobs answer model.ErrTooManyExportJobs.
+func (s *OHLCVExportService) CreateJob(ctx context.Context, userUUID string, req model.OHLCVExportRequest) (model.OHLCVExportJob, error) {
+	if s.ohlcvRepo == nil {
+		return model.OHLCVExportJob{}, model.ErrOHLCVUnavailable
+	}
+	if err := normalizeOHLCVExportRequest(&req); err != nil {
+		return model.OHLCVExportJob{}, err
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return model.OHLCVExportJob{}, fmt.Errorf("failed to generate export job id: %w", err)
+	}
+	job := &model.OHLCVExportJob{
+		ID:        hex.EncodeToString(id),
+		Status:    model.OHLCVExportPending,
+		Request:   req,
+		CreatedAt: time.Now().UTC(),
+		UserUUID:  userUUID,
+	}
+
+	s.mu.Lock()
+	if s.activeJobs(userUUID) >= model.MaxOHLCVExportActiveJobs {
+		s.mu.Unlock()
+		return model.OHLCVExportJob{}, model.ErrTooManyExportJobs
+	}
+	s.jobs[job.ID] = job
+	created := *job
+	s.mu.Unlock()
+
+	go s.runJob(job.ID)
+	return created, nil
+}
+
+func (s *OHLCVExportService) runJob(id string) {
+	s.slots <- struct{}{}
+	defer func() { <-s.slots }()
+
+	s.mu.Lock()
+	job := s.jobs[id]
+	job.Status = model.OHLCVExportRunning
+	req := job.Request
+	s.mu.Unlock()
+
+	path := s.jobPath(id, req.Format)
+	rows, err := s.writeJobFile(path, req)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now().UTC()
+	expiresAt := now.Add(ohlcvExportJobTTL)
+	job.Rows = rows
+	job.CompletedAt = &now
+	job.ExpiresAt = &expiresAt
+	if err != nil {
+		logger.Errorf("ohlcv export: job %s failed: %v", id, err)
+		os.Remove(path)
+		job.Status = model.OHLCVExportFailed
+		job.Error = "Failed to export OHLCV"
+		if errors.Is(err, model.ErrUnsupportedTimeFrame) {
+			job.Error = err.Error()
+		}
+		return
+	}
+	job.Status = model.OHLCVExportCompleted
+}
+
+func (s *OHLCVExportService) writeJobFile(path string, req model.OHLCVExportRequest) (int64, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	buffered := bufio.NewWriter(file)
+	rows, err := s.WriteExport(context.Background(), req, buffered)
+	if err != nil {
+		return rows, err
+	}
+	if err := buffered.Flush(); err != nil {
+		return rows, err
+	}
+	return rows, file.Close()
+}
+
+// GetJob returns a job of the user, jobs of other users are not found
+func (s *OHLCVExportService) GetJob(userUUID, id string) (model.OHLCVExportJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[id]
+	if !ok || job.UserUUID != userUUID || jobExpired(job) {
+		return model.OHLCVExportJob{}, model.ErrExportJobNotFound
+	}
+	return *job, nil
+}
+
+// GetJobFile returns the path of the file of a completed job and the name to download it as
+func (s *OHLCVExportService) GetJobFile(userUUID, id string) (string, string, error) {
+	job, err := s.GetJob(userUUID, id)
+	if err != nil {
+		return "", "", err
+	}
+	if job.Status != model.OHLCVExportCompleted {
+		return "", "", model.ErrExportJobNotCompleted
+	}
+	return s.jobPath(job.ID, job.Request.Format), job.Request.FileName(), nil
+}
+
+// activeJobs counts the pending and running jobs of the user, s.mu must be held
+func (s *OHLCVExportService) activeJobs(userUUID string) int {
+	active := 0
+	for _, job := range s.jobs {
+		if job.UserUUID == userUUID && (job.Status == model.OHLCVExportPending || job.Status == model.OHLCVExportRunning) {
+			active++
+		}
+	}
+	return active
+}
+
+// removeExpiredJobs drops finished jobs past their expiry with their files, s.mu must be held
+func (s *OHLCVExportService) removeExpiredJobs() {
+	for id, job := range s.jobs {
+		if jobExpired(job) {
+			os.Remove(s.jobPath(id, job.Request.Format))
+			delete(s.jobs, id)
+		}
+	}
+}
+
+func jobExpired(job *model.OHLCVExportJob) bool {
+	return job.ExpiresAt != nil && time.Now().After(*job.ExpiresAt)
+}
+
+func (s *OHLCVExportService) jobPath(id, format string) string {
+	return filepath.Join(s.dir, id+model.OHLCVExportExtension(format))
+}
+
+// estimateOHLCVExportRows is the number of candles the export has when no candle is missing
+func estimateOHLCVExportRows(req model.OHLCVExportRequest) int64 {
+	end := time.Now()
+	if req.EndDate != nil {
+		end = *req.EndDate
+	}
+	var rows int64
+	for _, tf := range req.TimeFrames {
+		timeFrame, _ := model.ParseTimeFrame(tf)
+		rows += (int64(end.Sub(req.StartDate)/timeFrame) + 1) * int64(len(req.Tickers))
+	}
+	return rows
+}
+
+func normalizeOHLCVExportRequest(req *model.OHLCVExportRequest) error {
+	req.Market = strings.ToLower(strings.TrimSpace(req.Market))
+	if req.Market == "" {
+		req.Market = model.IndicatorMarketCrypto
+	}
+	if req.Market != model.IndicatorMarketCrypto && req.Market != model.IndicatorMarketForex {
+		return model.ErrInvalidExportMarket
+	}
+	req.Format = strings.ToLower(strings.TrimSpace(req.Format))
+	if req.Format == "" {
+		req.Format = model.OHLCVExportCSV
+	}
+	if req.Format != model.OHLCVExportCSV && req.Format != model.OHLCVExportParquet {
+		return model.ErrInvalidExportFormat
+	}
+
+	req.Tickers = normalizeExportList(req.Tickers, strings.ToUpper)
+	if len(req.Tickers) > model.MaxOHLCVExportTickers {
+		return model.ErrExportTooManyTickers
+	}
+	req.TimeFrames = normalizeExportList(req.TimeFrames, strings.ToLower)
+	if len(req.TimeFrames) == 0 || len(req.TimeFrames) > model.MaxOHLCVExportTimeFrames {
+		return model.ErrExportTimeFramesInvalid
+	}
+	for _, tf := range req.TimeFrames {
+		if _, ok := model.ParseTimeFrame(tf); !ok {
+			return model.ErrExportTimeFramesInvalid
+		}
+	}
+
+	if req.Timezone != "" {
+		if _, err := time.LoadLocation(req.Timezone); err != nil {
+			return model.ErrInvalidOHLCVTimezone
+		}
+	}
+	if req.EndDate != nil && !req.EndDate.After(req.StartDate) {
+		return model.ErrInvalidExportRange
+	}
+	return nil
+}
+
+// normalizeExportList trims, converts and dedupes the values, keeping their order
+func normalizeExportList(values []string, convert func(string) string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		v = convert(strings.TrimSpace(v))
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		result = append(result, v)
+	}
+	return result
+}
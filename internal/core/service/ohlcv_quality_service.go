@@ -0,0 +1,407 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/quantsmithapp/datastation-backend/config"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
+)
+
+const (
+	defaultOHLCVQualityIntervalMinutes = 360
+	defaultOHLCVQualityLookbackDays    = 7
+	defaultOHLCVQualityZeroVolumeRun   = 3
+	maxOHLCVQualityLookbackDays        = 90
+	// ohlcvBackfillMaxBars is how many of the latest bars TradingView returns at most, older gaps are not repaired
+	ohlcvBackfillMaxBars = 5000
+	// ohlcvStaleMinAge keeps short timeframes from being reported for an ingestion hiccup
+	ohlcvStaleMinAge = time.Hour
+)
+
+// ohlcvBackfillExchanges is the TradingView exchange of the tickers of each OHLCV data type
+var ohlcvBackfillExchanges = map[string]string{
+	"binance": "BINANCE",
+	"forex":   "FX_IDC",
+}
+
+type OHLCVQualityService struct {
+	repo     port.OHLCVQualityRepo
+	backfill port.OHLCVBackfillSource
+	config   config.OHLCVQualityConfig
+
+	running atomic.Bool
+}
+
+// NewOHLCVQualityService checks the Timescale OHLCV tables. repo may be nil when Timescale is not available, the
+// service then answers model.ErrOHLCVUnavailable. Gaps are only repaired with a backfill source and cfg.Repair.
+func NewOHLCVQualityService(repo port.OHLCVQualityRepo, backfill port.OHLCVBackfillSource, cfg config.OHLCVQualityConfig) *OHLCVQualityService {
+	if cfg.IntervalMinutes <= 0 {
+		cfg.IntervalMinutes = defaultOHLCVQualityIntervalMinutes
+	}
+	if cfg.LookbackDays <= 0 || cfg.LookbackDays > maxOHLCVQualityLookbackDays {
+		cfg.LookbackDays = defaultOHLCVQualityLookbackDays
+	}
+	if cfg.ZeroVolumeRun <= 0 {
+		cfg.ZeroVolumeRun = defaultOHLCVQualityZeroVolumeRun
+	}
+	return &OHLCVQualityService{
+		repo:     repo,
+		backfill: backfill,
+		config:   cfg,
+	}
+}
+
+// Start scans the tables on the configured interval until ctx is cancelled
+func (s *OHLCVQualityService) Start(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(s.config.IntervalMinutes) * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Scan(ctx); err != nil {
+			logger.Errorf("ohlcv quality: scan failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// TriggerScan starts a scan in the background unless one is already running
+func (s *OHLCVQualityService) TriggerScan() error {
+	if s.repo == nil {
+		return model.ErrOHLCVUnavailable
+	}
+	if !s.running.CompareAndSwap(false, true) {
+		return model.ErrOHLCVQualityScanRunning
+	}
+	go func() {
+		defer s.running.Store(false)
+		if err := s.scan(context.Background()); err != nil {
+			logger.Errorf("ohlcv quality: scan failed: %v", err)
+		}
+	}()
+	return nil
+}
+
+// Scan checks every OHLCV table, repairs the gaps it can and stores the findings as a run
+func (s *OHLCVQualityService) Scan(ctx context.Context) error {
+	if s.repo == nil {
+		return model.ErrOHLCVUnavailable
+	}
+	if !s.running.CompareAndSwap(false, true) {
+		return model.ErrOHLCVQualityScanRunning
+	}
+	defer s.running.Store(false)
+	return s.scan(ctx)
+}
+
+func (s *OHLCVQualityService) scan(ctx context.Context) error {
+	runAt := time.Now().UTC().Truncate(time.Microsecond)
+	since := runAt.AddDate(0, 0, -s.config.LookbackDays)
+
+	tables, err := s.repo.GetOHLCVTables(ctx)
+	if err != nil {
+		return err
+	}
+
+	var findings []model.OHLCVQualityFinding
+	for _, table := range tables {
+		// A failing table is logged so the others are still checked
+		tableFindings, err := s.checkTable(ctx, table, since, runAt)
+		if err != nil {
+			logger.Errorf("ohlcv quality: failed to check %s: %v", table.Name, err)
+			continue
+		}
+		findings = append(findings, tableFindings...)
+	}
+
+	run := model.OHLCVQualityRun{
+		RunAt:      runAt,
+		FinishedAt: time.Now().UTC(),
+		Tables:     len(tables),
+		Findings:   len(findings),
+	}
+	for _, f := range findings {
+		run.Repaired += f.Repaired
+	}
+	return s.repo.SaveQualityRun(ctx, run, findings)
+}
+
+func (s *OHLCVQualityService) checkTable(ctx context.Context, table model.OHLCVTable, since, now time.Time) ([]model.OHLCVQualityFinding, error) {
+	forex := table.DataType == "forex"
+	var findings []model.OHLCVQualityFinding
+
+	if table.TimeFrame > 0 {
+		gaps, err := s.repo.FindGaps(ctx, table, since)
+		if err != nil {
+			return nil, err
+		}
+		if forex {
+			gaps = withoutForexClosures(gaps, table.TimeFrame)
+		}
+		s.repairGaps(ctx, table, gaps, now)
+		findings = append(findings, gaps...)
+
+		nonMonotonic, err := s.repo.FindNonMonotonic(ctx, table, since)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, nonMonotonic...)
+	}
+
+	duplicates, err := s.repo.FindDuplicates(ctx, table, since)
+	if err != nil {
+		return nil, err
+	}
+	findings = append(findings, duplicates...)
+
+	// FX_IDC quotes carry no real volume
+	if !forex {
+		zeroVolume, err := s.repo.FindZeroVolumeRuns(ctx, table, since, s.config.ZeroVolumeRun)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, zeroVolume...)
+	}
+
+	inconsistent, err := s.repo.FindInconsistentCandles(ctx, table, since)
+	if err != nil {
+		return nil, err
+	}
+	findings = append(findings, inconsistent...)
+
+	latest, err := s.repo.GetLatestCandleTimes(ctx, table, since)
+	if err != nil {
+		return nil, err
+	}
+	findings = append(findings, staleFindings(table, latest, since, now)...)
+
+	for i := range findings {
+		findings[i].RunAt = now
+	}
+	return findings, nil
+}
+
+// repairGaps backfills the missing candles of writable tables, fetching the bars of each ticker once
+func (s *OHLCVQualityService) repairGaps(ctx context.Context, table model.OHLCVTable, gaps []model.OHLCVQualityFinding, now time.Time) {
+	if !s.config.Repair || s.backfill == nil || !table.Writable || len(gaps) == 0 {
+		return
+	}
+	exchange, ok := ohlcvBackfillExchanges[table.DataType]
+	interval := tradingViewInterval(table.TimeFrame)
+	if !ok || interval == "" {
+		return
+	}
+
+	earliest := make(map[string]time.Time)
+	for _, gap := range gaps {
+		if start, ok := earliest[gap.Ticker]; !ok || gap.StartTime.Before(start) {
+			earliest[gap.Ticker] = gap.StartTime
+		}
+	}
+
+	candles := make(map[string][]model.OHLCVData, len(earliest))
+	for ticker, start := range earliest {
+		bars := int(now.Sub(start)/table.TimeFrame) + 1
+		if bars > ohlcvBackfillMaxBars {
+			bars = ohlcvBackfillMaxBars
+		}
+		data, err := s.backfill.GetCandles(ticker, exchange, interval, bars)
+		if err != nil {
+			logger.Errorf("ohlcv quality: failed to fetch %s %s candles of %s: %v", exchange, interval, ticker, err)
+			continue
+		}
+		candles[ticker] = data
+	}
+
+	for i := range gaps {
+		gap := &gaps[i]
+		data, ok := candles[gap.Ticker]
+		if !ok {
+			continue
+		}
+		var missing []model.OHLCVData
+		for _, candle := range data {
+			if !candle.Time.Before(gap.StartTime) && !candle.Time.After(gap.EndTime) {
+				missing = append(missing, candle)
+			}
+		}
+		if len(missing) == 0 {
+			gap.Detail = "no candles upstream"
+			continue
+		}
+		inserted, err := s.repo.InsertCandles(ctx, table, missing)
+		if err != nil {
+			logger.Errorf("ohlcv quality: failed to backfill %s of %s: %v", table.Name, gap.Ticker, err)
+			continue
+		}
+		gap.Repaired = inserted
+	}
+}
+
+func (s *OHLCVQualityService) GetReport(ctx context.Context, filter model.OHLCVQualityFilter) (model.OHLCVQualityReport, error) {
+	if s.repo == nil {
+		return model.OHLCVQualityReport{}, model.ErrOHLCVUnavailable
+	}
+	if filter.Kind != "" && !model.IsValidOHLCVIssue(filter.Kind) {
+		return model.OHLCVQualityReport{}, model.ErrInvalidOHLCVIssue
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = model.DefaultOHLCVQualityLimit
+	}
+	if filter.Limit > model.MaxOHLCVQualityLimit {
+		filter.Limit = model.MaxOHLCVQualityLimit
+	}
+
+	run, err := s.repo.GetQualityRun(ctx, filter.RunAt)
+	if err != nil {
+		return model.OHLCVQualityReport{}, err
+	}
+	filter.RunAt = &run.RunAt
+
+	summary, err := s.repo.CountQualityFindings(ctx, filter)
+	if err != nil {
+		return model.OHLCVQualityReport{}, err
+	}
+	findings, err := s.repo.GetQualityFindings(ctx, filter)
+	if err != nil {
+		return model.OHLCVQualityReport{}, err
+	}
+	return model.OHLCVQualityReport{Run: run, Summary: summary, Findings: findings}, nil
+}
+
+func (s *OHLCVQualityService) GetRuns(ctx context.Context, limit int) ([]model.OHLCVQualityRun, error) {
+	if s.repo == nil {
+		return nil, model.ErrOHLCVUnavailable
+	}
+	if limit <= 0 || limit > model.MaxOHLCVQualityLimit {
+		limit = model.DefaultOHLCVQualityLimit
+	}
+	return s.repo.GetQualityRuns(ctx, limit)
+}
+
+// staleFindings reports the tickers whose latest candle is older than three candles, and the table when it has none
+func staleFindings(table model.OHLCVTable, latest map[string]time.Time, since, now time.Time) []model.OHLCVQualityFinding {
+	if len(latest) == 0 {
+		return []model.OHLCVQualityFinding{{
+			TableName: table.Name,
+			Kind:      model.OHLCVIssueStale,
+			StartTime: since,
+			EndTime:   now,
+			Detail:    "no candles in the lookback window",
+		}}
+	}
+
+	threshold := 3 * table.TimeFrame
+	if threshold < ohlcvStaleMinAge {
+		threshold = ohlcvStaleMinAge
+	}
+	var findings []model.OHLCVQualityFinding
+	for ticker, t := range latest {
+		// The latest candle is still open until one timeframe after it starts
+		age := now.Sub(t.Add(table.TimeFrame))
+		if table.DataType == "forex" {
+			age -= forexClosedDuration(t.Add(table.TimeFrame), now)
+		}
+		if age <= threshold {
+			continue
+		}
+		finding := model.OHLCVQualityFinding{
+			TableName: table.Name,
+			Ticker:    ticker,
+			Kind:      model.OHLCVIssueStale,
+			StartTime: t,
+			EndTime:   now,
+			Detail:    fmt.Sprintf("latest candle is %s old", age.Truncate(time.Minute)),
+		}
+		if table.TimeFrame > 0 {
+			finding.Candles = int(age / table.TimeFrame)
+		}
+		findings = append(findings, finding)
+	}
+	return findings
+}
+
+// withoutForexClosures recounts forex gaps without the candles of the weekend closure and drops those left empty
+func withoutForexClosures(gaps []model.OHLCVQualityFinding, tf time.Duration) []model.OHLCVQualityFinding {
+	kept := gaps[:0]
+	for _, gap := range gaps {
+		open := 0
+		for t := gap.StartTime; !t.After(gap.EndTime); t = t.Add(tf) {
+			if !isForexClosed(t) {
+				open++
+			}
+		}
+		if open == 0 {
+			continue
+		}
+		gap.Candles = open
+		kept = append(kept, gap)
+	}
+	return kept
+}
+
+// isForexClosed reports whether t is in the weekend closure, Friday 21:00 to Sunday 22:00 UTC
+func isForexClosed(t time.Time) bool {
+	t = t.UTC()
+	switch t.Weekday() {
+	case time.Friday:
+		return t.Hour() >= 21
+	case time.Saturday:
+		return true
+	case time.Sunday:
+		return t.Hour() < 22
+	}
+	return false
+}
+
+// forexClosedDuration is how much of [from, to) falls in weekend closures
+func forexClosedDuration(from, to time.Time) time.Duration {
+	if !to.After(from) {
+		return 0
+	}
+	from = from.UTC()
+	// Start from the closure of the week before from, it may still be running
+	day := time.Date(from.Year(), from.Month(), from.Day(), 21, 0, 0, 0, time.UTC)
+	day = day.AddDate(0, 0, -int((day.Weekday()-time.Friday+7)%7)-7)
+
+	var closed time.Duration
+	for start := day; start.Before(to); start = start.AddDate(0, 0, 7) {
+		end := start.Add(49 * time.Hour)
+		lo, hi := start, end
+		if from.After(lo) {
+			lo = from
+		}
+		if to.Before(hi) {
+			hi = to
+		}
+		if hi.After(lo) {
+			closed += hi.Sub(lo)
+		}
+	}
+	return closed
+}
+
+// tradingViewInterval formats a timeframe in TradingView's notation, minutes up to a day, then days or weeks
+func tradingViewInterval(tf time.Duration) string {
+	const day = 24 * time.Hour
+	switch {
+	case tf <= 0:
+		return ""
+	case tf%(7*day) == 0:
+		return strconv.Itoa(int(tf/(7*day))) + "W"
+	case tf%day == 0:
+		return strconv.Itoa(int(tf/day)) + "D"
+	case tf%time.Minute == 0:
+		return strconv.Itoa(int(tf / time.Minute))
+	}
+	return ""
+}
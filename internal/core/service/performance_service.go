@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"math"
+	"time"
 
 	"github.com/quantsmithapp/datastation-backend/internal/core/adapter/repo"
 	"github.com/quantsmithapp/datastation-backend/internal/model"
@@ -92,3 +94,150 @@ func (s *PerformanceService) GetAuthorMultiholdingDetail(ctx context.Context, au
 
 	return result, nil
 }
+
+// GetAuthorReturnStats builds the monthly returns calendar and rolling 30/90 day statistics from the author's full NAV
+// history. An empty holdingPeriod uses the single-holding NAV, otherwise the multiholding NAV of that holding period.
+func (s *PerformanceService) GetAuthorReturnStats(ctx context.Context, authorUsername string, holdingPeriod string) (model.AuthorReturnStats, error) {
+	navs, err := s.repo.AuthorNavHistoryRepo(ctx, authorUsername, holdingPeriod)
+	if err != nil {
+		return model.AuthorReturnStats{}, err
+	}
+
+	return model.AuthorReturnStats{
+		AuthorUsername: authorUsername,
+		HoldingPeriod:  holdingPeriod,
+		MonthlyReturns: buildMonthlyReturns(navs),
+		Rolling30D:     buildRollingStats(navs, 30),
+		Rolling90D:     buildRollingStats(navs, 90),
+	}, nil
+}
+
+// buildMonthlyReturns compares each month's closing NAV to the previous month's close, the first month
+// is measured from the first NAV in the history
+func buildMonthlyReturns(navs []model.Nav) []model.MonthlyReturn {
+	result := []model.MonthlyReturn{}
+	if len(navs) == 0 {
+		return result
+	}
+
+	yearIndex := make(map[int]int)
+	prevMonthClose := navs[0].Nav
+	prevYearClose := navs[0].Nav
+	for i, nav := range navs {
+		isMonthEnd := i == len(navs)-1 || navs[i+1].Datetime.Month() != nav.Datetime.Month() || navs[i+1].Datetime.Year() != nav.Datetime.Year()
+		if !isMonthEnd {
+			continue
+		}
+
+		year := nav.Datetime.Year()
+		idx, ok := yearIndex[year]
+		if !ok {
+			result = append(result, model.MonthlyReturn{Year: year, Months: make([]*float64, 12)})
+			idx = len(result) - 1
+			yearIndex[year] = idx
+		}
+
+		if monthReturn, ok := percentChange(prevMonthClose, nav.Nav); ok {
+			result[idx].Months[nav.Datetime.Month()-1] = &monthReturn
+		}
+		prevMonthClose = nav.Nav
+
+		isYearEnd := i == len(navs)-1 || navs[i+1].Datetime.Year() != year
+		if isYearEnd {
+			if yearReturn, ok := percentChange(prevYearClose, nav.Nav); ok {
+				result[idx].YearReturn = &yearReturn
+			}
+			prevYearClose = nav.Nav
+		}
+	}
+	return result
+}
+
+// buildRollingStats returns, for every day with a full trailing window of history, the window return (percent),
+// annualized volatility of daily returns (percent) and maximum drawdown (fraction, same as AuthorNav)
+func buildRollingStats(navs []model.Nav, windowDays int) []model.RollingStat {
+	result := []model.RollingStat{}
+	if len(navs) == 0 {
+		return result
+	}
+
+	window := time.Duration(windowDays) * 24 * time.Hour
+	first := navs[0].Datetime
+	start := 0
+	for end := range navs {
+		windowStart := navs[end].Datetime.Add(-window)
+		if windowStart.Before(first) {
+			continue
+		}
+		for navs[start].Datetime.Before(windowStart) {
+			start++
+		}
+		points := navs[start : end+1]
+		if len(points) < 2 {
+			continue
+		}
+
+		windowReturn, ok := percentChange(points[0].Nav, points[len(points)-1].Nav)
+		if !ok {
+			continue
+		}
+		result = append(result, model.RollingStat{
+			Datetime:    navs[end].Datetime,
+			Return:      windowReturn,
+			Volatility:  annualizedVolatility(points),
+			MaxDrawdown: maxDrawdown(points),
+		})
+	}
+	return result
+}
+
+func percentChange(from, to float64) (float64, bool) {
+	change := (to/from - 1) * 100
+	if math.IsNaN(change) || math.IsInf(change, 0) {
+		return 0, false
+	}
+	return change, true
+}
+
+func annualizedVolatility(navs []model.Nav) float64 {
+	returns := make([]float64, 0, len(navs)-1)
+	for i := 1; i < len(navs); i++ {
+		r := navs[i].Nav/navs[i-1].Nav - 1
+		if math.IsNaN(r) || math.IsInf(r, 0) {
+			continue
+		}
+		returns = append(returns, r)
+	}
+	if len(returns) < 2 {
+		return 0
+	}
+
+	var mean float64
+	for _, r := range returns {
+		mean += r
+	}
+	mean /= float64(len(returns))
+
+	var variance float64
+	for _, r := range returns {
+		variance += (r - mean) * (r - mean)
+	}
+	variance /= float64(len(returns) - 1)
+
+	// NAV is marked daily and crypto trades every day of the year
+	return math.Sqrt(variance) * math.Sqrt(365) * 100
+}
+
+func maxDrawdown(navs []model.Nav) float64 {
+	var peak, maxDD float64
+	for i, nav := range navs {
+		if i == 0 || nav.Nav > peak {
+			peak = nav.Nav
+		}
+		drawdown := (peak - nav.Nav) / peak
+		if !math.IsNaN(drawdown) && !math.IsInf(drawdown, 0) && drawdown > maxDD {
+			maxDD = drawdown
+		}
+	}
+	return maxDD
+}
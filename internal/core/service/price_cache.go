@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
+)
+
+const (
+	priceRefreshInterval = time.Minute
+	priceRefreshTimeout  = 30 * time.Second
+	// A failed provider's prices are served this long after its last successful refresh, then the next providers fill in
+	priceStaleAfter = 5 * priceRefreshInterval
+)
+
+// PriceCache holds the latest prices of the providers by canonical ticker and refreshes them in the background.
+// Providers are in priority order: a ticker takes the values of the first provider that has it, and the values it
+// lacks from the next ones.
+type PriceCache struct {
+	providers []port.PriceProvider
+	tickers   port.TickerResolver
+
+	mu     sync.RWMutex
+	prices map[string]model.MarketPrice
+	// last holds the latest prices of each provider by canonical ticker and fetchedAt when they were fetched, in the
+	// order of providers
+	last      []map[string]model.MarketPrice
+	fetchedAt []time.Time
+}
+
+func NewPriceCache(tickers port.TickerResolver, providers ...port.PriceProvider) *PriceCache {
+	return &PriceCache{
+		providers: providers,
+		tickers:   tickers,
+		prices:    make(map[string]model.MarketPrice),
+		last:      make([]map[string]model.MarketPrice, len(providers)),
+		fetchedAt: make([]time.Time, len(providers)),
+	}
+}
+
+// Start refreshes the prices right away and then every minute until ctx is done
+func (c *PriceCache) Start(ctx context.Context) {
+	ticker := time.NewTicker(priceRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.Refresh(ctx); err != nil {
+			logger.Errorf("price cache: refresh failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh loads the prices of every provider. The prices of a failed provider are kept from its last successful refresh
+// for priceStaleAfter, it only fails when every provider does.
+func (c *PriceCache) Refresh(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, priceRefreshTimeout)
+	defer cancel()
+
+	results := make([][]model.MarketPrice, len(c.providers))
+	errs := make([]error, len(c.providers))
+	var wg sync.WaitGroup
+	for i, provider := range c.providers {
+		wg.Add(1)
+		go func(i int, provider port.PriceProvider) {
+			defer wg.Done()
+			results[i], errs[i] = provider.FetchPrices(ctx)
+		}(i, provider)
+	}
+	wg.Wait()
+
+	c.mu.RLock()
+	last := append([]map[string]model.MarketPrice(nil), c.last...)
+	fetchedAt := append([]time.Time(nil), c.fetchedAt...)
+	size := len(c.prices)
+	c.mu.RUnlock()
+
+	now := time.Now()
+	failed := 0
+	for i, provider := range c.providers {
+		// Failed providers keep their prices of the last refresh until they are stale
+		if errs[i] != nil {
+			failed++
+			logger.Errorf("price cache: %s failed: %v", provider.Name(), errs[i])
+			if last[i] != nil && now.Sub(fetchedAt[i]) > priceStaleAfter {
+				logger.Errorf("price cache: dropping the %s prices of %s", provider.Name(), fetchedAt[i].Format(time.RFC3339))
+				last[i] = nil
+			}
+			continue
+		}
+
+		// Several pairs of an asset can resolve to the same ticker, the one with the most volume wins
+		own := make(map[string]model.MarketPrice, len(results[i]))
+		for _, price := range results[i] {
+			symbol := c.tickers.Resolve(price.Symbol)
+			if symbol == "" {
+				continue
+			}
+			if existing, ok := own[symbol]; ok && priceVolume(existing) >= priceVolume(price) {
+				continue
+			}
+			price.Symbol = symbol
+			own[symbol] = price
+		}
+		last[i] = own
+		fetchedAt[i] = now
+	}
+
+	prices := make(map[string]model.MarketPrice, size)
+	for _, own := range last {
+		for symbol, price := range own {
+			mergePrice(prices, symbol, price)
+		}
+	}
+
+	c.mu.Lock()
+	c.prices = prices
+	c.last = last
+	c.fetchedAt = fetchedAt
+	c.mu.Unlock()
+
+	if len(c.providers) > 0 && failed == len(c.providers) {
+		return errors.Join(errs...)
+	}
+	return nil
+}
+
+func (c *PriceCache) GetPrice(symbol string) (model.MarketPrice, bool) {
+	key := c.tickers.Resolve(symbol)
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	price, ok := c.prices[key]
+	return price, ok
+}
+
+// GetPrices returns the prices of the symbols that have one, keyed by the symbols as given
+func (c *PriceCache) GetPrices(symbols []string) map[string]model.MarketPrice {
+	keys := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		keys[i] = c.tickers.Resolve(symbol)
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	result := make(map[string]model.MarketPrice, len(symbols))
+	for i, symbol := range symbols {
+		if price, ok := c.prices[keys[i]]; ok {
+			result[symbol] = price
+		}
+	}
+	return result
+}
+
+// mergePrice adds the price of a lower priority provider, it only fills the values the ticker lacks
+func mergePrice(prices map[string]model.MarketPrice, symbol string, price model.MarketPrice) {
+	existing, ok := prices[symbol]
+	if !ok {
+		prices[symbol] = price
+		return
+	}
+	fill := func(dst **float64, src *float64) {
+		if *dst == nil {
+			*dst = src
+		}
+	}
+	fill(&existing.Price, price.Price)
+	fill(&existing.Change24h, price.Change24h)
+	fill(&existing.Volume24h, price.Volume24h)
+	fill(&existing.MarketCap, price.MarketCap)
+	prices[symbol] = existing
+}
+
+func priceVolume(price model.MarketPrice) float64 {
+	if price.Volume24h == nil {
+		return 0
+	}
+	return *price.Volume24h
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
+)
+
+const (
+	// priceClientBuffer is how many messages a client may fall behind before it is dropped
+	priceClientBuffer          = 64
+	defaultPriceStreamExchange = "BINANCE"
+)
+
+type priceClient struct {
+	send    chan []byte
+	symbols map[string]bool
+}
+
+// PriceHub fans the quotes of one upstream subscription per symbol out to the clients subscribed to it. Sends never
+// block: a client whose buffer is full is dropped, which closes its channel so the connection can be closed.
+type PriceHub struct {
+	source port.PriceQuoteSource
+
+	mu          sync.Mutex
+	nextID      uint64
+	clients     map[uint64]*priceClient
+	subscribers map[string]map[uint64]*priceClient
+	// last is the latest quote message of each subscribed symbol, sent to new subscribers right away
+	last map[string][]byte
+}
+
+func NewPriceHub(source port.PriceQuoteSource) *PriceHub {
+	return &PriceHub{
+		source:      source,
+		clients:     make(map[uint64]*priceClient),
+		subscribers: make(map[string]map[uint64]*priceClient),
+		last:        make(map[string][]byte),
+	}
+}
+
+func (h *PriceHub) Register() (uint64, <-chan []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.nextID++
+	client := &priceClient{
+		send:    make(chan []byte, priceClientBuffer),
+		symbols: make(map[string]bool),
+	}
+	h.clients[h.nextID] = client
+	return h.nextID, client.send
+}
+
+// Unregister removes the client and its subscriptions, it is a no-op for clients already dropped
+func (h *PriceHub) Unregister(clientID uint64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.dropLocked(clientID)
+}
+
+// Subscribe adds the symbols to the client's subscriptions and returns them normalized, symbols without an
+// exchange prefix are on BINANCE
+func (h *PriceHub) Subscribe(clientID uint64, symbols []string) ([]string, error) {
+	symbols = normalizePriceSymbols(symbols)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	client, ok := h.clients[clientID]
+	if !ok {
+		return nil, nil
+	}
+	added := 0
+	for _, symbol := range symbols {
+		if !client.symbols[symbol] {
+			added++
+		}
+	}
+	if len(client.symbols)+added > model.MaxPriceStreamSymbols {
+		return nil, model.ErrTooManyPriceSymbols
+	}
+
+	for _, symbol := range symbols {
+		if client.symbols[symbol] {
+			continue
+		}
+		client.symbols[symbol] = true
+		if h.subscribers[symbol] == nil {
+			h.subscribers[symbol] = make(map[uint64]*priceClient)
+			// A failed write drops the upstream connection, the symbol is added again when it reconnects
+			if err := h.source.Subscribe(symbol); err != nil {
+				logger.Errorf("price stream: failed to subscribe to %s: %v", symbol, err)
+			}
+		}
+		h.subscribers[symbol][clientID] = client
+		if message, ok := h.last[symbol]; ok {
+			h.sendLocked(clientID, client, message)
+			if _, ok := h.clients[clientID]; !ok {
+				return nil, nil
+			}
+		}
+	}
+	return symbols, nil
+}
+
+// Unsubscribe removes the symbols from the client's subscriptions and returns them normalized
+func (h *PriceHub) Unsubscribe(clientID uint64, symbols []string) []string {
+	symbols = normalizePriceSymbols(symbols)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	client, ok := h.clients[clientID]
+	if !ok {
+		return nil
+	}
+	for _, symbol := range symbols {
+		if client.symbols[symbol] {
+			delete(client.symbols, symbol)
+			h.removeSubscriberLocked(symbol, clientID)
+		}
+	}
+	return symbols
+}
+
+// Send queues an event for the client
+func (h *PriceHub) Send(clientID uint64, event model.PriceStreamEvent) {
+	message, err := json.Marshal(event)
+	if err != nil {
+		logger.Errorf("price stream: failed to encode event: %v", err)
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if client, ok := h.clients[clientID]; ok {
+		h.sendLocked(clientID, client, message)
+	}
+}
+
+// Publish sends the quote to the clients subscribed to its symbol
+func (h *PriceHub) Publish(quote model.PriceQuote) {
+	message, err := json.Marshal(model.PriceStreamEvent{Type: model.PriceEventQuote, Quote: &quote})
+	if err != nil {
+		logger.Errorf("price stream: failed to encode quote: %v", err)
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	subscribers, ok := h.subscribers[quote.Symbol]
+	if !ok {
+		return
+	}
+	h.last[quote.Symbol] = message
+	for clientID, client := range subscribers {
+		h.sendLocked(clientID, client, message)
+	}
+}
+
+// sendLocked queues the message or drops the client when its buffer is full, h.mu must be held
+func (h *PriceHub) sendLocked(clientID uint64, client *priceClient, message []byte) {
+	select {
+	case client.send <- message:
+	default:
+		logger.Infof("price stream: dropping client %d that is not keeping up", clientID)
+		h.dropLocked(clientID)
+	}
+}
+
+// dropLocked removes the client and closes its channel, h.mu must be held
+func (h *PriceHub) dropLocked(clientID uint64) {
+	client, ok := h.clients[clientID]
+	if !ok {
+		return
+	}
+	for symbol := range client.symbols {
+		h.removeSubscriberLocked(symbol, clientID)
+	}
+	delete(h.clients, clientID)
+	close(client.send)
+}
+
+// removeSubscriberLocked unsubscribes upstream once the symbol has no subscriber left, h.mu must be held
+func (h *PriceHub) removeSubscriberLocked(symbol string, clientID uint64) {
+	delete(h.subscribers[symbol], clientID)
+	if len(h.subscribers[symbol]) > 0 {
+		return
+	}
+	delete(h.subscribers, symbol)
+	delete(h.last, symbol)
+	if err := h.source.Unsubscribe(symbol); err != nil {
+		logger.Errorf("price stream: failed to unsubscribe from %s: %v", symbol, err)
+	}
+}
+
+// normalizePriceSymbols upper-cases and dedupes the symbols and prefixes the default exchange when there is none
+func normalizePriceSymbols(symbols []string) []string {
+	seen := make(map[string]bool, len(symbols))
+	result := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		symbol = strings.ToUpper(strings.TrimSpace(symbol))
+		if symbol == "" {
+			continue
+		}
+		if !strings.Contains(symbol, ":") {
+			symbol = defaultPriceStreamExchange + ":" + symbol
+		}
+		if !seen[symbol] {
+			seen[symbol] = true
+			result = append(result, symbol)
+		}
+	}
+	return result
+}
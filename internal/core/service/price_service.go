@@ -1,53 +1,27 @@
 package service
 
 import (
-	"slices"
-	"strings"
-
-	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
 )
 
 type PriceService struct {
+	prices port.PriceCache
 }
 
-func NewPriceService() *PriceService {
-	return &PriceService{}
+func NewPriceService(prices port.PriceCache) *PriceService {
+	return &PriceService{prices: prices}
 }
 
-func (s *PriceService) GetPriceChange(symbols []string, scannerData []model.ScannerData) map[string]interface{} {
-	// Create a map to store price changes
-	priceChangeResults := make(map[string]interface{})
-	foundList := make([]string, 0)
-	// Process each requested symbol
+// GetPriceChange returns the 24 hour change of each symbol as a decimal, nil when the symbol has no price
+func (s *PriceService) GetPriceChange(symbols []string) map[string]*float64 {
+	prices := s.prices.GetPrices(symbols)
+	priceChangeResults := make(map[string]*float64, len(symbols))
 	for _, symbol := range symbols {
-		// Look for the symbol in scanner data
-
-		found := false
-		for _, item := range scannerData {
-			// Extract symbol from CRYPTO:XXUSD format
-			scannerSymbol := item.D[0].(string)
-
-			if slices.Contains(foundList, scannerSymbol) {
-				continue
-			}
-
-			if strings.EqualFold(scannerSymbol, symbol) {
-				if priceChange, ok := item.D[3].(float64); ok {
-					priceChangeResults[symbol] = priceChange / 100 // Convert percentage to decimal
-				} else {
-					priceChangeResults[symbol] = nil
-				}
-				foundList = append(foundList, symbol)
-				found = true
-				break
-			}
-		}
-
-		// If symbol not found in scanner data
-		if !found {
-			priceChangeResults[symbol] = nil
+		priceChangeResults[symbol] = nil
+		if price, ok := prices[symbol]; ok && price.Change24h != nil {
+			priceChange := *price.Change24h / 100 // Convert percentage to decimal
+			priceChangeResults[symbol] = &priceChange
 		}
 	}
-
 	return priceChangeResults
 }
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/cache"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
+)
+
+const (
+	defaultPromptComparisonDays    = 30
+	maxPromptComparisonDays        = 90
+	defaultPromptComparisonHorizon = 24
+	maxPromptComparisonHorizon     = 720
+	promptComparisonTF             = "1h"
+	promptComparisonCacheTTL       = 15 * time.Minute
+)
+
+type PromptComparisonService struct {
+	repo      port.PromptComparisonRepo
+	ohlcvRepo port.TimescaleRepo
+	cache     *cache.TTLCache[model.PromptComparison]
+}
+
+// NewPromptComparisonService takes a nil ohlcvRepo when Timescale is not connected, comparisons then skip hit rates
+func NewPromptComparisonService(repo port.PromptComparisonRepo, ohlcvRepo port.TimescaleRepo) *PromptComparisonService {
+	return &PromptComparisonService{
+		repo:      repo,
+		ohlcvRepo: ohlcvRepo,
+		cache:     cache.NewTTLCache[model.PromptComparison](promptComparisonCacheTTL),
+	}
+}
+
+func (s *PromptComparisonService) GetPromptVersions(ctx context.Context, since time.Time) ([]model.PromptVersionStats, error) {
+	return s.repo.GetPromptVersions(ctx, since)
+}
+
+// SetActivePromptVersion switches feeds, winrate and NAVs to the signals of the version. Tweets it did not extract
+// keep the signals of the version that extracted them last.
+func (s *PromptComparisonService) SetActivePromptVersion(ctx context.Context, promptVersion, updatedBy string) error {
+	promptVersion = strings.TrimSpace(promptVersion)
+	if promptVersion == "" {
+		return model.ErrPromptVersionRequired
+	}
+	return s.repo.SetActivePromptVersion(ctx, promptVersion, updatedBy)
+}
+
+// ComparePromptVersions compares two prompt versions on the tweets both extracted. A signal is a tweet and canonical
+// ticker: the versions agree when both found it with the same action, disagree on direction when the actions differ,
+// and a ticker found by one version only counts against the agreement rate too. Hit rates evaluate the LONG/SHORT
+// signals of each version against OHLCV over the horizon, entering at the open of the first candle after the tweet.
+func (s *PromptComparisonService) ComparePromptVersions(ctx context.Context, req model.PromptComparisonRequest) (model.PromptComparison, error) {
+	if err := normalizePromptComparisonRequest(&req); err != nil {
+		return model.PromptComparison{}, err
+	}
+	cacheKey, err := json.Marshal(req)
+	if err != nil {
+		return model.PromptComparison{}, err
+	}
+	if cached, ok := s.cache.Get(string(cacheKey)); ok {
+		return cached, nil
+	}
+
+	signals, sharedTweets, err := s.repo.GetPromptVersionSignals(ctx, req.VersionA, req.VersionB, req.FromDate, req.ToDate)
+	if err != nil {
+		return model.PromptComparison{}, err
+	}
+
+	type signalKey struct{ tweetID, ticker string }
+	actions := make(map[signalKey][2]string)
+	var keys []signalKey
+	for _, signal := range signals {
+		key := signalKey{signal.TweetID, signal.Ticker}
+		pair, ok := actions[key]
+		if !ok {
+			keys = append(keys, key)
+		}
+		if signal.PromptVersion == req.VersionA {
+			pair[0] = signal.Action
+		} else {
+			pair[1] = signal.Action
+		}
+		actions[key] = pair
+	}
+
+	comparison := model.PromptComparison{
+		VersionA:     req.VersionA,
+		VersionB:     req.VersionB,
+		FromDate:     req.FromDate,
+		ToDate:       req.ToDate,
+		HorizonHours: req.HorizonHours,
+		SharedTweets: sharedTweets,
+		Tickers:      []model.PromptTickerComparison{},
+		HitRates:     []model.PromptHitRate{},
+		ComputedAt:   time.Now().UTC(),
+	}
+	byTicker := make(map[string]*model.PromptTickerComparison)
+	for _, key := range keys {
+		t, ok := byTicker[key.ticker]
+		if !ok {
+			t = &model.PromptTickerComparison{Ticker: key.ticker}
+			byTicker[key.ticker] = t
+		}
+		pair := actions[key]
+		switch {
+		case pair[0] == "":
+			t.OnlyB++
+			comparison.OnlyB++
+		case pair[1] == "":
+			t.OnlyA++
+			comparison.OnlyA++
+		case pair[0] == pair[1]:
+			t.Agreements++
+			comparison.Agreements++
+		default:
+			t.DirectionDisagreements++
+			comparison.DirectionDisagreements++
+		}
+	}
+	comparison.ComparedSignals = len(keys)
+	if comparison.ComparedSignals > 0 {
+		comparison.AgreementRate = float64(comparison.Agreements) / float64(comparison.ComparedSignals)
+	}
+	for _, t := range byTicker {
+		t.Disagreements = t.DirectionDisagreements + t.OnlyA + t.OnlyB
+		t.AgreementRate = float64(t.Agreements) / float64(t.Agreements+t.Disagreements)
+		comparison.Tickers = append(comparison.Tickers, *t)
+	}
+	sort.Slice(comparison.Tickers, func(i, j int) bool {
+		if comparison.Tickers[i].Disagreements != comparison.Tickers[j].Disagreements {
+			return comparison.Tickers[i].Disagreements > comparison.Tickers[j].Disagreements
+		}
+		return comparison.Tickers[i].Ticker < comparison.Tickers[j].Ticker
+	})
+
+	if s.ohlcvRepo != nil {
+		comparison.HitRates = s.hitRates(signals, req)
+	}
+
+	s.cache.Set(string(cacheKey), comparison)
+	return comparison, nil
+}
+
+func (s *PromptComparisonService) hitRates(signals []model.PromptVersionSignal, req model.PromptComparisonRequest) []model.PromptHitRate {
+	horizon := time.Duration(req.HorizonHours) * time.Hour
+	candlesByTicker := make(map[string][]model.OHLCVData)
+	rates := map[string]*model.PromptHitRate{
+		req.VersionA: {PromptVersion: req.VersionA},
+		req.VersionB: {PromptVersion: req.VersionB},
+	}
+	returnSums := make(map[string]float64)
+
+	for _, signal := range signals {
+		var direction float64
+		switch model.SignalActionType(signal.Action) {
+		case model.SignalActionLong:
+			direction = 1
+		case model.SignalActionShort:
+			direction = -1
+		default:
+			continue
+		}
+		rate := rates[signal.PromptVersion]
+		rate.Signals++
+
+		candles, ok := candlesByTicker[signal.Ticker]
+		if !ok {
+			// Binance tables store USDT quoted pairs
+			end := req.ToDate.Add(horizon)
+			loaded, err := s.ohlcvRepo.GetCryptoOHLCV(model.OHLCVRequest{
+				Ticker:    signal.Ticker + "USDT",
+				TimeFrame: promptComparisonTF,
+				StartDate: req.FromDate,
+				EndDate:   &end,
+			})
+			if err != nil {
+				// Tickers without an OHLCV table are left unevaluated
+				logger.Warnf("prompt comparison: no OHLCV for %s: %v", signal.Ticker, err)
+			}
+			candles = loaded
+			candlesByTicker[signal.Ticker] = candles
+		}
+
+		forwardReturn, ok := forwardReturnPct(candles, signal.TweetCreatedAt, horizon)
+		if !ok {
+			continue
+		}
+		rate.Evaluated++
+		returnSums[signal.PromptVersion] += direction * forwardReturn
+		if direction*forwardReturn > 0 {
+			rate.Hits++
+		}
+	}
+
+	result := make([]model.PromptHitRate, 0, 2)
+	for _, version := range []string{req.VersionA, req.VersionB} {
+		rate := rates[version]
+		if rate.Evaluated > 0 {
+			rate.HitRate = float64(rate.Hits) / float64(rate.Evaluated)
+			rate.AvgReturnPct = returnSums[version] / float64(rate.Evaluated)
+		}
+		result = append(result, *rate)
+	}
+	return result
+}
+
+// forwardReturnPct enters at the open of the first candle at or after at and exits at the close of the last candle
+// within the horizon, like evaluateSignal. Signals whose horizon is not fully covered by OHLCV are not evaluated.
+func forwardReturnPct(candles []model.OHLCVData, at time.Time, horizon time.Duration) (float64, bool) {
+	entryIdx := sort.Search(len(candles), func(i int) bool { return !candles[i].Time.Before(at) })
+	if entryIdx == len(candles) || candles[entryIdx].Open <= 0 {
+		return 0, false
+	}
+	end := candles[entryIdx].Time.Add(horizon)
+	if candles[len(candles)-1].Time.Before(end) {
+		return 0, false
+	}
+	exitIdx := sort.Search(len(candles), func(i int) bool { return candles[i].Time.After(end) }) - 1
+	return (candles[exitIdx].Close/candles[entryIdx].Open - 1) * 100, true
+}
+
+func normalizePromptComparisonRequest(req *model.PromptComparisonRequest) error {
+	req.VersionA = strings.TrimSpace(req.VersionA)
+	req.VersionB = strings.TrimSpace(req.VersionB)
+	if req.VersionA == "" || req.VersionB == "" || req.VersionA == req.VersionB {
+		return model.ErrPromptVersionsRequired
+	}
+	if req.HorizonHours == 0 {
+		req.HorizonHours = defaultPromptComparisonHorizon
+	}
+	if req.HorizonHours < 1 || req.HorizonHours > maxPromptComparisonHorizon {
+		return model.ErrInvalidPromptComparisonHorizon
+	}
+	if req.ToDate.IsZero() {
+		req.ToDate = time.Now().UTC().Truncate(time.Hour)
+	}
+	if req.FromDate.IsZero() {
+		req.FromDate = req.ToDate.AddDate(0, 0, -defaultPromptComparisonDays)
+	}
+	if !req.FromDate.Before(req.ToDate) || req.ToDate.Sub(req.FromDate) > maxPromptComparisonDays*24*time.Hour {
+		return model.ErrInvalidPromptComparisonRange
+	}
+	return nil
+}
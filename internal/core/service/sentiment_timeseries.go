@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
+
+const (
+	maxSentimentTimeSeriesTickers = 20
+	maxSentimentTimeSeriesBuckets = 5000
+)
+
+var sentimentBucketDurations = map[string]time.Duration{
+	"1h": time.Hour,
+	"4h": 4 * time.Hour,
+	"1d": 24 * time.Hour,
+}
+
+// GetSentimentTimeSeries returns one series per requested ticker with a point for every bucket in [From, To),
+// including buckets without mentions, so charts of different tickers share the same time axis.
+func (s *TwitterCryptoService) GetSentimentTimeSeries(ctx context.Context, q model.SentimentTimeSeriesQuery) ([]model.TickerSentimentSeries, error) {
+	bucket, ok := sentimentBucketDurations[q.Bucket]
+	if !ok {
+		return nil, model.ErrInvalidSentimentBucket
+	}
+
+	q.Tickers = resolveTickers(s.tickers, q.Tickers)
+	if len(q.Tickers) == 0 {
+		return nil, model.ErrSentimentTickersRequired
+	}
+	if len(q.Tickers) > maxSentimentTimeSeriesTickers {
+		return nil, model.ErrSentimentTooManyTickers
+	}
+
+	// Buckets are aligned to UTC multiples of the bucket size, like the bucket_start computed by the repo
+	q.From = q.From.UTC().Truncate(bucket)
+	q.To = q.To.UTC()
+	if !q.From.Before(q.To) {
+		return nil, model.ErrSentimentInvalidRange
+	}
+	if q.To.Sub(q.From)/bucket > maxSentimentTimeSeriesBuckets {
+		return nil, model.ErrSentimentRangeTooLarge
+	}
+
+	counts, err := s.repo.GetSentimentBuckets(ctx, q, bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	countsByTicker := make(map[string]map[int64]model.SentimentBucketCount, len(q.Tickers))
+	for _, count := range counts {
+		if countsByTicker[count.Ticker] == nil {
+			countsByTicker[count.Ticker] = make(map[int64]model.SentimentBucketCount)
+		}
+		countsByTicker[count.Ticker][count.BucketStart.Unix()] = count
+	}
+
+	series := make([]model.TickerSentimentSeries, 0, len(q.Tickers))
+	for _, ticker := range q.Tickers {
+		tickerSeries := model.TickerSentimentSeries{Ticker: ticker, Bucket: q.Bucket, Points: []model.SentimentPoint{}}
+		for t := q.From; t.Before(q.To); t = t.Add(bucket) {
+			count := countsByTicker[ticker][t.Unix()]
+			point := model.SentimentPoint{
+				Time:     t,
+				Positive: count.Positive,
+				Neutral:  count.Neutral,
+				Negative: count.Negative,
+				Mentions: count.Positive + count.Neutral + count.Negative,
+			}
+			if point.Mentions > 0 {
+				point.NetSentiment = float64(point.Positive-point.Negative) / float64(point.Mentions)
+			}
+			tickerSeries.Mentions += point.Mentions
+			tickerSeries.Points = append(tickerSeries.Points, point)
+		}
+		series = append(series, tickerSeries)
+	}
+	return series, nil
+}
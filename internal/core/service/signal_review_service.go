@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+)
+
+const (
+	defaultSignalReviewLimit = 20
+	maxSignalReviewLimit     = 100
+)
+
+var signalFlagReasons = map[string]bool{
+	model.SignalFlagBadTicker:      true,
+	model.SignalFlagWrongDirection: true,
+	model.SignalFlagNotACall:       true,
+}
+
+type SignalReviewService struct {
+	repo    port.SignalReviewRepo
+	tickers port.TickerResolver
+}
+
+func NewSignalReviewService(repo port.SignalReviewRepo, tickers port.TickerResolver) *SignalReviewService {
+	return &SignalReviewService{
+		repo:    repo,
+		tickers: tickers,
+	}
+}
+
+func (s *SignalReviewService) FlagSignal(ctx context.Context, uid string, req model.SignalFlagRequest) error {
+	req.TweetID = strings.TrimSpace(req.TweetID)
+	req.Ticker = strings.TrimSpace(req.Ticker)
+	req.PromptVersion = strings.TrimSpace(req.PromptVersion)
+	req.Comment = strings.TrimSpace(req.Comment)
+	if req.TweetID == "" || req.Ticker == "" {
+		return model.ErrSignalKeyRequired
+	}
+	if !signalFlagReasons[req.Reason] {
+		return model.ErrInvalidSignalFlagReason
+	}
+	return s.repo.FlagSignal(ctx, uid, req)
+}
+
+// GetSignalReviews returns the review queue, pending signals by default. Status "all" lists every reviewed or
+// flagged signal.
+func (s *SignalReviewService) GetSignalReviews(ctx context.Context, query model.SignalReviewQuery) (model.SignalReviewPage, error) {
+	switch query.Status {
+	case "":
+		query.Status = model.SignalReviewStatusPending
+	case "all":
+		query.Status = ""
+	case model.SignalReviewStatusPending, model.SignalReviewStatusCorrected, model.SignalReviewStatusVoided, model.SignalReviewStatusConfirmed:
+	default:
+		return model.SignalReviewPage{}, model.ErrInvalidSignalReviewStatus
+	}
+	if query.Reason != "" && !signalFlagReasons[query.Reason] {
+		return model.SignalReviewPage{}, model.ErrInvalidSignalFlagReason
+	}
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.Limit <= 0 {
+		query.Limit = defaultSignalReviewLimit
+	}
+	if query.Limit > maxSignalReviewLimit {
+		query.Limit = maxSignalReviewLimit
+	}
+
+	items, total, err := s.repo.GetSignalReviews(ctx, query)
+	if err != nil {
+		return model.SignalReviewPage{}, err
+	}
+	return model.SignalReviewPage{
+		Items: items,
+		Total: total,
+		Page:  query.Page,
+		Limit: query.Limit,
+	}, nil
+}
+
+// CorrectSignal overrides the extraction with the reviewer's ticker, action or sentiment. The corrected ticker is
+// stored as the canonical pair ingestion stores (BTCUSDT), so readers joining candles see the same form either way.
+func (s *SignalReviewService) CorrectSignal(ctx context.Context, req model.SignalCorrectionRequest, reviewer string) error {
+	decision := model.SignalReviewDecision{
+		TweetID:       strings.TrimSpace(req.TweetID),
+		Ticker:        strings.TrimSpace(req.Ticker),
+		PromptVersion: strings.TrimSpace(req.PromptVersion),
+		Status:        model.SignalReviewStatusCorrected,
+		Note:          strings.TrimSpace(req.Note),
+		ReviewedBy:    reviewer,
+	}
+	if decision.TweetID == "" || decision.Ticker == "" {
+		return model.ErrSignalKeyRequired
+	}
+
+	if ticker := signalPairTicker(s.tickers.Resolve(req.CorrectedTicker)); ticker != "" {
+		decision.CorrectedTicker = &ticker
+	}
+	if action := strings.ToUpper(strings.TrimSpace(req.CorrectedAction)); action != "" {
+		switch model.SignalActionType(action) {
+		case model.SignalActionLong, model.SignalActionShort, model.SignalActionNatural:
+			decision.CorrectedAction = &action
+		default:
+			return model.ErrInvalidSignalCorrection
+		}
+	}
+	if sentiment := strings.TrimSpace(req.CorrectedSentiment); sentiment != "" {
+		if !signalSentiments[sentiment] {
+			return model.ErrInvalidSignalCorrection
+		}
+		decision.CorrectedSentiment = &sentiment
+	}
+	if decision.CorrectedTicker == nil && decision.CorrectedAction == nil && decision.CorrectedSentiment == nil {
+		return model.ErrInvalidSignalCorrection
+	}
+	return s.repo.ReviewSignal(ctx, decision)
+}
+
+// VoidSignal drops the signal from winrate and NAV, for tweets that are not a call
+func (s *SignalReviewService) VoidSignal(ctx context.Context, req model.SignalDecisionRequest, reviewer string) error {
+	return s.decide(ctx, req, model.SignalReviewStatusVoided, reviewer)
+}
+
+// ConfirmSignal keeps the extraction as is and closes the flags on it
+func (s *SignalReviewService) ConfirmSignal(ctx context.Context, req model.SignalDecisionRequest, reviewer string) error {
+	return s.decide(ctx, req, model.SignalReviewStatusConfirmed, reviewer)
+}
+
+func (s *SignalReviewService) decide(ctx context.Context, req model.SignalDecisionRequest, status, reviewer string) error {
+	decision := model.SignalReviewDecision{
+		TweetID:       strings.TrimSpace(req.TweetID),
+		Ticker:        strings.TrimSpace(req.Ticker),
+		PromptVersion: strings.TrimSpace(req.PromptVersion),
+		Status:        status,
+		Note:          strings.TrimSpace(req.Note),
+		ReviewedBy:    reviewer,
+	}
+	if decision.TweetID == "" || decision.Ticker == "" {
+		return model.ErrSignalKeyRequired
+	}
+	return s.repo.ReviewSignal(ctx, decision)
+}
+
+func (s *SignalReviewService) GetSignalReviewAudit(ctx context.Context, tweetID, ticker string) ([]model.SignalReviewAudit, error) {
+	tweetID = strings.TrimSpace(tweetID)
+	if tweetID == "" {
+		return nil, model.ErrSignalKeyRequired
+	}
+	return s.repo.GetSignalReviewAudit(ctx, tweetID, strings.TrimSpace(ticker))
+}
+
+func (s *SignalReviewService) GetSignalTrainingExamples(ctx context.Context, from, to time.Time, promptVersion string) ([]model.SignalTrainingExample, error) {
+	return s.repo.GetSignalTrainingExamples(ctx, from, to, strings.TrimSpace(promptVersion))
+}
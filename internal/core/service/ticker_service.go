@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/quantsmithapp/datastation-backend/internal/constant"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
+)
+
+const tickerReloadInterval = 5 * time.Minute
+
+var (
+	assetIDPattern       = regexp.MustCompile(`^[A-Z0-9]{1,32}$`)
+	tickerExchangePrefix = regexp.MustCompile(`^[A-Z0-9]+:`)
+	tickerQuoteSuffix    = regexp.MustCompile(`^(.{2,}?)[-_/]?(USDT|USDC|BUSD|USD|PERP)$`)
+)
+
+// tickerIndex is the in-memory copy of crypto_asset and crypto_asset_alias the resolver reads
+type tickerIndex struct {
+	assets  map[string]model.CryptoAsset
+	aliases map[string]string // alias -> asset ID
+}
+
+type TickerService struct {
+	repo port.TickerRepo
+
+	mu    sync.RWMutex
+	index tickerIndex
+}
+
+func NewTickerService(repo port.TickerRepo) *TickerService {
+	return &TickerService{
+		repo: repo,
+		index: tickerIndex{
+			assets:  map[string]model.CryptoAsset{},
+			aliases: map[string]string{},
+		},
+	}
+}
+
+// Resolve returns the canonical asset ID of a cashtag, name, pair or exchange symbol, or the cleaned ticker when no
+// asset matches. It follows the canonical_ticker SQL function so Go and SQL agree on every ticker.
+func (s *TickerService) Resolve(ticker string) string {
+	return s.ResolveTicker(ticker).AssetID
+}
+
+// ResolveTicker resolves like Resolve and reports whether the ticker is a known asset. Assets missing from the table
+// fall back to the static CRYPTO_TICKERS list for their name.
+func (s *TickerService) ResolveTicker(ticker string) model.ResolvedTicker {
+	index := s.currentIndex()
+	symbol := cleanTicker(ticker)
+	resolved := model.ResolvedTicker{Input: ticker, AssetID: index.resolve(symbol)}
+
+	if asset, ok := index.assets[resolved.AssetID]; ok {
+		resolved.Name = asset.Name
+		resolved.Known = true
+	} else if name, ok := constant.CRYPTO_TICKERS[strings.ToLower(resolved.AssetID)]; ok && resolved.AssetID != "" {
+		resolved.Name = name
+		resolved.Known = true
+	}
+	return resolved
+}
+
+// GetAssets returns every asset with its aliases, read from the database rather than the resolver cache
+func (s *TickerService) GetAssets(ctx context.Context) ([]model.CryptoAsset, error) {
+	assets, err := s.repo.GetAssets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	aliases, err := s.repo.GetAliases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byAsset := make(map[string][]model.TickerAlias, len(assets))
+	for _, alias := range aliases {
+		byAsset[alias.AssetID] = append(byAsset[alias.AssetID], alias)
+	}
+	for i := range assets {
+		assets[i].Aliases = byAsset[assets[i].ID]
+		if assets[i].Aliases == nil {
+			assets[i].Aliases = []model.TickerAlias{}
+		}
+	}
+	return assets, nil
+}
+
+func (s *TickerService) UpsertAsset(ctx context.Context, req model.CryptoAssetRequest) error {
+	req.ID = strings.ToUpper(strings.TrimSpace(req.ID))
+	req.Name = strings.TrimSpace(req.Name)
+	if !assetIDPattern.MatchString(req.ID) {
+		return model.ErrInvalidAssetID
+	}
+	if err := s.repo.UpsertAsset(ctx, req); err != nil {
+		return err
+	}
+	return s.Reload(ctx)
+}
+
+func (s *TickerService) DeleteAsset(ctx context.Context, id string) error {
+	if err := s.repo.DeleteAsset(ctx, strings.ToUpper(strings.TrimSpace(id))); err != nil {
+		return err
+	}
+	return s.Reload(ctx)
+}
+
+// UpsertAlias stores the alias cleaned the way tickers are cleaned before lookup, so "$xbt" is stored as XBT
+func (s *TickerService) UpsertAlias(ctx context.Context, req model.TickerAliasRequest) error {
+	req.Alias = cleanTicker(req.Alias)
+	req.AssetID = strings.ToUpper(strings.TrimSpace(req.AssetID))
+	req.Kind = strings.ToLower(strings.TrimSpace(req.Kind))
+	req.Exchange = strings.ToLower(strings.TrimSpace(req.Exchange))
+	if req.Alias == "" || len(req.Alias) > 64 {
+		return model.ErrInvalidTickerAlias
+	}
+	if !assetIDPattern.MatchString(req.AssetID) {
+		return model.ErrInvalidAssetID
+	}
+	if req.Kind == "" {
+		req.Kind = model.TickerAliasKindSymbol
+	}
+	switch req.Kind {
+	case model.TickerAliasKindSymbol, model.TickerAliasKindName, model.TickerAliasKindPair, model.TickerAliasKindExchange:
+	default:
+		return model.ErrInvalidTickerAliasKind
+	}
+
+	if err := s.repo.UpsertAlias(ctx, req); err != nil {
+		return err
+	}
+	return s.Reload(ctx)
+}
+
+func (s *TickerService) DeleteAlias(ctx context.Context, alias string) error {
+	if err := s.repo.DeleteAlias(ctx, cleanTicker(alias)); err != nil {
+		return err
+	}
+	return s.Reload(ctx)
+}
+
+// Start reloads the resolver cache every tickerReloadInterval until ctx is done. Call Reload once before so the first
+// requests resolve against the tables, a failed reload keeps serving the previous index.
+func (s *TickerService) Start(ctx context.Context) {
+	ticker := time.NewTicker(tickerReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := s.Reload(ctx); err != nil {
+			logger.Errorf("ticker: failed to reload assets and aliases: %v", err)
+		}
+	}
+}
+
+// Reload replaces the resolver cache with the current assets and aliases
+func (s *TickerService) Reload(ctx context.Context) error {
+	assets, err := s.repo.GetAssets(ctx)
+	if err != nil {
+		return err
+	}
+	aliases, err := s.repo.GetAliases(ctx)
+	if err != nil {
+		return err
+	}
+
+	index := tickerIndex{
+		assets:  make(map[string]model.CryptoAsset, len(assets)),
+		aliases: make(map[string]string, len(aliases)),
+	}
+	for _, asset := range assets {
+		index.assets[asset.ID] = asset
+	}
+	for _, alias := range aliases {
+		index.aliases[alias.Alias] = alias.AssetID
+	}
+
+	s.mu.Lock()
+	s.index = index
+	s.mu.Unlock()
+	return nil
+}
+
+// currentIndex returns the cached index, Start keeps it fresh so requests never wait for the database
+func (s *TickerService) currentIndex() tickerIndex {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.index
+}
+
+// resolve returns the asset of a cleaned symbol. The whole symbol is looked up first so quote-like assets (FDUSD,
+// PYUSD) are not read as pairs, then one quote suffix is stripped (BTCUSDT, BTC-PERP) and the base looked up. A
+// symbol the static CRYPTO_TICKERS list knows is kept whole unless its base is a known asset, so an unseeded
+// stablecoin does not turn into a two-letter ticker while BTCUSD still resolves to BTC.
+func (index tickerIndex) resolve(symbol string) string {
+	if id, ok := index.lookup(symbol); ok {
+		return id
+	}
+	base := tickerQuoteSuffix.ReplaceAllString(symbol, "$1")
+	if base == symbol {
+		return symbol
+	}
+	if id, ok := index.lookup(base); ok {
+		return id
+	}
+	if _, ok := constant.CRYPTO_TICKERS[strings.ToLower(symbol)]; ok {
+		return symbol
+	}
+	return base
+}
+
+func (index tickerIndex) lookup(symbol string) (string, bool) {
+	if id, ok := index.aliases[symbol]; ok {
+		return id, true
+	}
+	if _, ok := index.assets[symbol]; ok {
+		return symbol, true
+	}
+	return "", false
+}
+
+// cleanTicker upper-cases the ticker and strips cashtag and list punctuation and an exchange prefix (BINANCE:)
+func cleanTicker(ticker string) string {
+	ticker = strings.ToUpper(strings.Trim(ticker, " $[]'\""))
+	return tickerExchangePrefix.ReplaceAllString(ticker, "")
+}
+
+// resolveTickers resolves the tickers, keeping the first occurrence of each asset
+func resolveTickers(resolver port.TickerResolver, tickers []string) []string {
+	seen := make(map[string]bool, len(tickers))
+	resolved := make([]string, 0, len(tickers))
+	for _, ticker := range tickers {
+		ticker = resolver.Resolve(ticker)
+		if ticker == "" || seen[ticker] {
+			continue
+		}
+		seen[ticker] = true
+		resolved = append(resolved, ticker)
+	}
+	return resolved
+}
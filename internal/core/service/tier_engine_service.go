@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/quantsmithapp/datastation-backend/config"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
+)
+
+const (
+	defaultTierEngineIntervalMinutes = 1440
+	defaultTierEngineWindowDays      = 30
+)
+
+var errInvalidTierWinratePeriod = errors.New("invalid winrate period")
+
+// tierWinrateHorizons are the horizons in hours of each winrate period
+var tierWinrateHorizons = map[string][]int{
+	"1d":  {24},
+	"3d":  {72},
+	"7d":  {168},
+	"15d": {360},
+	"30d": {720},
+}
+
+// The "overall" period blends the horizons with the weights of twitter_crypto_backtesting
+var (
+	tierOverallHorizons = []int{24, 72, 168, 360, 720}
+	tierOverallWeights  = []float64{0.35, 0.3, 0.2, 0.1, 0.05}
+)
+
+type TierEngineService struct {
+	repo              port.TierEngineRepo
+	authorTierService port.AuthorTierService
+	winRateService    port.WinRateService
+	config            config.TierEngineConfig
+}
+
+func NewTierEngineService(
+	repo port.TierEngineRepo,
+	authorTierService port.AuthorTierService,
+	winRateService port.WinRateService,
+	cfg config.TierEngineConfig,
+) *TierEngineService {
+	if cfg.IntervalMinutes <= 0 {
+		cfg.IntervalMinutes = defaultTierEngineIntervalMinutes
+	}
+	if cfg.WindowDays <= 0 {
+		cfg.WindowDays = defaultTierEngineWindowDays
+	}
+	return &TierEngineService{
+		repo:              repo,
+		authorTierService: authorTierService,
+		winRateService:    winRateService,
+		config:            cfg,
+	}
+}
+
+// Start recomputes tier proposals on the configured interval until ctx is cancelled
+func (s *TierEngineService) Start(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(s.config.IntervalMinutes) * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.Recompute(ctx); err != nil {
+			logger.Errorf("tier engine: recompute failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Recompute grades every author against the configured rules and stores a proposal for each tier change
+func (s *TierEngineService) Recompute(ctx context.Context) ([]model.AuthorTierProposal, error) {
+	since := time.Now().AddDate(0, 0, -s.config.WindowDays)
+
+	metrics, err := s.repo.GetAuthorTierMetrics(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+	winrates, err := s.rollingWinrates(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+	navMap, err := s.repo.GetAuthorDailyNav(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+
+	proposals := []model.AuthorTierProposal{}
+	for _, m := range metrics {
+		m.Winrate = winrates[m.AuthorUsername]
+		m.MaxDrawdown, m.Consistency = navDrawdownAndConsistency(navMap[m.AuthorUsername])
+		proposedTier := s.evaluateTier(m)
+		if proposedTier == "" || proposedTier == m.CurrentTier {
+			continue
+		}
+		proposals = append(proposals, model.AuthorTierProposal{
+			AuthorUsername: m.AuthorUsername,
+			CurrentTier:    m.CurrentTier,
+			ProposedTier:   proposedTier,
+			Winrate:        m.Winrate,
+			SignalCount:    m.SignalCount,
+			MaxDrawdown:    m.MaxDrawdown,
+			Consistency:    m.Consistency,
+			Status:         model.TierProposalStatusPending,
+		})
+	}
+
+	if err := s.repo.InsertTierProposals(ctx, proposals); err != nil {
+		return nil, err
+	}
+	logger.Infof("tier engine: %d authors evaluated, %d tier changes proposed", len(metrics), len(proposals))
+	return proposals, nil
+}
+
+func (s *TierEngineService) GetProposals(ctx context.Context, status string) ([]model.AuthorTierProposal, error) {
+	return s.repo.GetTierProposals(ctx, status)
+}
+
+// ApproveProposal marks the proposal approved and applies the proposed tier through the author tier service. The
+// approval is only committed once the tier is written, so the proposal stays pending when the tier cannot be applied.
+// When the approval cannot be committed after that, the tier the write replaced is restored, unless the tier was
+// changed again meanwhile.
+func (s *TierEngineService) ApproveProposal(ctx context.Context, id, reviewedBy string) (model.AuthorTierProposal, error) {
+	proposal, err := s.repo.GetTierProposalByID(ctx, id)
+	if err != nil {
+		return proposal, err
+	}
+	if proposal.Status != model.TierProposalStatusPending {
+		return proposal, model.ErrTierProposalNotPending
+	}
+
+	applied := false
+	var previous *string
+	err = s.repo.ReviewTierProposal(ctx, id, model.TierProposalStatusApproved, reviewedBy, func() error {
+		replaced, err := s.authorTierService.ReplaceAuthorTier(ctx, proposal.AuthorUsername, &proposal.ProposedTier)
+		if err != nil {
+			return err
+		}
+		applied, previous = true, replaced
+		return nil
+	})
+	if err != nil {
+		if applied {
+			// The request context may be what failed the commit
+			restored, restoreErr := s.authorTierService.RestoreAuthorTier(
+				context.WithoutCancel(ctx), proposal.AuthorUsername, &proposal.ProposedTier, previous)
+			switch {
+			case restoreErr != nil:
+				logger.Errorf("tier engine: failed to restore the tier of %s after proposal %s failed: %v",
+					proposal.AuthorUsername, id, restoreErr)
+			case !restored:
+				logger.Errorf("tier engine: tier of %s changed while proposal %s failed, it was not restored",
+					proposal.AuthorUsername, id)
+			}
+		}
+		return proposal, err
+	}
+
+	proposal.Status = model.TierProposalStatusApproved
+	proposal.ReviewedBy = &reviewedBy
+	return proposal, nil
+}
+
+func (s *TierEngineService) RejectProposal(ctx context.Context, id, reviewedBy string) (model.AuthorTierProposal, error) {
+	proposal, err := s.repo.GetTierProposalByID(ctx, id)
+	if err != nil {
+		return proposal, err
+	}
+	if proposal.Status != model.TierProposalStatusPending {
+		return proposal, model.ErrTierProposalNotPending
+	}
+
+	if err := s.repo.ReviewTierProposal(ctx, id, model.TierProposalStatusRejected, reviewedBy, nil); err != nil {
+		return proposal, err
+	}
+
+	proposal.Status = model.TierProposalStatusRejected
+	proposal.ReviewedBy = &reviewedBy
+	return proposal, nil
+}
+
+// rollingWinrates evaluates the signals posted since the window start at the configured period and returns the
+// winrate of each author. Authors without an evaluated signal are missing.
+func (s *TierEngineService) rollingWinrates(ctx context.Context, since time.Time) (map[string]float64, error) {
+	req := model.WinrateCalcRequest{
+		FromDate: since.UTC().Truncate(time.Hour),
+		ToDate:   time.Now().UTC().Truncate(time.Hour),
+	}
+	switch period := s.config.WinratePeriod; period {
+	case "", "overall":
+		req.HorizonHours = append([]int(nil), tierOverallHorizons...)
+		req.Weights = append([]float64(nil), tierOverallWeights...)
+	default:
+		horizons, ok := tierWinrateHorizons[period]
+		if !ok {
+			return nil, errInvalidTierWinratePeriod
+		}
+		req.HorizonHours = append([]int(nil), horizons...)
+	}
+
+	result, err := s.winRateService.CalculateWinrate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	winrates := make(map[string]float64, len(result.Authors))
+	for _, author := range result.Authors {
+		winrates[author.AuthorUsername] = author.BlendedWinrate
+	}
+	return winrates, nil
+}
+
+// evaluateTier returns the tier of the first rule the author satisfies, or the default tier
+func (s *TierEngineService) evaluateTier(m model.AuthorTierMetrics) string {
+	for _, rule := range s.config.Rules {
+		if m.Winrate < rule.MinWinrate || m.SignalCount < rule.MinSignals {
+			continue
+		}
+		if rule.MaxDrawdown > 0 && m.MaxDrawdown > rule.MaxDrawdown {
+			continue
+		}
+		if m.Consistency < rule.MinConsistency {
+			continue
+		}
+		return rule.Tier
+	}
+	return s.config.DefaultTier
+}
+
+// navDrawdownAndConsistency returns the maximum drawdown of the series and the share of days with a non-negative return
+func navDrawdownAndConsistency(navs []model.Nav) (float64, float64) {
+	if len(navs) < 2 {
+		return maxDrawdown(navs), 0
+	}
+
+	positiveDays := 0
+	for i := 1; i < len(navs); i++ {
+		if navs[i].Nav >= navs[i-1].Nav {
+			positiveDays++
+		}
+	}
+	return maxDrawdown(navs), float64(positiveDays) / float64(len(navs)-1)
+}
@@ -0,0 +1,320 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/quantsmithapp/datastation-backend/config"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/cache"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
+)
+
+const (
+	defaultTrendingIntervalMinutes    = 15
+	defaultTrendingWindowHours        = 4
+	defaultTrendingBaselineWindows    = 42
+	defaultTrendingZThreshold         = 2.5
+	defaultTrendingMinMentions        = 5
+	defaultTrendingAlertCooldownHours = 24
+	minTrendingWinrateWeight          = 0.1
+)
+
+var watchlistTickerPattern = regexp.MustCompile(`^[A-Z0-9]{1,32}$`)
+
+type TrendingService struct {
+	repo             port.TrendingRepo
+	notificationRepo port.NotificationRepo
+	tickers          port.TickerResolver
+	telegramService  port.TelegramService
+	config           config.TrendingConfig
+	cache            *cache.TTLCache[model.TrendingResult]
+}
+
+func NewTrendingService(repo port.TrendingRepo, notificationRepo port.NotificationRepo, tickers port.TickerResolver, telegramService port.TelegramService, cfg config.TrendingConfig) *TrendingService {
+	if cfg.IntervalMinutes <= 0 {
+		cfg.IntervalMinutes = defaultTrendingIntervalMinutes
+	}
+	if cfg.WindowHours <= 0 {
+		cfg.WindowHours = defaultTrendingWindowHours
+	}
+	if cfg.BaselineWindows <= 1 {
+		cfg.BaselineWindows = defaultTrendingBaselineWindows
+	}
+	if cfg.ZThreshold <= 0 {
+		cfg.ZThreshold = defaultTrendingZThreshold
+	}
+	if cfg.MinMentions <= 0 {
+		cfg.MinMentions = defaultTrendingMinMentions
+	}
+	if cfg.AlertCooldownHours <= 0 {
+		cfg.AlertCooldownHours = defaultTrendingAlertCooldownHours
+	}
+	return &TrendingService{
+		repo:             repo,
+		notificationRepo: notificationRepo,
+		tickers:          tickers,
+		telegramService:  telegramService,
+		config:           cfg,
+		cache:            cache.NewTTLCache[model.TrendingResult](time.Duration(cfg.IntervalMinutes) * time.Minute),
+	}
+}
+
+// Start detects trending tickers on the configured interval and alerts watchers until ctx is cancelled
+func (s *TrendingService) Start(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(s.config.IntervalMinutes) * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.Evaluate(ctx); err != nil {
+			logger.Errorf("trending: evaluation failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// GetTrending returns the tickers mentioned in the current window ranked by z-score. Results are cached for one
+// evaluation interval per weight.
+func (s *TrendingService) GetTrending(ctx context.Context, weight string, limit int) (model.TrendingResult, error) {
+	if weight == "" {
+		weight = model.TrendingWeightTier
+	}
+	if weight != model.TrendingWeightNone && weight != model.TrendingWeightTier && weight != model.TrendingWeightWinrate {
+		return model.TrendingResult{}, model.ErrInvalidTrendingWeight
+	}
+
+	result, ok := s.cache.Get(weight)
+	if !ok {
+		var err error
+		result, err = s.compute(ctx, weight, time.Now())
+		if err != nil {
+			return model.TrendingResult{}, err
+		}
+		s.cache.Set(weight, result)
+	}
+
+	if limit > 0 && len(result.Tickers) > limit {
+		result.Tickers = result.Tickers[:limit]
+	}
+	return result, nil
+}
+
+// Evaluate recomputes the tier weighted ranking and alerts users watching a ticker that is trending
+func (s *TrendingService) Evaluate(ctx context.Context) (model.TrendingResult, error) {
+	now := time.Now()
+	result, err := s.compute(ctx, model.TrendingWeightTier, now)
+	if err != nil {
+		return model.TrendingResult{}, err
+	}
+	s.cache.Set(model.TrendingWeightTier, result)
+
+	trending := make(map[string]model.TrendingTicker)
+	var tickers []string
+	for _, t := range result.Tickers {
+		if t.IsTrending {
+			trending[t.Ticker] = t
+			tickers = append(tickers, t.Ticker)
+		}
+	}
+	if len(tickers) > 0 {
+		s.alertWatchers(ctx, trending, tickers, now)
+	}
+	return result, nil
+}
+
+func (s *TrendingService) compute(ctx context.Context, weight string, now time.Time) (model.TrendingResult, error) {
+	window := time.Duration(s.config.WindowHours) * time.Hour
+	windows := s.config.BaselineWindows + 1
+
+	mentions, err := s.repo.GetTickerMentionWindows(ctx, now, window, windows)
+	if err != nil {
+		return model.TrendingResult{}, err
+	}
+	authorWeights, err := s.authorWeights(ctx, weight)
+	if err != nil {
+		return model.TrendingResult{}, err
+	}
+
+	type tickerWindows struct {
+		weighted []float64
+		mentions int
+		authors  int
+	}
+	byTicker := make(map[string]*tickerWindows)
+	for _, m := range mentions {
+		if m.WindowIndex < 0 || m.WindowIndex >= windows {
+			continue
+		}
+		tw, ok := byTicker[m.Ticker]
+		if !ok {
+			tw = &tickerWindows{weighted: make([]float64, windows)}
+			byTicker[m.Ticker] = tw
+		}
+		w := 1.0
+		if authorWeights != nil {
+			if aw, ok := authorWeights[m.AuthorUsername]; ok {
+				w = aw
+			}
+		}
+		tw.weighted[m.WindowIndex] += float64(m.Mentions) * w
+		if m.WindowIndex == 0 {
+			tw.mentions += m.Mentions
+			tw.authors++
+		}
+	}
+
+	result := model.TrendingResult{
+		Weight:      weight,
+		WindowHours: s.config.WindowHours,
+		WindowEnd:   now,
+		Tickers:     []model.TrendingTicker{},
+	}
+	for ticker, tw := range byTicker {
+		if tw.mentions == 0 {
+			continue
+		}
+		mean, variance := meanAndVariance(tw.weighted[1:])
+		// Floor the deviation at Poisson noise so quiet tickers with a flat baseline do not explode
+		std := math.Max(math.Sqrt(variance), math.Max(math.Sqrt(mean), 1))
+		z := (tw.weighted[0] - mean) / std
+		result.Tickers = append(result.Tickers, model.TrendingTicker{
+			Ticker:           ticker,
+			Mentions:         tw.mentions,
+			Authors:          tw.authors,
+			WeightedMentions: tw.weighted[0],
+			BaselineMean:     mean,
+			BaselineStd:      std,
+			ZScore:           z,
+			IsTrending:       z >= s.config.ZThreshold && tw.mentions >= s.config.MinMentions,
+		})
+	}
+
+	sort.Slice(result.Tickers, func(i, j int) bool {
+		if result.Tickers[i].ZScore != result.Tickers[j].ZScore {
+			return result.Tickers[i].ZScore > result.Tickers[j].ZScore
+		}
+		return result.Tickers[i].Ticker < result.Tickers[j].Ticker
+	})
+	for i := range result.Tickers {
+		result.Tickers[i].Rank = i + 1
+	}
+	return result, nil
+}
+
+// authorWeights returns the weight of a mention per author, nil when mentions are not weighted. Winrate weights are
+// relative to the average winrate so an average author weighs 1 whatever scale the winrate is stored in.
+func (s *TrendingService) authorWeights(ctx context.Context, weight string) (map[string]float64, error) {
+	if weight == model.TrendingWeightNone {
+		return nil, nil
+	}
+	inputs, err := s.repo.GetAuthorWeightInputs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	weights := make(map[string]float64, len(inputs))
+	switch weight {
+	case model.TrendingWeightTier:
+		for _, input := range inputs {
+			w, ok := s.config.TierWeights[input.AuthorTier]
+			if !ok {
+				w = 1
+			}
+			weights[input.AuthorUsername] = w
+		}
+	case model.TrendingWeightWinrate:
+		var total float64
+		for _, input := range inputs {
+			total += input.Winrate
+		}
+		if total <= 0 {
+			return nil, nil
+		}
+		mean := total / float64(len(inputs))
+		for _, input := range inputs {
+			weights[input.AuthorUsername] = math.Max(input.Winrate/mean, minTrendingWinrateWeight)
+		}
+	}
+	return weights, nil
+}
+
+// alertWatchers sends one Telegram message per user listing the trending tickers on their watchlist
+func (s *TrendingService) alertWatchers(ctx context.Context, trending map[string]model.TrendingTicker, tickers []string, now time.Time) {
+	alertedBefore := now.Add(-time.Duration(s.config.AlertCooldownHours) * time.Hour)
+	subscribers, err := s.repo.GetTrendingAlertSubscribers(ctx, tickers, alertedBefore)
+	if err != nil {
+		logger.Errorf("trending: failed to get alert subscribers: %v", err)
+		return
+	}
+	if s.telegramService == nil {
+		return
+	}
+
+	tickersByUser := make(map[string][]string)
+	for _, sub := range subscribers {
+		tickersByUser[sub.UserUUID] = append(tickersByUser[sub.UserUUID], sub.Ticker)
+	}
+
+	for uid, userTickers := range tickersByUser {
+		telegram, err := s.notificationRepo.GetTelegram(ctx, uid)
+		if err != nil || telegram.ChatID == "" {
+			continue
+		}
+		chatID, err := strconv.ParseInt(telegram.ChatID, 10, 64)
+		if err != nil {
+			logger.Errorf("trending: invalid telegram chat id for user %s: %v", uid, err)
+			continue
+		}
+
+		sort.Strings(userTickers)
+		lines := make([]string, 0, len(userTickers))
+		for _, ticker := range userTickers {
+			t := trending[ticker]
+			lines = append(lines, fmt.Sprintf("%s: %d mentions by %d authors in %dh (z %.1f)", t.Ticker, t.Mentions, t.Authors, s.config.WindowHours, t.ZScore))
+		}
+		if err := s.telegramService.SendMessage(ctx, "🔥 Trending on your watchlist\n"+strings.Join(lines, "\n"), chatID); err != nil {
+			logger.Errorf("trending: failed to notify user %s: %v", uid, err)
+			continue
+		}
+		for _, ticker := range userTickers {
+			if err := s.repo.MarkTrendingAlerted(ctx, uid, ticker, now); err != nil {
+				logger.Errorf("trending: failed to mark %s alerted for user %s: %v", ticker, uid, err)
+			}
+		}
+	}
+}
+
+// HandleSignalEvent drops the cached rankings when a new signal with a ticker is ingested, so the next request counts
+// its mention instead of waiting for the evaluation interval
+func (s *TrendingService) HandleSignalEvent(event model.SignalEvent) {
+	if event.Kind == model.SignalEventSignal && event.Created && len(event.Tickers) > 0 {
+		s.cache.Clear()
+	}
+}
+
+func (s *TrendingService) GetWatchlist(ctx context.Context, uid string) ([]model.WatchlistTicker, error) {
+	return s.repo.GetWatchlist(ctx, uid)
+}
+
+func (s *TrendingService) UpsertWatchlistTicker(ctx context.Context, uid, ticker string, trendingAlert bool) error {
+	ticker = s.tickers.Resolve(ticker)
+	if !watchlistTickerPattern.MatchString(ticker) {
+		return model.ErrInvalidWatchlistTicker
+	}
+	return s.repo.UpsertWatchlistTicker(ctx, uid, ticker, trendingAlert)
+}
+
+func (s *TrendingService) DeleteWatchlistTicker(ctx context.Context, uid, ticker string) error {
+	return s.repo.DeleteWatchlistTicker(ctx, uid, s.tickers.Resolve(ticker))
+}
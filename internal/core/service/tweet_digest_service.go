@@ -0,0 +1,386 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/quantsmithapp/datastation-backend/config"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
+)
+
+const (
+	defaultDigestIntervalMinutes     = 60
+	defaultDigestTopLimit            = 10
+	defaultDigestNewCallLookbackDays = 30
+	// minDigestShiftSignals is the LONG/SHORT signals a ticker needs in both periods to report a sentiment shift
+	minDigestShiftSignals = 3
+	// digestMessageItems caps each section of a delivered digest, the stored digest keeps TopLimit items
+	digestMessageItems  = 5
+	maxDigestMessageLen = 4000
+	defaultDigestList   = 10
+	maxDigestList       = 60
+)
+
+type TweetDigestService struct {
+	repo            port.TweetDigestRepo
+	telegramService port.TelegramService
+	emailService    port.EmailService
+	config          config.DigestConfig
+}
+
+// NewTweetDigestService takes a nil telegramService or emailService when that channel is unavailable, subscriptions on
+// it are then not delivered
+func NewTweetDigestService(repo port.TweetDigestRepo, telegramService port.TelegramService, emailService port.EmailService, cfg config.DigestConfig) *TweetDigestService {
+	if cfg.IntervalMinutes <= 0 {
+		cfg.IntervalMinutes = defaultDigestIntervalMinutes
+	}
+	if cfg.TopLimit <= 0 {
+		cfg.TopLimit = defaultDigestTopLimit
+	}
+	if cfg.NewCallLookbackDays <= 0 {
+		cfg.NewCallLookbackDays = defaultDigestNewCallLookbackDays
+	}
+	return &TweetDigestService{
+		repo:            repo,
+		telegramService: telegramService,
+		emailService:    emailService,
+		config:          cfg,
+	}
+}
+
+// Start builds the digest of each period once it has ended and delivers it to subscribers, checking on the configured
+// interval until ctx is cancelled. Digests already stored are not rebuilt, so restarts do not resend them.
+func (s *TweetDigestService) Start(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(s.config.IntervalMinutes) * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		for _, period := range []string{model.DigestPeriodDaily, model.DigestPeriodWeekly} {
+			if err := s.rollup(ctx, period, time.Now().UTC()); err != nil {
+				logger.Errorf("tweet digest: %s rollup failed: %v", period, err)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *TweetDigestService) rollup(ctx context.Context, period string, now time.Time) error {
+	start := lastEndedDigestPeriod(period, now)
+	digest, err := s.repo.GetDigest(ctx, period, &start)
+	if errors.Is(err, model.ErrDigestNotFound) {
+		digest, err = s.build(ctx, period, start)
+	}
+	if err != nil {
+		return err
+	}
+	s.deliver(ctx, digest)
+	return nil
+}
+
+// BuildDigest builds and stores the digest of a period, replacing the stored one. Subscribers that already received
+// the period are not sent it again.
+func (s *TweetDigestService) BuildDigest(ctx context.Context, req model.BuildDigestRequest) (model.TweetDigest, error) {
+	if !validDigestPeriod(req.Period) {
+		return model.TweetDigest{}, model.ErrInvalidDigestPeriod
+	}
+	now := time.Now().UTC()
+	start := lastEndedDigestPeriod(req.Period, now)
+	if req.PeriodStart != nil {
+		start = req.PeriodStart.UTC()
+		if !start.Equal(digestPeriodStart(req.Period, start)) || nextDigestPeriod(req.Period, start).After(now) {
+			return model.TweetDigest{}, model.ErrDigestNotComplete
+		}
+	}
+	return s.build(ctx, req.Period, start)
+}
+
+func (s *TweetDigestService) build(ctx context.Context, period string, start time.Time) (model.TweetDigest, error) {
+	end := nextDigestPeriod(period, start)
+	previousStart := start.Add(-end.Sub(start))
+
+	topics, err := s.repo.GetKeyTopics(ctx, start, end)
+	if err != nil {
+		return model.TweetDigest{}, err
+	}
+	if period == model.DigestPeriodWeekly {
+		topics = lastTopicPerDay(topics)
+	}
+	current, err := s.repo.GetTickerStats(ctx, start, end)
+	if err != nil {
+		return model.TweetDigest{}, err
+	}
+	previous, err := s.repo.GetTickerStats(ctx, previousStart, start)
+	if err != nil {
+		return model.TweetDigest{}, err
+	}
+	authors, err := s.repo.GetTopAuthors(ctx, start, end, s.config.TopLimit)
+	if err != nil {
+		return model.TweetDigest{}, err
+	}
+	calls, err := s.repo.GetNewCalls(ctx, start, end, start.AddDate(0, 0, -s.config.NewCallLookbackDays), s.config.TopLimit)
+	if err != nil {
+		return model.TweetDigest{}, err
+	}
+
+	topTickers, shifts := compareDigestTickers(current, previous, s.config.TopLimit)
+	return s.repo.SaveDigest(ctx, model.TweetDigest{
+		Period:      period,
+		PeriodStart: start,
+		PeriodEnd:   end,
+		TweetDigestContent: model.TweetDigestContent{
+			Topics:          topics,
+			TopTickers:      topTickers,
+			SentimentShifts: shifts,
+			TopAuthors:      authors,
+			NewCalls:        calls,
+		},
+	})
+}
+
+// compareDigestTickers ranks the tickers by mentions and by the absolute change of their bullish ratio since the
+// previous period
+func compareDigestTickers(current, previous []model.DigestTickerStats, limit int) ([]model.DigestTicker, []model.DigestSentimentShift) {
+	previousByTicker := make(map[string]model.DigestTickerStats, len(previous))
+	for _, stats := range previous {
+		previousByTicker[stats.Ticker] = stats
+	}
+
+	tickers := make([]model.DigestTicker, 0, len(current))
+	shifts := []model.DigestSentimentShift{}
+	for _, stats := range current {
+		prev := previousByTicker[stats.Ticker]
+		tickers = append(tickers, model.DigestTicker{DigestTickerStats: stats, PreviousMentions: prev.Mentions})
+
+		signals, prevSignals := stats.Longs+stats.Shorts, prev.Longs+prev.Shorts
+		if signals < minDigestShiftSignals || prevSignals < minDigestShiftSignals {
+			continue
+		}
+		ratio := float64(stats.Longs-stats.Shorts) / float64(signals)
+		prevRatio := float64(prev.Longs-prev.Shorts) / float64(prevSignals)
+		shifts = append(shifts, model.DigestSentimentShift{
+			Ticker:               stats.Ticker,
+			BullishRatio:         ratio,
+			PreviousBullishRatio: prevRatio,
+			Shift:                ratio - prevRatio,
+			Signals:              signals,
+		})
+	}
+
+	sort.Slice(tickers, func(i, j int) bool {
+		if tickers[i].Mentions != tickers[j].Mentions {
+			return tickers[i].Mentions > tickers[j].Mentions
+		}
+		return tickers[i].Ticker < tickers[j].Ticker
+	})
+	sort.Slice(shifts, func(i, j int) bool {
+		if math.Abs(shifts[i].Shift) != math.Abs(shifts[j].Shift) {
+			return math.Abs(shifts[i].Shift) > math.Abs(shifts[j].Shift)
+		}
+		return shifts[i].Ticker < shifts[j].Ticker
+	})
+	if len(tickers) > limit {
+		tickers = tickers[:limit]
+	}
+	if len(shifts) > limit {
+		shifts = shifts[:limit]
+	}
+	return tickers, shifts
+}
+
+func lastTopicPerDay(topics []model.DigestTopic) []model.DigestTopic {
+	sampled := []model.DigestTopic{}
+	for i, topic := range topics {
+		if i+1 == len(topics) || !sameUTCDay(topic.DateTime, topics[i+1].DateTime) {
+			sampled = append(sampled, topic)
+		}
+	}
+	return sampled
+}
+
+func sameUTCDay(a, b time.Time) bool {
+	ay, am, ad := a.UTC().Date()
+	by, bm, bd := b.UTC().Date()
+	return ay == by && am == bm && ad == bd
+}
+
+// deliver sends the digest to the subscriptions that have not received it, through the user's linked Telegram chat or
+// account email. Users without one are retried on the next run.
+func (s *TweetDigestService) deliver(ctx context.Context, digest model.TweetDigest) {
+	subscribers, err := s.repo.GetPendingSubscribers(ctx, digest.Period, digest.PeriodStart)
+	if err != nil {
+		logger.Errorf("tweet digest: failed to get %s subscribers: %v", digest.Period, err)
+		return
+	}
+
+	message := digestMessage(digest)
+	for _, sub := range subscribers {
+		switch sub.Channel {
+		case model.DigestChannelTelegram:
+			if s.telegramService == nil || sub.TelegramChatID == "" {
+				continue
+			}
+			chatID, err := strconv.ParseInt(sub.TelegramChatID, 10, 64)
+			if err != nil {
+				logger.Errorf("tweet digest: invalid telegram chat id for user %s: %v", sub.UserUUID, err)
+				continue
+			}
+			if err := s.telegramService.SendMessage(ctx, message, chatID); err != nil {
+				logger.Errorf("tweet digest: failed to send telegram digest to user %s: %v", sub.UserUUID, err)
+				continue
+			}
+		case model.DigestChannelEmail:
+			if s.emailService == nil || sub.Email == "" {
+				continue
+			}
+			if err := s.emailService.SendEmail(ctx, sub.Email, digestTitle(digest), message); err != nil {
+				logger.Errorf("tweet digest: failed to email digest to user %s: %v", sub.UserUUID, err)
+				continue
+			}
+		default:
+			continue
+		}
+		if err := s.repo.MarkSent(ctx, sub.UserUUID, digest.Period, sub.Channel, digest.PeriodStart); err != nil {
+			logger.Errorf("tweet digest: failed to mark digest sent to user %s: %v", sub.UserUUID, err)
+		}
+	}
+}
+
+func digestTitle(digest model.TweetDigest) string {
+	if digest.Period == model.DigestPeriodWeekly {
+		return "Weekly digest, week of " + digest.PeriodStart.Format("Jan 2, 2006")
+	}
+	return "Daily digest, " + digest.PeriodStart.Format("Jan 2, 2006")
+}
+
+func digestMessage(digest model.TweetDigest) string {
+	var b strings.Builder
+	b.WriteString("📰 " + digestTitle(digest) + "\n")
+
+	if len(digest.TopTickers) > 0 {
+		b.WriteString("\nMost mentioned\n")
+		for _, t := range firstN(digest.TopTickers, digestMessageItems) {
+			fmt.Fprintf(&b, "• %s: %d mentions by %d authors (%d long / %d short), %d the period before\n",
+				t.Ticker, t.Mentions, t.Authors, t.Longs, t.Shorts, t.PreviousMentions)
+		}
+	}
+	if len(digest.SentimentShifts) > 0 {
+		b.WriteString("\nBiggest sentiment shifts\n")
+		for _, shift := range firstN(digest.SentimentShifts, digestMessageItems) {
+			fmt.Fprintf(&b, "• %s: %+.0f%% → %+.0f%% bullish\n", shift.Ticker, shift.PreviousBullishRatio*100, shift.BullishRatio*100)
+		}
+	}
+	if len(digest.TopAuthors) > 0 {
+		b.WriteString("\nTop authors\n")
+		for _, author := range firstN(digest.TopAuthors, digestMessageItems) {
+			fmt.Fprintf(&b, "• @%s: %d tweets, %d engagements\n", author.AuthorUsername, author.Tweets, author.Engagement)
+		}
+	}
+	if len(digest.NewCalls) > 0 {
+		b.WriteString("\nNotable new calls\n")
+		for _, call := range firstN(digest.NewCalls, digestMessageItems) {
+			fmt.Fprintf(&b, "• @%s %s %s %s\n", call.AuthorUsername, call.Action, call.Ticker, call.URL)
+		}
+	}
+
+	message := b.String()
+	if len(message) > maxDigestMessageLen {
+		message = message[:strings.LastIndex(message[:maxDigestMessageLen], "\n")+1]
+	}
+	return message
+}
+
+func firstN[T any](items []T, n int) []T {
+	if len(items) > n {
+		return items[:n]
+	}
+	return items
+}
+
+func (s *TweetDigestService) GetDigest(ctx context.Context, period string, periodStart *time.Time) (model.TweetDigest, error) {
+	if !validDigestPeriod(period) {
+		return model.TweetDigest{}, model.ErrInvalidDigestPeriod
+	}
+	return s.repo.GetDigest(ctx, period, periodStart)
+}
+
+func (s *TweetDigestService) GetDigests(ctx context.Context, period string, limit int) ([]model.TweetDigest, error) {
+	if !validDigestPeriod(period) {
+		return nil, model.ErrInvalidDigestPeriod
+	}
+	if limit <= 0 {
+		limit = defaultDigestList
+	}
+	if limit > maxDigestList {
+		limit = maxDigestList
+	}
+	return s.repo.GetDigests(ctx, period, limit)
+}
+
+func (s *TweetDigestService) GetSubscriptions(ctx context.Context, uid string) ([]model.DigestSubscription, error) {
+	return s.repo.GetSubscriptions(ctx, uid)
+}
+
+func (s *TweetDigestService) Subscribe(ctx context.Context, uid string, req model.DigestSubscriptionRequest) error {
+	if err := validateDigestSubscription(req); err != nil {
+		return err
+	}
+	return s.repo.Subscribe(ctx, uid, req.Period, req.Channel)
+}
+
+func (s *TweetDigestService) Unsubscribe(ctx context.Context, uid string, req model.DigestSubscriptionRequest) error {
+	if err := validateDigestSubscription(req); err != nil {
+		return err
+	}
+	return s.repo.Unsubscribe(ctx, uid, req.Period, req.Channel)
+}
+
+func validateDigestSubscription(req model.DigestSubscriptionRequest) error {
+	if !validDigestPeriod(req.Period) {
+		return model.ErrInvalidDigestPeriod
+	}
+	if req.Channel != model.DigestChannelTelegram && req.Channel != model.DigestChannelEmail {
+		return model.ErrInvalidDigestChannel
+	}
+	return nil
+}
+
+func validDigestPeriod(period string) bool {
+	return period == model.DigestPeriodDaily || period == model.DigestPeriodWeekly
+}
+
+// digestPeriodStart is the start of the UTC day or Monday-start week containing t
+func digestPeriodStart(period string, t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	start := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	if period == model.DigestPeriodWeekly {
+		start = start.AddDate(0, 0, -(int(start.Weekday())+6)%7)
+	}
+	return start
+}
+
+func nextDigestPeriod(period string, start time.Time) time.Time {
+	if period == model.DigestPeriodWeekly {
+		return start.AddDate(0, 0, 7)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+func lastEndedDigestPeriod(period string, now time.Time) time.Time {
+	current := digestPeriodStart(period, now)
+	if period == model.DigestPeriodWeekly {
+		return current.AddDate(0, 0, -7)
+	}
+	return current.AddDate(0, 0, -1)
+}
@@ -9,11 +9,12 @@ import (
 )
 
 type TwitterCryptoService struct {
-	repo port.TwitterCryptoRepo
+	repo    port.TwitterCryptoRepo
+	tickers port.TickerResolver
 }
 
-func NewTwitterCryptoService(repo port.TwitterCryptoRepo) port.TwitterCryptoService {
-	return &TwitterCryptoService{repo: repo}
+func NewTwitterCryptoService(repo port.TwitterCryptoRepo, tickers port.TickerResolver) port.TwitterCryptoService {
+	return &TwitterCryptoService{repo: repo, tickers: tickers}
 }
 
 func (s *TwitterCryptoService) GetAllSentiments(ctx context.Context) ([]model.TwitterCryptoSentiment, error) {
@@ -32,35 +33,47 @@ func (s *TwitterCryptoService) GetAuthorWinrate(ctx context.Context, selectedWin
 	return s.repo.GetAuthorWinrate(ctx, selectedWinratePeriod)
 }
 
-func (s *TwitterCryptoService) GetPaginatedTweets(ctx context.Context, start, limit int, sortBy, sortOrder string) ([]model.TwitterCryptoTweet, int, error) {
-	return s.repo.GetPaginatedTweets(ctx, start, limit, sortBy, sortOrder)
+func (s *TwitterCryptoService) GetPaginatedTweets(ctx context.Context, page model.FeedPage, sortBy, sortOrder string) ([]model.TwitterCryptoTweet, *model.FeedCursor, int, error) {
+	return s.repo.GetPaginatedTweets(ctx, page, sortBy, sortOrder)
 }
 
 func (s *TwitterCryptoService) GetPaginatedSentiments(ctx context.Context, start, limit int, sortBy, sortOrder string) ([]model.TwitterCryptoSentiment, int, error) {
 	return s.repo.GetPaginatedSentiments(ctx, start, limit, sortBy, sortOrder)
 }
 
-func (s *TwitterCryptoService) GetTweetsWithSentiments(ctx context.Context, start, limit int, sortBy, sortOrder string) ([]model.TwitterCryptoTweetWithSentiment, int, error) {
-	return s.repo.GetTweetsWithSentiments(ctx, start, limit, sortBy, sortOrder)
+func (s *TwitterCryptoService) GetTweetsWithSentiments(ctx context.Context, page model.FeedPage, sortBy, sortOrder string) ([]model.TwitterCryptoTweetWithSentiment, *model.FeedCursor, int, error) {
+	return s.repo.GetTweetsWithSentiments(ctx, page, sortBy, sortOrder)
 }
 
-func (s *TwitterCryptoService) GetTweetsWithSentimentsAndAuthor(ctx context.Context, start, limit int, sortBy, sortOrder string, authors []string, fromDate, toDate *time.Time, searchTokenSymbolValue string) ([]model.TwitterCryptoTweetWithSentimentAndAuthor, int, error) {
-	return s.repo.GetTweetsWithSentimentsAndAuthor(ctx, start, limit, sortBy, sortOrder, authors, fromDate, toDate, searchTokenSymbolValue)
+func (s *TwitterCryptoService) GetTweetsWithSentimentsAndAuthor(ctx context.Context, page model.FeedPage, sortBy, sortOrder string, authors []string, fromDate, toDate *time.Time, searchTokenSymbolValue string) ([]model.TwitterCryptoTweetWithSentimentAndAuthor, *model.FeedCursor, int, error) {
+	if searchTokenSymbolValue != "" {
+		searchTokenSymbolValue = s.tickers.Resolve(searchTokenSymbolValue)
+	}
+	return s.repo.GetTweetsWithSentimentsAndAuthor(ctx, page, sortBy, sortOrder, authors, fromDate, toDate, searchTokenSymbolValue)
+}
+func (s *TwitterCryptoService) SearchTweets(ctx context.Context, q model.TweetSearchQuery) ([]model.TwitterCryptoTweetSearchResult, int, error) {
+	if q.SearchTokenSymbolValue != "" {
+		q.SearchTokenSymbolValue = s.tickers.Resolve(q.SearchTokenSymbolValue)
+	}
+	return s.repo.SearchTweets(ctx, q)
 }
 func (s *TwitterCryptoService) GetTweetsWithSentimentAuthorSignal(ctx context.Context, start, limit int, sortBy, sortOrder string, authors []string, fromDate, toDate *time.Time) ([]model.TwitterCryptoTweetWithSentimentAuthorAndSignal, int, error) {
 	return s.repo.GetTweetsWithSentimentAuthorSignal(ctx, start, limit, sortBy, sortOrder, authors, fromDate, toDate)
 }
-func (s *TwitterCryptoService) GetTweetsWithSentimentsAndTier(ctx context.Context, start, limit int, sortBy, sortOrder string, authors []string, fromDate, toDate *time.Time) ([]model.TwitterCryptoTweetWithSentimentAndTier, int, error) {
-	tweets, total, err := s.repo.GetTweetsWithSentimentsAndTier(ctx, start, limit, sortBy, sortOrder, authors, fromDate, toDate)
+func (s *TwitterCryptoService) GetTweetsWithSentimentsAndTier(ctx context.Context, page model.FeedPage, sortBy, sortOrder string, authors []string, fromDate, toDate *time.Time) ([]model.TwitterCryptoTweetWithSentimentAndTier, *model.FeedCursor, int, error) {
+	tweets, next, total, err := s.repo.GetTweetsWithSentimentsAndTier(ctx, page, sortBy, sortOrder, authors, fromDate, toDate)
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, 0, err
 	}
 
-	return tweets, total, nil
+	return tweets, next, total, nil
 }
 
-func (s *TwitterCryptoService) GetSummaries(ctx context.Context, start, limit int, sortBy, sortOrder string, fromDate, toDate *time.Time) ([]model.TwitterCryptoSummary, int, error) {
-	return s.repo.GetSummaries(ctx, start, limit, sortBy, sortOrder, fromDate, toDate)
+func (s *TwitterCryptoService) GetSummaries(ctx context.Context, page model.FeedPage, sortBy, sortOrder string, fromDate, toDate *time.Time) ([]model.TwitterCryptoSummary, *model.FeedCursor, int, error) {
+	return s.repo.GetSummaries(ctx, page, sortBy, sortOrder, fromDate, toDate)
+}
+func (s *TwitterCryptoService) GetTopMentionedTickers(ctx context.Context, from, to time.Time, limit int) ([]string, error) {
+	return s.repo.GetTopMentionedTickers(ctx, from, to, limit)
 }
 func (s *TwitterCryptoService) GetBubbleSentiment(ctx context.Context) (model.BubbleSentimentModel, error) {
 	return s.repo.GetBubbleSentiment(ctx)
@@ -0,0 +1,298 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
+)
+
+const (
+	winrateCacheTTL        = 15 * time.Minute
+	maxWinrateHorizons     = 10
+	maxWinrateHorizonHours = 24 * 90
+	defaultWinrateTF       = "1h"
+)
+
+// CalculateWinrate evaluates author signals against OHLCV for the requested horizons and success rule.
+// Signal tickers without candles are skipped and reported as missing. Results are cached per normalized parameter set.
+func (s *winRateService) CalculateWinrate(ctx context.Context, req model.WinrateCalcRequest) (model.WinrateCalcResult, error) {
+	if s.ohlcvRepo == nil {
+		return model.WinrateCalcResult{}, model.ErrOHLCVUnavailable
+	}
+	weights, err := normalizeWinrateRequest(&req)
+	if err != nil {
+		return model.WinrateCalcResult{}, err
+	}
+
+	cacheKey, err := json.Marshal(req)
+	if err != nil {
+		return model.WinrateCalcResult{}, err
+	}
+	if cached, ok := s.cache.Get(string(cacheKey)); ok {
+		return cached, nil
+	}
+
+	signals, err := s.winRateRepo.GetSignalsForWinrate(ctx, req.FromDate, req.ToDate, req.Tickers, req.Authors)
+	if err != nil {
+		return model.WinrateCalcResult{}, err
+	}
+
+	maxHorizon := time.Duration(req.HorizonHours[len(req.HorizonHours)-1]) * time.Hour
+	candlesByTicker, missing := s.loadCandles(signals, req.TimeFrame, req.FromDate, req.ToDate.Add(maxHorizon))
+
+	type tally struct {
+		wins  []int
+		total []int
+		seen  int
+	}
+	tallies := make(map[string]*tally)
+	for _, signal := range signals {
+		direction := signalDirection(signal)
+		candles := candlesByTicker[signal.Ticker]
+		if direction == 0 || len(candles) == 0 {
+			continue
+		}
+
+		t, ok := tallies[signal.AuthorUsername]
+		if !ok {
+			t = &tally{wins: make([]int, len(req.HorizonHours)), total: make([]int, len(req.HorizonHours))}
+			tallies[signal.AuthorUsername] = t
+		}
+		t.seen++
+
+		for i, hours := range req.HorizonHours {
+			win, evaluated := evaluateSignal(candles, signal.CreatedAt, time.Duration(hours)*time.Hour, direction, req)
+			if !evaluated {
+				continue
+			}
+			t.total[i]++
+			if win {
+				t.wins[i]++
+			}
+		}
+	}
+
+	results := make([]model.AuthorWinrateResult, 0, len(tallies))
+	for author, t := range tallies {
+		result := model.AuthorWinrateResult{AuthorUsername: author, TotalSignals: t.seen}
+		var blended, weightSum float64
+		for i, hours := range req.HorizonHours {
+			h := model.HorizonWinrate{HorizonHours: hours, Wins: t.wins[i], Total: t.total[i]}
+			if h.Total > 0 {
+				h.Winrate = float64(h.Wins) / float64(h.Total)
+				blended += weights[i] * h.Winrate
+				weightSum += weights[i]
+			}
+			result.Horizons = append(result.Horizons, h)
+		}
+		// Horizons without any evaluated signal do not drag the blend down
+		if weightSum > 0 {
+			result.BlendedWinrate = blended / weightSum
+		}
+		results = append(results, result)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].BlendedWinrate == results[j].BlendedWinrate {
+			return results[i].AuthorUsername < results[j].AuthorUsername
+		}
+		return results[i].BlendedWinrate > results[j].BlendedWinrate
+	})
+
+	result := model.WinrateCalcResult{Authors: results, Missing: missing}
+	s.cache.Set(string(cacheKey), result)
+	return result, nil
+}
+
+// normalizeWinrateRequest validates the request in place so equivalent requests share a cache key and returns the blend weights
+func normalizeWinrateRequest(req *model.WinrateCalcRequest) ([]float64, error) {
+	switch req.Rule {
+	case "":
+		req.Rule = model.WinrateRuleDirection
+	case model.WinrateRuleDirection:
+	case model.WinrateRuleMinMove:
+		if req.MinMovePct <= 0 {
+			return nil, model.ErrWinrateInvalidThresholds
+		}
+	case model.WinrateRuleSLTP:
+		if req.TakeProfitPct <= 0 || req.StopLossPct <= 0 {
+			return nil, model.ErrWinrateInvalidThresholds
+		}
+	default:
+		return nil, model.ErrWinrateInvalidRule
+	}
+	if req.Rule != model.WinrateRuleMinMove {
+		req.MinMovePct = 0
+	}
+	if req.Rule != model.WinrateRuleSLTP {
+		req.TakeProfitPct, req.StopLossPct = 0, 0
+	}
+
+	if len(req.HorizonHours) == 0 || len(req.HorizonHours) > maxWinrateHorizons {
+		return nil, model.ErrWinrateInvalidHorizon
+	}
+	for _, h := range req.HorizonHours {
+		if h < 1 || h > maxWinrateHorizonHours {
+			return nil, model.ErrWinrateInvalidHorizon
+		}
+	}
+
+	weights := req.Weights
+	if len(weights) == 0 {
+		weights = make([]float64, len(req.HorizonHours))
+		for i := range weights {
+			weights[i] = 1
+		}
+	}
+	if len(weights) != len(req.HorizonHours) {
+		return nil, model.ErrWinrateInvalidWeights
+	}
+	var sum float64
+	for _, w := range weights {
+		if w < 0 {
+			return nil, model.ErrWinrateInvalidWeights
+		}
+		sum += w
+	}
+	if sum <= 0 {
+		return nil, model.ErrWinrateInvalidWeights
+	}
+
+	// Sort horizons together with their weights and rescale the weights to sum to 1
+	order := make([]int, len(req.HorizonHours))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return req.HorizonHours[order[a]] < req.HorizonHours[order[b]] })
+	horizons := make([]int, len(order))
+	normalized := make([]float64, len(order))
+	for i, idx := range order {
+		horizons[i] = req.HorizonHours[idx]
+		normalized[i] = weights[idx] / sum
+	}
+	req.HorizonHours = horizons
+	req.Weights = normalized
+
+	if req.ToDate.IsZero() {
+		req.ToDate = time.Now().UTC().Truncate(time.Hour)
+	}
+	if req.FromDate.IsZero() {
+		req.FromDate = req.ToDate.AddDate(0, -3, 0)
+	}
+	if !req.FromDate.Before(req.ToDate) {
+		return nil, model.ErrWinrateInvalidDateRange
+	}
+	if req.TimeFrame == "" {
+		req.TimeFrame = defaultWinrateTF
+	}
+
+	req.Tickers = normalizeUpperList(req.Tickers)
+	req.Authors = normalizeList(req.Authors)
+	return normalized, nil
+}
+
+func normalizeUpperList(values []string) []string {
+	for i, v := range values {
+		values[i] = strings.ToUpper(v)
+	}
+	return normalizeList(values)
+}
+
+func normalizeList(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		result = append(result, v)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// loadCandles fetches OHLCV for every signal ticker and returns the sorted tickers without candles. Signal tickers are
+// canonical assets such as BTC and Binance tables store their USDT quoted pairs.
+func (s *winRateService) loadCandles(signals []model.WinrateSignal, timeFrame string, from, to time.Time) (map[string][]model.OHLCVData, []string) {
+	candlesByTicker := make(map[string][]model.OHLCVData)
+	missing := make([]string, 0)
+	for _, signal := range signals {
+		if _, ok := candlesByTicker[signal.Ticker]; ok {
+			continue
+		}
+		candles, err := s.ohlcvRepo.GetCryptoOHLCV(model.OHLCVRequest{
+			Ticker:    signal.Ticker + "USDT",
+			TimeFrame: timeFrame,
+			StartDate: from,
+			EndDate:   &to,
+		})
+		if err != nil {
+			// Pairs Binance does not list have no candle table
+			logger.Errorf("winrate calculator: skipping %s, failed to get candles: %v", signal.Ticker, err)
+			candles = nil
+		}
+		if len(candles) == 0 {
+			missing = append(missing, signal.Ticker)
+		}
+		candlesByTicker[signal.Ticker] = candles
+	}
+	sort.Strings(missing)
+	return candlesByTicker, missing
+}
+
+func signalDirection(signal model.WinrateSignal) float64 {
+	switch {
+	case signal.Action == string(model.SignalActionLong) || signal.Sentiment == "Bullish":
+		return 1
+	case signal.Action == string(model.SignalActionShort) || signal.Sentiment == "Bearish":
+		return -1
+	}
+	return 0
+}
+
+// evaluateSignal enters at the open of the first candle at or after the signal and reports whether the signal
+// succeeded within the horizon. Signals whose horizon is not fully covered by OHLCV are not evaluated.
+func evaluateSignal(candles []model.OHLCVData, at time.Time, horizon time.Duration, direction float64, req model.WinrateCalcRequest) (win bool, evaluated bool) {
+	entryIdx := sort.Search(len(candles), func(i int) bool { return !candles[i].Time.Before(at) })
+	if entryIdx == len(candles) {
+		return false, false
+	}
+	entry := candles[entryIdx].Open
+	if entry <= 0 {
+		return false, false
+	}
+	end := candles[entryIdx].Time.Add(horizon)
+	if candles[len(candles)-1].Time.Before(end) {
+		return false, false
+	}
+	exitIdx := sort.Search(len(candles), func(i int) bool { return candles[i].Time.After(end) }) - 1
+
+	switch req.Rule {
+	case model.WinrateRuleSLTP:
+		takeProfit := entry * (1 + direction*req.TakeProfitPct/100)
+		stopLoss := entry * (1 - direction*req.StopLossPct/100)
+		for _, c := range candles[entryIdx : exitIdx+1] {
+			// When both levels are inside the same candle the stop is assumed to have been hit first
+			if (direction > 0 && c.Low <= stopLoss) || (direction < 0 && c.High >= stopLoss) {
+				return false, true
+			}
+			if (direction > 0 && c.High >= takeProfit) || (direction < 0 && c.Low <= takeProfit) {
+				return true, true
+			}
+		}
+		// Neither level touched before the horizon counts as a failed signal
+		return false, true
+	case model.WinrateRuleMinMove:
+		move := direction * (candles[exitIdx].Close/entry - 1) * 100
+		return move >= req.MinMovePct, true
+	default:
+		move := direction * (candles[exitIdx].Close/entry - 1)
+		return move > 0, true
+	}
+}
@@ -3,14 +3,22 @@ package service
 import (
 	"github.com/quantsmithapp/datastation-backend/internal/core/port"
 	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/cache"
 )
 
 type winRateService struct {
 	winRateRepo port.WinRateRepo
+	ohlcvRepo   port.TimescaleRepo
+	cache       *cache.TTLCache[model.WinrateCalcResult]
 }
 
-func NewWinRateService(winRateRepo port.WinRateRepo) *winRateService {
-	return &winRateService{winRateRepo: winRateRepo}
+// NewWinRateService creates the winrate service, ohlcvRepo may be nil when Timescale is not configured
+func NewWinRateService(winRateRepo port.WinRateRepo, ohlcvRepo port.TimescaleRepo) *winRateService {
+	return &winRateService{
+		winRateRepo: winRateRepo,
+		ohlcvRepo:   ohlcvRepo,
+		cache:       cache.NewTTLCache[model.WinrateCalcResult](winrateCacheTTL),
+	}
 }
 
 func (s *winRateService) GetWinRate() ([]model.WinRateModel, error) {
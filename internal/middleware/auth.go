@@ -55,35 +55,54 @@ func AuthMiddleware(userRepo port.AuthRepo, jwtService port.JwtService) fiber.Ha
 		if idToken == "" {
 			return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": "invalid token"})
 		}
+		return authenticateUser(c, userRepo, jwtService, idToken)
+	}
+}
 
-		token, err := jwtService.VerifyToken(idToken)
-		if err != nil {
-			return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+// WebSocketAuthMiddleware runs the AuthMiddleware token check on WebSocket upgrades. Browsers cannot add headers to
+// them, so the ID token may also come in the token query parameter.
+func WebSocketAuthMiddleware(userRepo port.AuthRepo, jwtService port.JwtService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		idToken := c.Query("token")
+		if idToken == "" {
+			idToken = extractBearerToken(c.Get("Authorization"))
 		}
-
-		uid, err := json.Marshal(token.UID)
-		if err != nil {
-			return c.Status(http.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		if idToken == "" {
+			return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": "missing token"})
 		}
+		return authenticateUser(c, userRepo, jwtService, idToken)
+	}
+}
 
-		_, err = userRepo.CheckUserByUid(string(uid))
-		if err != nil {
-			return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": "user not found"})
-		}
+// authenticateUser verifies the ID token and stores the user in the locals before moving on to the next handler
+func authenticateUser(c *fiber.Ctx, userRepo port.AuthRepo, jwtService port.JwtService, idToken string) error {
+	token, err := jwtService.VerifyToken(idToken)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
 
-		uidString := strings.ReplaceAll(string(uid), "\"", "")
+	uid, err := json.Marshal(token.UID)
+	if err != nil {
+		return c.Status(http.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
 
-		userInfo, err := userRepo.GetUserInfo(uidString)
-		if err != nil {
-			return c.Status(http.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
-		}
+	_, err = userRepo.CheckUserByUid(string(uid))
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": "user not found"})
+	}
 
-		c.Locals("uid", uidString)
-		c.Locals("email", userInfo.Email)
-		c.Locals("twitter_uid", userInfo.TwitterUID)
-		c.Locals("twitter_name", userInfo.TwitterName)
-		return c.Next()
+	uidString := strings.ReplaceAll(string(uid), "\"", "")
+
+	userInfo, err := userRepo.GetUserInfo(uidString)
+	if err != nil {
+		return c.Status(http.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
+
+	c.Locals("uid", uidString)
+	c.Locals("email", userInfo.Email)
+	c.Locals("twitter_uid", userInfo.TwitterUID)
+	c.Locals("twitter_name", userInfo.TwitterName)
+	return c.Next()
 }
 
 func extractBearerToken(rawToken string) string {
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/quantsmithapp/datastation-backend/internal/core/port"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
+)
+
+// IngestAPIKeyMiddleware accepts requests carrying an active ingestion API key with the given scope in X-API-Key
+func IngestAPIKeyMiddleware(authenticator port.IngestAuthenticator, scope string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		rawKey := c.Get("X-API-Key")
+		if rawKey == "" {
+			return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": "missing API key"})
+		}
+
+		key, err := authenticator.AuthenticateAPIKey(c.UserContext(), rawKey)
+		if err != nil {
+			if errors.Is(err, model.ErrInvalidIngestAPIKey) {
+				return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+			}
+			logger.Errorf("ingest: failed to authenticate API key: %v", err)
+			return c.Status(http.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to authenticate API key"})
+		}
+		if !key.HasScope(scope) {
+			return c.Status(http.StatusForbidden).JSON(fiber.Map{"error": model.ErrIngestScopeDenied.Error()})
+		}
+
+		c.Locals("api_key_id", key.ID)
+		c.Locals("api_key_name", key.Name)
+		return c.Next()
+	}
+}
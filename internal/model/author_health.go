@@ -0,0 +1,61 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrPausedSubscriptionNotFound = errors.New("paused subscription not found")
+
+const (
+	AuthorHealthHealthy          = "healthy"
+	AuthorHealthDegraded         = "degraded"
+	AuthorHealthInsufficientData = "insufficient_data"
+)
+
+// AuthorHealth compares an author's recent window against the rest of their own history, measured on the author's
+// daily NAV. PositiveDayShare is the share of days with a positive NAV return, a proxy of the winrate since NAV does not
+// record individual signals. Returns are in percent and drawdowns are fractions.
+type AuthorHealth struct {
+	AuthorUsername           string  `json:"author_username" db:"author_username"`
+	Status                   string  `json:"status" db:"status"`
+	RecentPositiveDayShare   float64 `json:"recent_positive_day_share" db:"recent_positive_day_share"`
+	BaselinePositiveDayShare float64 `json:"baseline_positive_day_share" db:"baseline_positive_day_share"`
+	RecentROI                float64 `json:"recent_roi" db:"recent_roi"`
+	BaselineROI              float64 `json:"baseline_roi" db:"baseline_roi"`
+	RecentDrawdown           float64 `json:"recent_drawdown" db:"recent_drawdown"`
+	BaselineDrawdown         float64 `json:"baseline_drawdown" db:"baseline_drawdown"`
+	// PositiveDayZScore is the two-proportion z-score of the recent positive day share against the baseline, over days
+	PositiveDayZScore float64 `json:"positive_day_z_score" db:"positive_day_z_score"`
+	ReturnTStat       float64 `json:"return_t_stat" db:"return_t_stat"`
+	// DrawdownZScore scores the recent drawdown against the drawdowns of baseline windows of the same length
+	DrawdownZScore  float64   `json:"drawdown_z_score" db:"drawdown_z_score"`
+	EvaluatedAt     time.Time `json:"evaluated_at" db:"evaluated_at"`
+	StatusChangedAt time.Time `json:"status_changed_at" db:"status_changed_at"`
+	// AlertedAt is when subscribers were alerted of the degraded status, nil until every alert was sent
+	AlertedAt *time.Time `json:"alerted_at" db:"alerted_at"`
+}
+
+// AuthorSubscriber is a copytrade wallet following an author
+type AuthorSubscriber struct {
+	UserUUID   string `db:"uuid"`
+	WalletID   string `db:"wallet_id"`
+	WalletName string `db:"wallet_name"`
+	AutoPause  bool   `db:"auto_pause"`
+}
+
+type AuthorHealthPreference struct {
+	AutoPause bool `json:"auto_pause" db:"auto_pause"`
+}
+
+// PausedSubscription is a wallet subscription removed by the author health watcher
+type PausedSubscription struct {
+	WalletID       string    `json:"wallet_id" db:"wallet_id"`
+	AuthorUsername string    `json:"author_username" db:"author_username"`
+	PausedAt       time.Time `json:"paused_at" db:"paused_at"`
+}
+
+type ResumeSubscriptionRequest struct {
+	WalletID       string `json:"wallet_id"`
+	AuthorUsername string `json:"author_username"`
+}
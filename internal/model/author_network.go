@@ -0,0 +1,62 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidNetworkWindow = errors.New("window_days must be between 1 and 180")
+	ErrAuthorNotInNetwork   = errors.New("author has no signals in the window")
+)
+
+// AuthorTickerSignal counts the LONG and SHORT signals of an author on one ticker
+type AuthorTickerSignal struct {
+	AuthorUsername string `db:"author_username"`
+	AuthorTier     string `db:"author_tier"`
+	Ticker         string `db:"ticker"`
+	Longs          int    `db:"longs"`
+	Shorts         int    `db:"shorts"`
+}
+
+// SimilarAuthor is an edge of the author network seen from one author
+type SimilarAuthor struct {
+	AuthorUsername string `json:"author_username"`
+	AuthorTier     string `json:"author_tier"`
+	SharedTickers  int    `json:"shared_tickers"`
+	Agreements     int    `json:"agreements"`
+	Disagreements  int    `json:"disagreements"`
+	// Overlap is the cosine overlap of the two ticker sets, between 0 and 1
+	Overlap float64 `json:"overlap"`
+	// Agreement is between -1 (always opposite directions) and 1 (always the same direction) on the shared tickers
+	Agreement float64 `json:"agreement"`
+	// Similarity is Overlap signed and scaled by Agreement
+	Similarity float64 `json:"similarity"`
+}
+
+type SimilarAuthorsResult struct {
+	AuthorUsername string          `json:"author_username"`
+	WindowDays     int             `json:"window_days"`
+	Tickers        int             `json:"tickers"`
+	Similar        []SimilarAuthor `json:"similar"`
+}
+
+type AuthorClusterMember struct {
+	AuthorUsername string `json:"author_username"`
+	AuthorTier     string `json:"author_tier"`
+}
+
+type AuthorCluster struct {
+	ID         int                   `json:"id"`
+	Size       int                   `json:"size"`
+	Members    []AuthorClusterMember `json:"members"`
+	TopTickers []string              `json:"top_tickers"`
+	// Cohesion is the average similarity of the linked member pairs
+	Cohesion float64 `json:"cohesion"`
+}
+
+type AuthorClusterResult struct {
+	WindowDays int             `json:"window_days"`
+	Clusters   []AuthorCluster `json:"clusters"`
+	ComputedAt time.Time       `json:"computed_at"`
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidConsensusWindow  = errors.New("window_hours must be between 1 and 720")
+	ErrConsensusTickerRequired = errors.New("ticker is required")
+)
+
+type ConsensusQuery struct {
+	// Ticker limits the consensus to one ticker, empty returns every ticker with a signal in the window
+	Ticker      string
+	WindowHours int
+	Tier        string
+}
+
+// ConsensusSignal is the latest LONG or SHORT signal of a tracked author on a ticker
+type ConsensusSignal struct {
+	AuthorUsername string           `db:"author_username"`
+	AuthorTier     string           `db:"author_tier"`
+	Ticker         string           `db:"ticker"`
+	Action         SignalActionType `db:"action"`
+	SignalAt       time.Time        `db:"signal_at"`
+	TweetID        string           `db:"tweet_id"`
+	Winrate        float64          `db:"winrate"`
+	// MeanWinrate is the average winrate of every tracked author, the winrate weight is relative to it
+	MeanWinrate float64 `db:"mean_winrate"`
+}
+
+type ConsensusContributor struct {
+	AuthorUsername string           `json:"author_username"`
+	AuthorTier     string           `json:"author_tier"`
+	Action         SignalActionType `json:"action"`
+	SignalAt       time.Time        `json:"signal_at"`
+	TweetID        string           `json:"tweet_id"`
+	Winrate        float64          `json:"winrate"`
+	WinrateWeight  float64          `json:"winrate_weight"`
+	RecencyWeight  float64          `json:"recency_weight"`
+	Weight         float64          `json:"weight"`
+}
+
+type TickerConsensus struct {
+	Ticker      string `json:"ticker"`
+	Tier        string `json:"tier"`
+	WindowHours int    `json:"window_hours"`
+	// Score is between -1 (every weighted author short) and 1 (every weighted author long)
+	Score float64 `json:"score"`
+	// Confidence is between 0 and 1, the agreement of the authors discounted when few authors contribute
+	Confidence   float64                `json:"confidence"`
+	LongWeight   float64                `json:"long_weight"`
+	ShortWeight  float64                `json:"short_weight"`
+	LongAuthors  int                    `json:"long_authors"`
+	ShortAuthors int                    `json:"short_authors"`
+	Contributors []ConsensusContributor `json:"contributors"`
+	ComputedAt   time.Time              `json:"computed_at"`
+}
+
+type ConsensusSnapshot struct {
+	Ticker       string    `json:"ticker" db:"ticker"`
+	Tier         string    `json:"tier" db:"tier"`
+	WindowHours  int       `json:"window_hours" db:"window_hours"`
+	Score        float64   `json:"score" db:"score"`
+	Confidence   float64   `json:"confidence" db:"confidence"`
+	LongWeight   float64   `json:"long_weight" db:"long_weight"`
+	ShortWeight  float64   `json:"short_weight" db:"short_weight"`
+	LongAuthors  int       `json:"long_authors" db:"long_authors"`
+	ShortAuthors int       `json:"short_authors" db:"short_authors"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,66 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// CorrelationBenchmark is the pair betas and rolling correlations are measured against
+const CorrelationBenchmark = "BTCUSDT"
+
+const (
+	DefaultCorrelationTimeFrame = "1d"
+	DefaultCorrelationLookback  = 90
+	DefaultCorrelationWindow    = 30
+	MaxCorrelationTickers       = 50
+	MinCorrelationLookback      = 10
+	MaxCorrelationLookback      = 1000
+	MinCorrelationWindow        = 5
+)
+
+var (
+	ErrCorrelationTickersRequired  = errors.New("tickers or top is required")
+	ErrTooManyCorrelationTickers   = errors.New("at most 50 tickers")
+	ErrInvalidCorrelationTimeFrame = errors.New("tf must be a number of minutes, hours, days or weeks such as 15m, 4h, 1d or 1w")
+	ErrInvalidCorrelationLookback  = errors.New("lookback must be between 10 and 1000 candles")
+	ErrInvalidCorrelationWindow    = errors.New("window must be at least 5 candles and at most the lookback")
+)
+
+// CorrelationRequest selects the pairs by Tickers, or the Top pairs against USDT by market cap when Tickers is empty.
+// Lookback and Window are numbers of returns.
+type CorrelationRequest struct {
+	Tickers   []string `json:"tickers"`
+	Top       int      `json:"top"`
+	TimeFrame string   `json:"tf"`
+	Lookback  int      `json:"lookback"`
+	Window    int      `json:"window"`
+}
+
+// TickerBeta measures a pair against the benchmark over the lookback. Values are null with fewer than Window returns
+// in common.
+type TickerBeta struct {
+	Ticker       string   `json:"ticker"`
+	Beta         *float64 `json:"beta"`
+	Correlation  *float64 `json:"correlation"`
+	Observations int      `json:"observations"`
+}
+
+// CorrelationResult holds the correlations of the log returns of the pairs over the lookback. Matrix is indexed like
+// Tickers and only counts the returns both pairs have, cells are null with fewer than Window of them. Rolling holds
+// the correlation of each pair to the benchmark over the Window returns ending at each of Times, null where the pair
+// or the benchmark lacks one of them.
+type CorrelationResult struct {
+	Tickers   []string              `json:"tickers"`
+	Benchmark string                `json:"benchmark"`
+	TimeFrame string                `json:"tf"`
+	Lookback  int                   `json:"lookback"`
+	Window    int                   `json:"window"`
+	Start     time.Time             `json:"start"`
+	End       time.Time             `json:"end"`
+	Matrix    [][]*float64          `json:"matrix"`
+	Betas     []TickerBeta          `json:"betas"`
+	Times     []time.Time           `json:"times"`
+	Rolling   map[string][]*float64 `json:"rolling"`
+	// Missing are the requested pairs without candles in the lookback, they are left out of the result
+	Missing []string `json:"missing"`
+}
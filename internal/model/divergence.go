@@ -0,0 +1,58 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// Divergence types. The first two compare price extremes with the sentiment trend, the last two compare the level
+// of sentiment with the price trend.
+const (
+	DivergencePriceHighSentimentFalling  = "price_high_sentiment_falling"
+	DivergencePriceLowSentimentRising    = "price_low_sentiment_rising"
+	DivergenceNegativeSentimentPriceUp   = "negative_sentiment_price_up"
+	DivergencePositiveSentimentPriceDown = "positive_sentiment_price_down"
+)
+
+var (
+	ErrDivergenceTickerRequired = errors.New("ticker is required")
+	ErrInvalidDivergenceWindow  = errors.New("lookback and forward must be between 2 and 200 buckets")
+)
+
+type DivergenceRequest struct {
+	Ticker  string
+	Bucket  string
+	From    time.Time
+	To      time.Time
+	Authors []string
+	// Lookback is the number of buckets a new price extreme and the sentiment trend are measured over
+	Lookback int
+	// Forward is the number of buckets after the event its forward return is measured over
+	Forward int
+}
+
+type DivergenceEvent struct {
+	Ticker    string    `json:"ticker"`
+	Type      string    `json:"type"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	// ExpectedDirection is 1 when the divergence is bullish for the price and -1 when it is bearish
+	ExpectedDirection int `json:"expected_direction"`
+	// Strength is between 0 and 1, the largest sentiment gap seen during the event
+	Strength float64 `json:"strength"`
+	// PriceChangePct and SentimentChange are measured over the lookback ending at EndTime
+	PriceChangePct  float64 `json:"price_change_pct"`
+	SentimentChange float64 `json:"sentiment_change"`
+	NetSentiment    float64 `json:"net_sentiment"`
+	Mentions        int     `json:"mentions"`
+	// ForwardReturnPct is the close to close return Forward buckets after EndTime, nil until that close exists
+	ForwardReturnPct *float64 `json:"forward_return_pct"`
+}
+
+type TickerDivergence struct {
+	Ticker   string            `json:"ticker"`
+	Bucket   string            `json:"bucket"`
+	Lookback int               `json:"lookback"`
+	Forward  int               `json:"forward"`
+	Events   []DivergenceEvent `json:"events"`
+}
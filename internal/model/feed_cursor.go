@@ -0,0 +1,52 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidFeedCursor      = errors.New("invalid cursor")
+	ErrFeedCursorSort         = errors.New("cursor pagination requires sorting by creation time")
+	ErrFeedCursorSortMismatch = errors.New("cursor belongs to another sort, request the first page again")
+)
+
+// FeedCursor is the keyset position of the last row of a feed page
+type FeedCursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        string    `json:"id"`
+	// InsertedAt breaks ties of feeds ordered by a time other than the row creation time, e.g. the key topic hour
+	InsertedAt *time.Time `json:"c,omitempty"`
+	// SortBy and SortOrder are the sort of the page the cursor was issued for, it only continues that sort
+	SortBy    string `json:"sb"`
+	SortOrder string `json:"so"`
+}
+
+// Encode returns the cursor as an opaque URL-safe token
+func (c FeedCursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func DecodeFeedCursor(token string) (*FeedCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidFeedCursor
+	}
+	var cursor FeedCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.CreatedAt.IsZero() {
+		return nil, ErrInvalidFeedCursor
+	}
+	return &cursor, nil
+}
+
+// FeedPage selects a page of a feed either by offset (Start) or, when Cursor is set, by keyset after the cursor.
+// The total count is only computed when WithTotal is set.
+type FeedPage struct {
+	Start     int
+	Limit     int
+	Cursor    *FeedCursor
+	WithTotal bool
+}
@@ -0,0 +1,81 @@
+package model
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Indicators computed over OHLCV, parameters are written after the name as in macd:12:26:9
+const (
+	IndicatorSMA        = "sma"        // period, default 20
+	IndicatorEMA        = "ema"        // period, default 20
+	IndicatorRSI        = "rsi"        // period, default 14
+	IndicatorMACD       = "macd"       // fast, slow and signal periods, default 12:26:9
+	IndicatorBollinger  = "bb"         // period and standard deviations, default 20:2
+	IndicatorATR        = "atr"        // period, default 14
+	IndicatorVWAP       = "vwap"       // period, default 0 which resets every day in the request timezone
+	IndicatorStochastic = "stoch"      // %K period, %K smoothing and %D period, default 14:3:3
+	IndicatorVolatility = "volatility" // period of the annualized standard deviation of log returns, default 20
+)
+
+// Markets indicators are computed on
+const (
+	IndicatorMarketCrypto = "crypto"
+	IndicatorMarketForex  = "forex"
+)
+
+const (
+	MaxIndicatorsPerRequest = 10
+	MaxIndicatorPeriod      = 500
+	MaxIndicatorCandles     = 5000
+)
+
+var (
+	ErrInvalidIndicator          = errors.New("indicator must be sma, ema, rsi, macd, bb, atr, vwap, stoch or volatility")
+	ErrInvalidIndicatorParams    = errors.New("indicator periods must be whole numbers up to 500, macd needs fast < slow and bb deviations above 0")
+	ErrTooManyIndicators         = errors.New("at most 10 indicators per request")
+	ErrIndicatorsRequired        = errors.New("indicators is required")
+	ErrIndicatorTickerRequired   = errors.New("ticker is required")
+	ErrInvalidIndicatorTimeFrame = errors.New("tf must be a number of minutes, hours, days or weeks such as 15m, 4h, 1d or 1w")
+	ErrInvalidIndicatorRange     = errors.New("end_date must be after start_date")
+	ErrIndicatorRangeTooLarge    = errors.New("range must span at most 5000 candles")
+)
+
+// IndicatorSpec is an indicator with its parameters
+type IndicatorSpec struct {
+	Name   string    `json:"name"`
+	Params []float64 `json:"params"`
+}
+
+// Key identifies the indicator and its parameters, such as macd:12:26:9
+func (s IndicatorSpec) Key() string {
+	parts := []string{s.Name}
+	for _, p := range s.Params {
+		parts = append(parts, strconv.FormatFloat(p, 'f', -1, 64))
+	}
+	return strings.Join(parts, ":")
+}
+
+type IndicatorRequest struct {
+	Market string `json:"market"`
+	OHLCVRequest
+	Indicators []IndicatorSpec `json:"indicators"`
+}
+
+// IndicatorSeries holds the output lines of one indicator, such as macd, signal and histogram, aligned with the
+// candle times of the result. Single line indicators have one line named value. Values are null while the
+// indicator lacks history, which only happens at the start of the ticker's data.
+type IndicatorSeries struct {
+	Key string `json:"key"`
+	IndicatorSpec
+	Lines map[string][]*float64 `json:"lines"`
+}
+
+type IndicatorResult struct {
+	Ticker     string            `json:"ticker"`
+	TimeFrame  string            `json:"tf"`
+	Times      []time.Time       `json:"times"`
+	Indicators []IndicatorSeries `json:"indicators"`
+}
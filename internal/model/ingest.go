@@ -0,0 +1,114 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Scopes of an ingestion API key, one per ingested table
+const (
+	IngestScopeTweets     = "tweets:write"
+	IngestScopeSentiments = "sentiments:write"
+	IngestScopeSignals    = "signals:write"
+)
+
+var IngestScopes = []string{IngestScopeTweets, IngestScopeSentiments, IngestScopeSignals}
+
+const MaxIngestBatchSize = 500
+
+var (
+	ErrInvalidIngestAPIKey      = errors.New("invalid or revoked API key")
+	ErrIngestScopeDenied        = errors.New("API key is missing the required scope")
+	ErrInvalidIngestScope       = errors.New("scopes must be tweets:write, sentiments:write or signals:write")
+	ErrIngestAPIKeyNameRequired = errors.New("name is required")
+	ErrIngestAPIKeyNotFound     = errors.New("API key not found")
+	ErrInvalidIngestBatch       = errors.New("batch must contain between 1 and 500 records")
+)
+
+// IngestAPIKey is a service-to-service key. Only the SHA-256 hash of the key is stored, KeyPrefix identifies it.
+type IngestAPIKey struct {
+	ID         string         `json:"id" db:"id"`
+	Name       string         `json:"name" db:"name"`
+	KeyPrefix  string         `json:"key_prefix" db:"key_prefix"`
+	Scopes     pq.StringArray `json:"scopes" db:"scopes" swaggertype:"array,string"`
+	CreatedBy  string         `json:"created_by" db:"created_by"`
+	CreatedAt  time.Time      `json:"created_at" db:"created_at"`
+	LastUsedAt *time.Time     `json:"last_used_at" db:"last_used_at"`
+	RevokedAt  *time.Time     `json:"revoked_at" db:"revoked_at"`
+}
+
+func (k IngestAPIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+type IngestAPIKeyRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+// CreatedIngestAPIKey carries the plain key, it is only returned when the key is created
+type CreatedIngestAPIKey struct {
+	IngestAPIKey
+	Key string `json:"key"`
+}
+
+type IngestTweetsRequest struct {
+	Tweets []TwitterCryptoTweet `json:"tweets"`
+}
+
+type IngestSentimentsRequest struct {
+	Sentiments []TwitterCryptoSentiment `json:"sentiments"`
+}
+
+// IngestTweetSignals is the full set of signals extracted from one tweet by one prompt version, it replaces the signals
+// stored for the tweet and prompt version
+type IngestTweetSignals struct {
+	TweetID       string                `json:"tweet_id"`
+	PromptVersion string                `json:"prompt_version"`
+	Signals       []TwitterCryptoSignal `json:"signals"`
+}
+
+type IngestSignalsRequest struct {
+	Tweets []IngestTweetSignals `json:"tweets"`
+}
+
+type IngestRejection struct {
+	Index int    `json:"index"`
+	ID    string `json:"id"`
+	Error string `json:"error"`
+}
+
+type IngestResult struct {
+	Accepted int               `json:"accepted"`
+	Created  int               `json:"created"`
+	Updated  int               `json:"updated"`
+	Rejected []IngestRejection `json:"rejected"`
+}
+
+// Kinds of SignalEvent
+const (
+	SignalEventTweet     = "tweet"
+	SignalEventSentiment = "sentiment"
+	SignalEventSignal    = "signal"
+)
+
+// SignalEvent is raised for every accepted ingestion record. Created is false when the record replaced a stored one,
+// so subscribers can skip replays of the same tweet.
+type SignalEvent struct {
+	Kind           string           `json:"kind"`
+	TweetID        string           `json:"tweet_id"`
+	AuthorUsername string           `json:"author_username"`
+	Tickers        []string         `json:"tickers"`
+	Action         SignalActionType `json:"action,omitempty"`
+	Sentiment      string           `json:"sentiment,omitempty"`
+	Score          *float64         `json:"score,omitempty"`
+	Created        bool             `json:"created"`
+	At             time.Time        `json:"at"`
+}
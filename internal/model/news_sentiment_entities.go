@@ -1,6 +1,9 @@
 package model
 
-import "time"
+import (
+	"errors"
+	"time"
+)
 
 type NewsSentimentEntities struct {
 	Ticker           string    `json:"ticker" db:"coin"`
@@ -17,3 +20,91 @@ type NewsSentimentEntities struct {
 	BearishCutoff    float64   `json:"bearish_cutoff" db:"bearish_cutoff"`
 	Decision         string    `json:"decision" db:"decision"`
 }
+
+// Bucket sizes of a news sentiment query, days and weeks start at local midnight (weeks on Monday) in the timezone
+const (
+	NewsBucketHour    = "1h"
+	NewsBucket4Hours  = "4h"
+	NewsBucket12Hours = "12h"
+	NewsBucketDay     = "1d"
+	NewsBucketWeek    = "1w"
+)
+
+const MaxNewsSentimentDays = 90
+
+var (
+	ErrInvalidNewsBucket   = errors.New("bucket must be 1h, 4h, 12h, 1d or 1w")
+	ErrInvalidNewsTimezone = errors.New("timezone must be an IANA timezone such as UTC or Asia/Bangkok")
+	ErrInvalidNewsRange    = errors.New("from must be before to and at most 90 days earlier")
+	ErrInvalidNewsLimit    = errors.New("limit must be between 1 and 100 and headlines between 1 and 10")
+)
+
+type NewsSentimentRequest struct {
+	// Tickers are canonical, empty for the most mentioned tickers
+	Tickers   []string
+	Sources   []string
+	From      time.Time
+	To        time.Time
+	Bucket    string
+	Timezone  string
+	Limit     int
+	Headlines int
+}
+
+// NewsArticleMention is a news article mentioning a canonical ticker, PublishedAt is in UTC
+type NewsArticleMention struct {
+	ID          string    `db:"id"`
+	Title       string    `db:"title"`
+	Source      string    `db:"source_name"`
+	Sentiment   string    `db:"sentiment"`
+	PublishedAt time.Time `db:"published_at"`
+	Ticker      string    `db:"ticker"`
+}
+
+// NewsSentimentCounts counts articles by sentiment. BullishRatio is (positive - negative) / total.
+type NewsSentimentCounts struct {
+	Positive     int     `json:"positive"`
+	Neutral      int     `json:"neutral"`
+	Negative     int     `json:"negative"`
+	Total        int     `json:"total"`
+	NetSentiment int     `json:"net_sentiment"`
+	BullishRatio float64 `json:"bullish_ratio"`
+}
+
+type NewsSourceSentiment struct {
+	Source string `json:"source"`
+	NewsSentimentCounts
+}
+
+type NewsHeadline struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Source      string    `json:"source"`
+	Sentiment   string    `json:"sentiment"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+type NewsSentimentBucket struct {
+	Start time.Time `json:"start"`
+	NewsSentimentCounts
+	// Sources are sorted by articles, most first
+	Sources []NewsSourceSentiment `json:"sources"`
+	// TopHeadlines are the latest articles on the side of the bucket's net sentiment
+	TopHeadlines []NewsHeadline `json:"top_headlines"`
+}
+
+type NewsTickerSentiment struct {
+	Ticker  string                `json:"ticker"`
+	Totals  NewsSentimentCounts   `json:"totals"`
+	Sources []NewsSourceSentiment `json:"sources"`
+	Buckets []NewsSentimentBucket `json:"buckets"`
+}
+
+type NewsSentimentResult struct {
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+	Bucket   string    `json:"bucket"`
+	Timezone string    `json:"timezone"`
+	// Tickers are sorted by articles, most first
+	Tickers []NewsTickerSentiment `json:"tickers"`
+}
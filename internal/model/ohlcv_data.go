@@ -1,9 +1,16 @@
 package model
 
 import (
+	"errors"
+	"strconv"
 	"time"
 )
 
+var (
+	ErrUnsupportedTimeFrame = errors.New("timeframe has no table and cannot be derived from a finer one")
+	ErrInvalidOHLCVTimezone = errors.New("timezone must be an IANA timezone such as UTC or Asia/Bangkok")
+)
+
 type OHLCVData struct {
 	Time   time.Time `json:"time" gorm:"column:bucket;primaryKey"`
 	Ticker string    `json:"ticker" gorm:"column:ticker;primaryKey"`
@@ -20,4 +27,30 @@ type OHLCVRequest struct {
 	StartDate time.Time  `json:"start_date"`
 	EndDate   *time.Time `json:"end_date,omitempty"`
 	AllPair   bool       `json:"all_pair"` // If true, return data for all symbols; if false, only return data for the specified ticker
+	// Tickers restricts AllPair requests to these tickers
+	Tickers []string `json:"tickers,omitempty"`
+	// Timezone aligns candles to local midnight, weeks start on Monday. Empty is UTC.
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// ParseTimeFrame parses a timeframe of minutes, hours, days or weeks such as 15m, 6h, 3d or 1w
+func ParseTimeFrame(tf string) (time.Duration, bool) {
+	if len(tf) < 2 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(tf[:len(tf)-1])
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+	switch tf[len(tf)-1] {
+	case 'm':
+		return time.Duration(n) * time.Minute, true
+	case 'h':
+		return time.Duration(n) * time.Hour, true
+	case 'd':
+		return time.Duration(n) * 24 * time.Hour, true
+	case 'w':
+		return time.Duration(n) * 7 * 24 * time.Hour, true
+	}
+	return 0, false
 }
@@ -0,0 +1,98 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Formats of an OHLCV export
+const (
+	OHLCVExportCSV     = "csv" // gzip compressed
+	OHLCVExportParquet = "parquet"
+)
+
+// Statuses of an export job
+const (
+	OHLCVExportPending   = "pending"
+	OHLCVExportRunning   = "running"
+	OHLCVExportCompleted = "completed"
+	OHLCVExportFailed    = "failed"
+)
+
+const (
+	MaxOHLCVExportTickers    = 200
+	MaxOHLCVExportTimeFrames = 10
+	// MaxOHLCVExportDirectRows is the most candles an export streamed in the response may have, larger exports and
+	// exports of all pairs run as jobs
+	MaxOHLCVExportDirectRows = 500000
+	// MaxOHLCVExportActiveJobs is how many jobs a user may have pending or running at once
+	MaxOHLCVExportActiveJobs = 3
+)
+
+var (
+	ErrInvalidExportFormat     = errors.New("format must be csv or parquet")
+	ErrInvalidExportMarket     = errors.New("market must be crypto or forex")
+	ErrExportTimeFramesInvalid = errors.New("tf must list 1 to 10 timeframes of minutes, hours, days or weeks such as 15m, 4h, 1d or 1w")
+	ErrExportTooManyTickers    = errors.New("at most 200 tickers per export")
+	ErrInvalidExportRange      = errors.New("end_date must be after start_date")
+	ErrExportTooLarge          = errors.New("export is too large to stream, create an export job instead")
+	ErrExportJobNotFound       = errors.New("export job not found")
+	ErrExportJobNotCompleted   = errors.New("export job has not completed")
+	ErrTooManyExportJobs       = errors.New("at most 3 export jobs can be pending or running at once, wait for one to complete")
+)
+
+// OHLCVExportRequest exports the candles of the tickers, all pairs when empty, for each timeframe in
+// [StartDate, EndDate]
+type OHLCVExportRequest struct {
+	Market     string     `json:"market"`
+	Tickers    []string   `json:"tickers"`
+	TimeFrames []string   `json:"timeframes"`
+	StartDate  time.Time  `json:"start_date"`
+	EndDate    *time.Time `json:"end_date,omitempty"`
+	Timezone   string     `json:"timezone,omitempty"`
+	Format     string     `json:"format"`
+}
+
+// FileName names the export after its market and range, such as ohlcv_crypto_20240101_20240201.csv.gz
+func (r OHLCVExportRequest) FileName() string {
+	end := time.Now()
+	if r.EndDate != nil {
+		end = *r.EndDate
+	}
+	return fmt.Sprintf("ohlcv_%s_%s_%s%s", r.Market, r.StartDate.Format("20060102"), end.Format("20060102"),
+		OHLCVExportExtension(r.Format))
+}
+
+func OHLCVExportExtension(format string) string {
+	if format == OHLCVExportParquet {
+		return ".parquet"
+	}
+	return ".csv.gz"
+}
+
+// OHLCVExportRow is a row of an export, sorted by timeframe, time and ticker
+type OHLCVExportRow struct {
+	TimeFrame string    `parquet:"tf,dict" json:"tf"`
+	Time      time.Time `parquet:"time,timestamp(millisecond)" json:"time"`
+	Ticker    string    `parquet:"ticker,dict" json:"ticker"`
+	Open      float64   `parquet:"open" json:"open"`
+	High      float64   `parquet:"high" json:"high"`
+	Low       float64   `parquet:"low" json:"low"`
+	Close     float64   `parquet:"close" json:"close"`
+	Volume    float64   `parquet:"volume" json:"volume"`
+}
+
+type OHLCVExportJob struct {
+	ID          string             `json:"id"`
+	Status      string             `json:"status"`
+	Request     OHLCVExportRequest `json:"request"`
+	Rows        int64              `json:"rows"`
+	Error       string             `json:"error,omitempty"`
+	DownloadURL string             `json:"download_url,omitempty"`
+	CreatedAt   time.Time          `json:"created_at"`
+	CompletedAt *time.Time         `json:"completed_at,omitempty"`
+	// ExpiresAt is when the file of a completed job is deleted
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	UserUUID  string     `json:"-"`
+}
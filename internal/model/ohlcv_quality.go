@@ -0,0 +1,91 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// Kinds of OHLCV data quality findings
+const (
+	OHLCVIssueGap = "gap"
+	// OHLCVIssueDuplicate is a bucket with more than one candle of a ticker
+	OHLCVIssueDuplicate = "duplicate"
+	// OHLCVIssueNonMonotonic is a candle off the timeframe grid or in the future
+	OHLCVIssueNonMonotonic = "non_monotonic"
+	OHLCVIssueZeroVolume   = "zero_volume"
+	// OHLCVIssueInconsistent is a candle whose high is below its low, open or close, or whose low is above them
+	OHLCVIssueInconsistent = "ohlc_inconsistent"
+	// OHLCVIssueStale is a ticker, or a table when it has no ticker, whose latest candle is too old
+	OHLCVIssueStale = "stale"
+)
+
+const (
+	DefaultOHLCVQualityLimit = 500
+	MaxOHLCVQualityLimit     = 5000
+)
+
+var (
+	ErrInvalidOHLCVIssue       = errors.New("kind must be gap, duplicate, non_monotonic, zero_volume, ohlc_inconsistent or stale")
+	ErrOHLCVQualityRunNotFound = errors.New("data quality scan not found")
+	ErrOHLCVQualityScanRunning = errors.New("a data quality scan is already running")
+)
+
+func IsValidOHLCVIssue(kind string) bool {
+	switch kind {
+	case OHLCVIssueGap, OHLCVIssueDuplicate, OHLCVIssueNonMonotonic, OHLCVIssueZeroVolume, OHLCVIssueInconsistent,
+		OHLCVIssueStale:
+		return true
+	}
+	return false
+}
+
+// OHLCVTable is a Timescale OHLCV table or continuous aggregate named {type}_{tf}
+type OHLCVTable struct {
+	Name       string
+	DataType   string
+	TimeColumn string
+	// TimeFrame is zero when the tf suffix is not a fixed duration
+	TimeFrame time.Duration
+	// Writable tables hold raw candles, continuous aggregates are derived from them and cannot be backfilled
+	Writable bool
+}
+
+// OHLCVQualityFinding is an issue of the candles of a ticker between StartTime and EndTime. Candles is how many
+// candles are missing, extra or affected, Repaired how many missing ones were backfilled.
+type OHLCVQualityFinding struct {
+	ID        int64     `json:"id"`
+	RunAt     time.Time `json:"run_at"`
+	TableName string    `json:"table_name"`
+	Ticker    string    `json:"ticker"`
+	Kind      string    `json:"kind"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Candles   int       `json:"candles"`
+	Repaired  int       `json:"repaired"`
+	Detail    string    `json:"detail"`
+}
+
+// OHLCVQualityRun is one scan of every OHLCV table
+type OHLCVQualityRun struct {
+	RunAt      time.Time `json:"run_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	Tables     int       `json:"tables"`
+	Findings   int       `json:"findings"`
+	Repaired   int       `json:"repaired"`
+}
+
+// OHLCVQualityFilter selects findings of a run, the latest one when RunAt is nil
+type OHLCVQualityFilter struct {
+	RunAt  *time.Time
+	Table  string
+	Ticker string
+	Kind   string
+	Limit  int
+}
+
+type OHLCVQualityReport struct {
+	Run OHLCVQualityRun `json:"run"`
+	// Summary counts the findings matching the filter by kind
+	Summary  map[string]int        `json:"summary"`
+	Findings []OHLCVQualityFinding `json:"findings"`
+}
@@ -1,9 +1,12 @@
 package model
 
 import (
+	"errors"
 	"time"
 )
 
+var ErrInvalidHoldingPeriod = errors.New("invalid holding period")
+
 type AuthorNav struct {
 	AuthorUsername  string  `json:"authorUsername"`
 	AuthorName      string  `json:"authorName"`
@@ -123,3 +126,26 @@ type AuthorDetail struct {
 	BearishTokens   []SentimentToken         `json:"bearishTokens"`
 	BullishTokens   []SentimentToken         `json:"bullishTokens"`
 }
+
+// MonthlyReturn is one year row of the monthly returns calendar, returns are in percent and nil when the month has no data
+type MonthlyReturn struct {
+	Year       int        `json:"year"`
+	Months     []*float64 `json:"months"`
+	YearReturn *float64   `json:"yearReturn"`
+}
+
+// RollingStat holds the trailing window statistics ending at Datetime
+type RollingStat struct {
+	Datetime    time.Time `json:"datetime"`
+	Return      float64   `json:"return"`
+	Volatility  float64   `json:"volatility"`
+	MaxDrawdown float64   `json:"maxDrawdown"`
+}
+
+type AuthorReturnStats struct {
+	AuthorUsername string          `json:"authorUsername"`
+	HoldingPeriod  string          `json:"holdingPeriod"`
+	MonthlyReturns []MonthlyReturn `json:"monthlyReturns"`
+	Rolling30D     []RollingStat   `json:"rolling30D"`
+	Rolling90D     []RollingStat   `json:"rolling90D"`
+}
@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 type Get24HoursPriceBySymbolsReq struct {
 	Symbols []string `json:"symbols"` // ["BTC", "ETH"]
 }
@@ -13,3 +15,17 @@ type ScannerResponse struct {
 	TotalCount int           `json:"totalCount"`
 	Data       []ScannerData `json:"data"`
 }
+
+// MarketPrice is the latest market data of an asset. Providers report their own symbol, the price cache keys it by
+// canonical ticker. Values a provider does not have are nil.
+type MarketPrice struct {
+	Symbol string   `json:"symbol"`
+	Price  *float64 `json:"price"`
+	// Change24h is the 24 hour change in percent
+	Change24h *float64 `json:"change_24h"`
+	// Volume24h is the 24 hour volume in USD
+	Volume24h *float64  `json:"volume_24h"`
+	MarketCap *float64  `json:"market_cap"`
+	Source    string    `json:"source"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
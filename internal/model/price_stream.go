@@ -0,0 +1,53 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// Actions of a price stream client message
+const (
+	PriceStreamSubscribe   = "subscribe"
+	PriceStreamUnsubscribe = "unsubscribe"
+)
+
+// Types of a price stream event
+const (
+	PriceEventQuote        = "quote"
+	PriceEventSubscribed   = "subscribed"
+	PriceEventUnsubscribed = "unsubscribed"
+	PriceEventError        = "error"
+)
+
+const MaxPriceStreamSymbols = 50
+
+var (
+	ErrTooManyPriceSymbols      = errors.New("at most 50 symbols per connection")
+	ErrInvalidPriceStreamAction = errors.New("action must be subscribe or unsubscribe")
+)
+
+// PriceQuote is the latest quote of a TradingView symbol such as BINANCE:BTCUSDT
+type PriceQuote struct {
+	Symbol        string    `json:"symbol"`
+	Price         float64   `json:"price"`
+	Change        float64   `json:"change"`
+	ChangePercent float64   `json:"change_percent"`
+	Volume        float64   `json:"volume"`
+	Bid           float64   `json:"bid,omitempty"`
+	Ask           float64   `json:"ask,omitempty"`
+	Time          time.Time `json:"time"`
+}
+
+// PriceStreamRequest is a message from a price stream client. Symbols without an exchange prefix are on BINANCE.
+type PriceStreamRequest struct {
+	Action  string   `json:"action"`
+	Symbols []string `json:"symbols"`
+}
+
+// PriceStreamEvent is a message to a price stream client
+type PriceStreamEvent struct {
+	Type    string      `json:"type"`
+	Quote   *PriceQuote `json:"quote,omitempty"`
+	Symbols []string    `json:"symbols,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
@@ -0,0 +1,90 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrPromptVersionsRequired         = errors.New("version_a and version_b are required and must differ")
+	ErrInvalidPromptComparisonRange   = errors.New("from_date must be before to_date and at most 90 days earlier")
+	ErrInvalidPromptComparisonHorizon = errors.New("horizon_hours must be between 1 and 720")
+	ErrPromptVersionRequired          = errors.New("prompt_version is required")
+	ErrPromptVersionNotFound          = errors.New("no signals were extracted by this prompt version")
+)
+
+// PromptVersionStats summarizes the signals extracted by one prompt version
+type PromptVersionStats struct {
+	PromptVersion string    `json:"prompt_version" db:"prompt_version"`
+	Signals       int       `json:"signals" db:"signals"`
+	Tweets        int       `json:"tweets" db:"tweets"`
+	FirstSignalAt time.Time `json:"first_signal_at" db:"first_signal_at"`
+	LastSignalAt  time.Time `json:"last_signal_at" db:"last_signal_at"`
+	// Active is set on the version the feeds, winrate and NAVs read signals from
+	Active bool `json:"active" db:"active"`
+}
+
+// ActivePromptVersionRequest switches the signals every reader uses to the given prompt version
+type ActivePromptVersionRequest struct {
+	PromptVersion string `json:"prompt_version"`
+}
+
+type PromptComparisonRequest struct {
+	VersionA     string
+	VersionB     string
+	FromDate     time.Time
+	ToDate       time.Time
+	HorizonHours int
+}
+
+// PromptVersionSignal is the latest signal of a prompt version on a tweet and canonical ticker
+type PromptVersionSignal struct {
+	TweetID        string    `db:"tweet_id"`
+	AuthorUsername string    `db:"author_username"`
+	TweetCreatedAt time.Time `db:"tweet_created_at"`
+	PromptVersion  string    `db:"prompt_version"`
+	Ticker         string    `db:"ticker"`
+	Action         string    `db:"action"`
+}
+
+// PromptTickerComparison counts how the two versions compare on the signals of one ticker
+type PromptTickerComparison struct {
+	Ticker                 string  `json:"ticker"`
+	Agreements             int     `json:"agreements"`
+	DirectionDisagreements int     `json:"direction_disagreements"`
+	OnlyA                  int     `json:"only_a"`
+	OnlyB                  int     `json:"only_b"`
+	Disagreements          int     `json:"disagreements"`
+	AgreementRate          float64 `json:"agreement_rate"`
+}
+
+// PromptHitRate is the share of the LONG/SHORT signals of a version whose forward return went their way
+type PromptHitRate struct {
+	PromptVersion string  `json:"prompt_version"`
+	Signals       int     `json:"signals"`
+	Evaluated     int     `json:"evaluated"`
+	Hits          int     `json:"hits"`
+	HitRate       float64 `json:"hit_rate"`
+	AvgReturnPct  float64 `json:"avg_return_pct"`
+}
+
+type PromptComparison struct {
+	VersionA     string    `json:"version_a"`
+	VersionB     string    `json:"version_b"`
+	FromDate     time.Time `json:"from_date"`
+	ToDate       time.Time `json:"to_date"`
+	HorizonHours int       `json:"horizon_hours"`
+	// SharedTweets is the number of tweets both versions extracted signals from, everything below is on those tweets
+	SharedTweets           int     `json:"shared_tweets"`
+	ComparedSignals        int     `json:"compared_signals"`
+	Agreements             int     `json:"agreements"`
+	DirectionDisagreements int     `json:"direction_disagreements"`
+	OnlyA                  int     `json:"only_a"`
+	OnlyB                  int     `json:"only_b"`
+	AgreementRate          float64 `json:"agreement_rate"`
+	// Tickers are sorted by disagreements, most first
+	Tickers []PromptTickerComparison `json:"tickers"`
+	// HitRates is empty when OHLCV is unavailable
+	HitRates   []PromptHitRate `json:"hit_rates"`
+	ComputedAt time.Time       `json:"computed_at"`
+}
@@ -0,0 +1,49 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidSentimentBucket   = errors.New("bucket must be one of 1h, 4h, 1d")
+	ErrSentimentTickersRequired = errors.New("at least one ticker is required")
+	ErrSentimentTooManyTickers  = errors.New("too many tickers")
+	ErrSentimentRangeTooLarge   = errors.New("date range has too many buckets, use a larger bucket")
+	ErrSentimentInvalidRange    = errors.New("from_date must be before to_date")
+)
+
+// SentimentTimeSeriesQuery selects the tweet sentiment of one or many tickers, bucketed by Bucket (1h, 4h or 1d)
+type SentimentTimeSeriesQuery struct {
+	Tickers []string
+	Bucket  string
+	From    time.Time
+	To      time.Time
+	Authors []string
+}
+
+// SentimentBucketCount is one row of the aggregated sentiment of a ticker in a bucket
+type SentimentBucketCount struct {
+	Ticker      string    `db:"ticker"`
+	BucketStart time.Time `db:"bucket_start"`
+	Positive    int       `db:"positive"`
+	Neutral     int       `db:"neutral"`
+	Negative    int       `db:"negative"`
+}
+
+type SentimentPoint struct {
+	Time     time.Time `json:"time"`
+	Positive int       `json:"positive"`
+	Neutral  int       `json:"neutral"`
+	Negative int       `json:"negative"`
+	Mentions int       `json:"mentions"`
+	// NetSentiment is (positive - negative) / mentions, between -1 and 1
+	NetSentiment float64 `json:"net_sentiment"`
+}
+
+type TickerSentimentSeries struct {
+	Ticker   string           `json:"ticker"`
+	Bucket   string           `json:"bucket"`
+	Mentions int              `json:"mentions"`
+	Points   []SentimentPoint `json:"points"`
+}
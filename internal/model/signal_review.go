@@ -0,0 +1,151 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrInvalidSignalFlagReason   = errors.New("reason must be bad_ticker, wrong_direction or not_a_call")
+	ErrInvalidSignalReviewStatus = errors.New("status must be pending, corrected, voided, confirmed or all")
+	ErrInvalidSignalCorrection   = errors.New("a correction needs a ticker, a LONG/SHORT/NONE action or a Bullish/Bearish/Neutral sentiment")
+	ErrSignalKeyRequired         = errors.New("tweet_id and ticker are required")
+	ErrSignalNotFound            = errors.New("signal not found")
+)
+
+// Reasons a user can flag a signal for
+const (
+	SignalFlagBadTicker      = "bad_ticker"
+	SignalFlagWrongDirection = "wrong_direction"
+	SignalFlagNotACall       = "not_a_call"
+)
+
+const (
+	SignalReviewStatusPending   = "pending"
+	SignalReviewStatusCorrected = "corrected"
+	SignalReviewStatusVoided    = "voided"
+	SignalReviewStatusConfirmed = "confirmed"
+)
+
+// Events of the signal review audit trail, the review statuses besides flagged
+const SignalReviewEventFlagged = "flagged"
+
+// SignalFlagRequest reports a wrong extraction. A signal is identified by its tweet, ticker and prompt version, an
+// empty prompt version picks the signal of the active version.
+type SignalFlagRequest struct {
+	TweetID       string `json:"tweet_id"`
+	Ticker        string `json:"ticker"`
+	PromptVersion string `json:"prompt_version"`
+	Reason        string `json:"reason"`
+	Comment       string `json:"comment"`
+}
+
+type SignalReviewQuery struct {
+	Status        string
+	Reason        string
+	PromptVersion string
+	Page          int
+	Limit         int
+}
+
+// SignalReviewItem puts a signal as extracted next to its tweet and the review state
+type SignalReviewItem struct {
+	TweetID         string    `json:"tweet_id" db:"tweet_id"`
+	TweetText       string    `json:"tweet_text" db:"tweet_text"`
+	TweetURL        string    `json:"tweet_url" db:"tweet_url"`
+	AuthorUsername  string    `json:"author_username" db:"author_username"`
+	TweetCreatedAt  time.Time `json:"tweet_created_at" db:"tweet_created_at"`
+	Ticker          string    `json:"ticker" db:"ticker"`
+	Action          string    `json:"action" db:"action"`
+	Sentiment       string    `json:"sentiment" db:"sentiment"`
+	Score           *float64  `json:"score" db:"score"`
+	Content         string    `json:"content" db:"content"`
+	PromptVersion   string    `json:"prompt_version" db:"prompt_version"`
+	SignalCreatedAt time.Time `json:"signal_created_at" db:"signal_created_at"`
+
+	Status             string         `json:"status" db:"status"`
+	FlagCount          int            `json:"flag_count" db:"flag_count"`
+	Reasons            pq.StringArray `json:"reasons" db:"reasons" swaggertype:"array,string"`
+	CorrectedTicker    *string        `json:"corrected_ticker" db:"corrected_ticker"`
+	CorrectedAction    *string        `json:"corrected_action" db:"corrected_action"`
+	CorrectedSentiment *string        `json:"corrected_sentiment" db:"corrected_sentiment"`
+	Note               string         `json:"note" db:"note"`
+	ReviewedBy         *string        `json:"reviewed_by" db:"reviewed_by"`
+	ReviewedAt         *time.Time     `json:"reviewed_at" db:"reviewed_at"`
+	UpdatedAt          time.Time      `json:"updated_at" db:"updated_at"`
+}
+
+type SignalReviewPage struct {
+	Items []SignalReviewItem `json:"items"`
+	Total int                `json:"total"`
+	Page  int                `json:"page"`
+	Limit int                `json:"limit"`
+}
+
+// SignalCorrectionRequest overrides the ticker, action or sentiment of a signal, empty fields keep the extraction
+type SignalCorrectionRequest struct {
+	TweetID            string `json:"tweet_id"`
+	Ticker             string `json:"ticker"`
+	PromptVersion      string `json:"prompt_version"`
+	CorrectedTicker    string `json:"corrected_ticker"`
+	CorrectedAction    string `json:"corrected_action"`
+	CorrectedSentiment string `json:"corrected_sentiment"`
+	Note               string `json:"note"`
+}
+
+// SignalDecisionRequest voids a signal or confirms the extraction was right
+type SignalDecisionRequest struct {
+	TweetID       string `json:"tweet_id"`
+	Ticker        string `json:"ticker"`
+	PromptVersion string `json:"prompt_version"`
+	Note          string `json:"note"`
+}
+
+// SignalReviewDecision is what the repo stores for a reviewed signal
+type SignalReviewDecision struct {
+	TweetID            string
+	Ticker             string
+	PromptVersion      string
+	Status             string
+	CorrectedTicker    *string
+	CorrectedAction    *string
+	CorrectedSentiment *string
+	Note               string
+	ReviewedBy         string
+}
+
+type SignalReviewAudit struct {
+	ID                 int64     `json:"id" db:"id"`
+	TweetID            string    `json:"tweet_id" db:"tweet_id"`
+	Ticker             string    `json:"ticker" db:"ticker"`
+	PromptVersion      string    `json:"prompt_version" db:"prompt_version"`
+	Event              string    `json:"event" db:"event"`
+	Actor              string    `json:"actor" db:"actor"`
+	Reason             string    `json:"reason" db:"reason"`
+	Note               string    `json:"note" db:"note"`
+	CorrectedTicker    *string   `json:"corrected_ticker" db:"corrected_ticker"`
+	CorrectedAction    *string   `json:"corrected_action" db:"corrected_action"`
+	CorrectedSentiment *string   `json:"corrected_sentiment" db:"corrected_sentiment"`
+	CreatedAt          time.Time `json:"created_at" db:"created_at"`
+}
+
+// SignalTrainingExample is a reviewed extraction labelled for prompt evaluation and fine-tuning. Label is the review
+// status, the expected fields are empty for voided signals.
+type SignalTrainingExample struct {
+	TweetID            string         `json:"tweet_id" db:"tweet_id"`
+	TweetText          string         `json:"tweet_text" db:"tweet_text"`
+	AuthorUsername     string         `json:"author_username" db:"author_username"`
+	TweetCreatedAt     time.Time      `json:"tweet_created_at" db:"tweet_created_at"`
+	PromptVersion      string         `json:"prompt_version" db:"prompt_version"`
+	ExtractedTicker    string         `json:"extracted_ticker" db:"extracted_ticker"`
+	ExtractedAction    string         `json:"extracted_action" db:"extracted_action"`
+	ExtractedSentiment string         `json:"extracted_sentiment" db:"extracted_sentiment"`
+	Label              string         `json:"label" db:"label"`
+	ExpectedTicker     string         `json:"expected_ticker" db:"expected_ticker"`
+	ExpectedAction     string         `json:"expected_action" db:"expected_action"`
+	ExpectedSentiment  string         `json:"expected_sentiment" db:"expected_sentiment"`
+	Reasons            pq.StringArray `json:"reasons" db:"reasons" swaggertype:"array,string"`
+	ReviewedAt         time.Time      `json:"reviewed_at" db:"reviewed_at"`
+}
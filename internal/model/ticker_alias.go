@@ -0,0 +1,59 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// Ticker alias kinds
+const (
+	TickerAliasKindSymbol   = "symbol"
+	TickerAliasKindName     = "name"
+	TickerAliasKindPair     = "pair"
+	TickerAliasKindExchange = "exchange"
+)
+
+var (
+	ErrInvalidAssetID         = errors.New("asset id must be 1-32 letters or digits")
+	ErrInvalidTickerAlias     = errors.New("alias must be 1-64 characters")
+	ErrInvalidTickerAliasKind = errors.New("kind must be symbol, name, pair or exchange")
+	ErrAssetNotFound          = errors.New("asset not found")
+	ErrTickerAliasNotFound    = errors.New("alias not found")
+)
+
+// CryptoAsset is a canonical asset, ID is the symbol every alias resolves to
+type CryptoAsset struct {
+	ID        string        `json:"id" db:"id"`
+	Name      string        `json:"name" db:"name"`
+	Aliases   []TickerAlias `json:"aliases" db:"-"`
+	CreatedAt time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at" db:"updated_at"`
+}
+
+type TickerAlias struct {
+	Alias     string    `json:"alias" db:"alias"`
+	AssetID   string    `json:"asset_id" db:"asset_id"`
+	Kind      string    `json:"kind" db:"kind"`
+	Exchange  string    `json:"exchange" db:"exchange"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+type CryptoAssetRequest struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type TickerAliasRequest struct {
+	Alias    string `json:"alias"`
+	AssetID  string `json:"asset_id"`
+	Kind     string `json:"kind"`
+	Exchange string `json:"exchange"`
+}
+
+type ResolvedTicker struct {
+	Input   string `json:"input"`
+	AssetID string `json:"asset_id"`
+	Name    string `json:"name"`
+	// Known is false when the ticker matched no asset and AssetID is only the cleaned input
+	Known bool `json:"known"`
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrTierProposalNotFound   = errors.New("tier proposal not found")
+	ErrTierProposalNotPending = errors.New("tier proposal is not pending")
+)
+
+const (
+	TierProposalStatusPending    = "pending"
+	TierProposalStatusApproved   = "approved"
+	TierProposalStatusRejected   = "rejected"
+	TierProposalStatusSuperseded = "superseded"
+)
+
+// AuthorTierMetrics holds the inputs used by the tier engine to grade an author over its window. Winrate is the share
+// of the author's LONG/SHORT signals in the window that moved their way, evaluated against OHLCV.
+type AuthorTierMetrics struct {
+	AuthorUsername string  `json:"author_username" db:"author_username"`
+	CurrentTier    string  `json:"current_tier" db:"author_tier"`
+	Winrate        float64 `json:"winrate" db:"winrate"`
+	SignalCount    int     `json:"signal_count" db:"signal_count"`
+	MaxDrawdown    float64 `json:"max_drawdown" db:"max_drawdown"`
+	Consistency    float64 `json:"consistency" db:"consistency"`
+}
+
+// AuthorTierProposal is a tier change suggested by the tier engine waiting for CRM review
+type AuthorTierProposal struct {
+	ID             string     `json:"id" db:"id"`
+	AuthorUsername string     `json:"author_username" db:"author_username"`
+	CurrentTier    string     `json:"current_tier" db:"current_tier"`
+	ProposedTier   string     `json:"proposed_tier" db:"proposed_tier"`
+	Winrate        float64    `json:"winrate" db:"winrate"`
+	SignalCount    int        `json:"signal_count" db:"signal_count"`
+	MaxDrawdown    float64    `json:"max_drawdown" db:"max_drawdown"`
+	Consistency    float64    `json:"consistency" db:"consistency"`
+	Status         string     `json:"status" db:"status"`
+	ReviewedBy     *string    `json:"reviewed_by" db:"reviewed_by"`
+	ReviewedAt     *time.Time `json:"reviewed_at" db:"reviewed_at"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at"`
+}
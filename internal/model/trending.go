@@ -0,0 +1,71 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	TrendingWeightNone    = "none"
+	TrendingWeightTier    = "tier"
+	TrendingWeightWinrate = "winrate"
+)
+
+var (
+	ErrInvalidTrendingWeight  = errors.New("weight must be one of none, tier, winrate")
+	ErrInvalidWatchlistTicker = errors.New("invalid ticker")
+)
+
+// TickerMentionWindow is the number of mentions of a ticker by one author in one window. Window 0 is the current
+// window, window n ended n windows ago.
+type TickerMentionWindow struct {
+	Ticker         string `db:"ticker"`
+	AuthorUsername string `db:"author_username"`
+	WindowIndex    int    `db:"window_index"`
+	Mentions       int    `db:"mentions"`
+}
+
+// AuthorWeightInput holds what a mention of the author can be weighted by
+type AuthorWeightInput struct {
+	AuthorUsername string  `db:"author_username"`
+	AuthorTier     string  `db:"author_tier"`
+	Winrate        float64 `db:"winrate"`
+}
+
+type TrendingTicker struct {
+	Rank     int    `json:"rank"`
+	Ticker   string `json:"ticker"`
+	Mentions int    `json:"mentions"`
+	Authors  int    `json:"authors"`
+	// WeightedMentions, BaselineMean and BaselineStd are in weighted mentions per window
+	WeightedMentions float64 `json:"weighted_mentions"`
+	BaselineMean     float64 `json:"baseline_mean"`
+	BaselineStd      float64 `json:"baseline_std"`
+	ZScore           float64 `json:"z_score"`
+	IsTrending       bool    `json:"is_trending"`
+}
+
+type TrendingResult struct {
+	Weight      string           `json:"weight"`
+	WindowHours int              `json:"window_hours"`
+	WindowEnd   time.Time        `json:"window_end"`
+	Tickers     []TrendingTicker `json:"tickers"`
+}
+
+type WatchlistTicker struct {
+	Ticker        string     `json:"ticker" db:"ticker"`
+	TrendingAlert bool       `json:"trending_alert" db:"trending_alert"`
+	LastAlertedAt *time.Time `json:"last_alerted_at" db:"last_alerted_at"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+}
+
+type WatchlistTickerRequest struct {
+	Ticker        string `json:"ticker"`
+	TrendingAlert bool   `json:"trending_alert"`
+}
+
+// WatchlistAlertSubscriber is a user to alert because a ticker on their watchlist started trending
+type WatchlistAlertSubscriber struct {
+	UserUUID string `db:"uuid"`
+	Ticker   string `db:"ticker"`
+}
@@ -0,0 +1,118 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// Periods of a digest, daily digests cover a UTC day and weekly digests a UTC week starting on Monday
+const (
+	DigestPeriodDaily  = "daily"
+	DigestPeriodWeekly = "weekly"
+)
+
+// Channels a digest subscription is delivered through
+const (
+	DigestChannelTelegram = "telegram"
+	DigestChannelEmail    = "email"
+)
+
+var (
+	ErrInvalidDigestPeriod  = errors.New("period must be daily or weekly")
+	ErrInvalidDigestChannel = errors.New("channel must be telegram or email")
+	ErrDigestNotFound       = errors.New("digest not found")
+	ErrDigestNotComplete    = errors.New("period_start must start a period that has ended")
+)
+
+// DigestTopic is the key topic summary of one hour
+type DigestTopic struct {
+	DateTime time.Time `json:"date_time" db:"date_time"`
+	Topic    string    `json:"topic" db:"response_topic"`
+}
+
+// DigestTickerStats counts the signals of tracked authors on a canonical ticker in a period
+type DigestTickerStats struct {
+	Ticker   string `json:"ticker" db:"ticker"`
+	Mentions int    `json:"mentions" db:"mentions"`
+	Authors  int    `json:"authors" db:"authors"`
+	Longs    int    `json:"longs" db:"longs"`
+	Shorts   int    `json:"shorts" db:"shorts"`
+}
+
+type DigestTicker struct {
+	DigestTickerStats
+	PreviousMentions int `json:"previous_mentions"`
+}
+
+// DigestSentimentShift compares the bullish ratio, (longs - shorts) / (longs + shorts), with the previous period
+type DigestSentimentShift struct {
+	Ticker               string  `json:"ticker"`
+	BullishRatio         float64 `json:"bullish_ratio"`
+	PreviousBullishRatio float64 `json:"previous_bullish_ratio"`
+	Shift                float64 `json:"shift"`
+	Signals              int     `json:"signals"`
+}
+
+// DigestAuthor ranks an author by the likes, retweets, replies and quotes of their tweets in the period
+type DigestAuthor struct {
+	AuthorUsername string `json:"author_username" db:"author_username"`
+	Tweets         int    `json:"tweets" db:"tweets"`
+	Engagement     int64  `json:"engagement" db:"engagement"`
+	Views          int64  `json:"views" db:"views"`
+}
+
+// DigestCall is a LONG/SHORT signal of a tracked author on a ticker they had not called in the lookback
+type DigestCall struct {
+	TweetID        string    `json:"tweet_id" db:"tweet_id"`
+	URL            string    `json:"url" db:"url"`
+	AuthorUsername string    `json:"author_username" db:"author_username"`
+	AuthorTier     string    `json:"author_tier" db:"author_tier"`
+	Ticker         string    `json:"ticker" db:"ticker"`
+	Action         string    `json:"action" db:"action"`
+	Engagement     int64     `json:"engagement" db:"engagement"`
+	TweetCreatedAt time.Time `json:"tweet_created_at" db:"tweet_created_at"`
+}
+
+type TweetDigestContent struct {
+	// Topics are the hourly key topics of the period, weekly digests keep the last topic of each day
+	Topics          []DigestTopic          `json:"topics"`
+	TopTickers      []DigestTicker         `json:"top_tickers"`
+	SentimentShifts []DigestSentimentShift `json:"sentiment_shifts"`
+	TopAuthors      []DigestAuthor         `json:"top_authors"`
+	NewCalls        []DigestCall           `json:"new_calls"`
+}
+
+type TweetDigest struct {
+	ID          string    `json:"id" db:"id"`
+	Period      string    `json:"period" db:"period"`
+	PeriodStart time.Time `json:"period_start" db:"period_start"`
+	PeriodEnd   time.Time `json:"period_end" db:"period_end"`
+	TweetDigestContent
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+type DigestSubscription struct {
+	Period     string     `json:"period" db:"period"`
+	Channel    string     `json:"channel" db:"channel"`
+	LastSentAt *time.Time `json:"last_sent_at" db:"last_sent_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+}
+
+type DigestSubscriptionRequest struct {
+	Period  string `json:"period"`
+	Channel string `json:"channel"`
+}
+
+// DigestSubscriber is a subscription that has not received the digest yet, with where to deliver it
+type DigestSubscriber struct {
+	UserUUID       string `db:"uuid"`
+	Channel        string `db:"channel"`
+	TelegramChatID string `db:"telegram_chat_id"`
+	Email          string `db:"email"`
+}
+
+type BuildDigestRequest struct {
+	Period string `json:"period"`
+	// PeriodStart defaults to the last ended period
+	PeriodStart *time.Time `json:"period_start"`
+}
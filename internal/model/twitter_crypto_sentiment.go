@@ -100,12 +100,15 @@ type TwitterCryptoTweetWithSentimentAndAuthor struct {
 }
 
 type TwitterCryptoTweetWithSentimentAndTier struct {
+	TweetID           string    `json:"tweet_id" db:"tweet_id"`
 	ResponseScore     *int      `json:"response_score,omitempty" db:"response_score"`
 	ResponseSentiment string    `json:"response_sentiment,omitempty" db:"response_sentiment"`
 	ResponseTickers   string    `json:"response_tickers,omitempty" db:"response_tickers"`
 	TweetCreatedAt    time.Time `json:"tweet_created_at" db:"tweet_created_at"`
 	TweetCreatedDate  time.Time `json:"tweet_created_date" db:"tweet_created_date"`
 	TwitterCryptoSignal
+	// PageTweets is the number of tweets of the page, including tweets without a signal row
+	PageTweets int `json:"-" db:"page_tweets"`
 }
 
 type TwitterCryptoAuthorWinRate struct {
@@ -148,3 +151,22 @@ type NextCursor struct {
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	ID        string    `json:"id" db:"id"`
 }
+
+// TweetSearchQuery holds the full-text query and the feed filters it is combined with
+type TweetSearchQuery struct {
+	Query                  string
+	Start                  int
+	Limit                  int
+	SortBy                 string // relevance or recency
+	Authors                []string
+	Sentiment              string
+	FromDate               *time.Time
+	ToDate                 *time.Time
+	SearchTokenSymbolValue string
+}
+
+type TwitterCryptoTweetSearchResult struct {
+	TwitterCryptoTweetWithSentimentAndAuthor
+	Rank    float64 `json:"rank" db:"rank"`
+	Snippet string  `json:"snippet" db:"snippet"`
+}
@@ -5,6 +5,7 @@ import (
 )
 
 type TwitterCryptoSummary struct {
+	ID            string    `json:"-" db:"id"`
 	DateTime      time.Time `json:"date_time" db:"date_time"`
 	ResponseTopic string    `json:"response_topic" db:"response_topic"`
 	CreatedAt     time.Time `json:"created_at" db:"created_at"`
@@ -1,5 +1,19 @@
 package model
 
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrWinrateInvalidRule       = errors.New("rule must be one of direction, min_move, sl_tp")
+	ErrWinrateInvalidHorizon    = errors.New("horizon_hours must contain 1 to 10 values between 1 and 2160")
+	ErrWinrateInvalidWeights    = errors.New("weights must match horizon_hours and sum to a positive value")
+	ErrWinrateInvalidDateRange  = errors.New("from_date must be before to_date")
+	ErrWinrateInvalidThresholds = errors.New("min_move_pct, take_profit_pct and stop_loss_pct must be positive for the selected rule")
+	ErrOHLCVUnavailable         = errors.New("ohlcv data source is not available")
+)
+
 type WinRateEntities struct {
 	AuthorId         string  `db:"author_id"`
 	AuthorUsername   string  `db:"author_username"`
@@ -20,3 +34,54 @@ type WinRateModel struct {
 	CryptoWinRate15D float64 `json:"crypto_win_rate_15d"`
 	CryptoWinRate30D float64 `json:"crypto_win_rate_30d"`
 }
+
+const (
+	WinrateRuleDirection = "direction"
+	WinrateRuleMinMove   = "min_move"
+	WinrateRuleSLTP      = "sl_tp"
+)
+
+// WinrateSignal is a directional author signal evaluated by the winrate calculator
+type WinrateSignal struct {
+	TweetID        string    `db:"tweet_id"`
+	AuthorUsername string    `db:"author_username"`
+	Ticker         string    `db:"ticker"`
+	Action         string    `db:"action"`
+	Sentiment      string    `db:"sentiment"`
+	CreatedAt      time.Time `db:"tweet_created_at"`
+}
+
+type WinrateCalcRequest struct {
+	HorizonHours  []int     `json:"horizon_hours" example:"24,72,168"`
+	Weights       []float64 `json:"weights,omitempty" example:"0.5,0.3,0.2"`
+	Rule          string    `json:"rule" example:"direction"`
+	MinMovePct    float64   `json:"min_move_pct,omitempty" example:"2"`
+	TakeProfitPct float64   `json:"take_profit_pct,omitempty" example:"5"`
+	StopLossPct   float64   `json:"stop_loss_pct,omitempty" example:"3"`
+	Tickers       []string  `json:"tickers,omitempty" example:"BTC,ETH"`
+	Authors       []string  `json:"authors,omitempty"`
+	TimeFrame     string    `json:"tf,omitempty" example:"1h"`
+	FromDate      time.Time `json:"from_date" example:"2025-01-01T00:00:00Z"`
+	ToDate        time.Time `json:"to_date" example:"2025-06-30T00:00:00Z"`
+}
+
+type HorizonWinrate struct {
+	HorizonHours int     `json:"horizon_hours"`
+	Wins         int     `json:"wins"`
+	Total        int     `json:"total"`
+	Winrate      float64 `json:"winrate"`
+}
+
+// WinrateCalcResult ranks the authors by blended winrate
+type WinrateCalcResult struct {
+	Authors []AuthorWinrateResult `json:"authors"`
+	// Missing are the signal tickers without candles, their signals are left out of the winrates
+	Missing []string `json:"missing"`
+}
+
+type AuthorWinrateResult struct {
+	AuthorUsername string           `json:"author_username"`
+	Horizons       []HorizonWinrate `json:"horizons"`
+	BlendedWinrate float64          `json:"blended_winrate"`
+	TotalSignals   int              `json:"total_signals"`
+}
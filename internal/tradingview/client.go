@@ -1,13 +1,14 @@
 package tradingview
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math/rand"
 	"net/http"
 	"net/url"
-	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -23,12 +24,10 @@ const (
 	wsTimeout = 5 * time.Second
 )
 
+// TradingViewClient fetches TradingView data, it is safe for concurrent use
 type TradingViewClient struct {
-	token        string
-	ws           *websocket.Conn
-	session      string
-	chartSession string
-	wsDebug      bool
+	token    string
+	sessions *SessionManager
 }
 
 type HistoricalData struct {
@@ -79,9 +78,7 @@ type SearchParams struct {
 }
 
 func NewTradingViewClient(username, password string) (*TradingViewClient, error) {
-	client := &TradingViewClient{
-		wsDebug: false,
-	}
+	client := &TradingViewClient{}
 
 	// Auth
 	token := client.auth(username, password)
@@ -92,8 +89,7 @@ func NewTradingViewClient(username, password string) (*TradingViewClient, error)
 		client.token = token
 	}
 
-	client.session = generateSession()
-	client.chartSession = generateChartSession()
+	client.sessions = NewSessionManager(wsURL, client.token, DefaultPoolSize)
 
 	return client, nil
 }
@@ -145,7 +141,8 @@ func (c *TradingViewClient) auth(username, password string) string {
 	return result.User.AuthToken
 }
 
-func (c *TradingViewClient) createConnection() error {
+// dial opens a websocket to TradingView that answers pings
+func dial(url string) (*websocket.Conn, error) {
 	headers := http.Header{
 		"Origin":          []string{"https://www.tradingview.com"}, // Changed origin
 		"User-Agent":      []string{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36"},
@@ -158,9 +155,9 @@ func (c *TradingViewClient) createConnection() error {
 		Proxy:             http.ProxyFromEnvironment,
 	}
 
-	ws, _, err := dialer.Dial(wsURL, headers)
+	ws, _, err := dialer.Dial(url, headers)
 	if err != nil {
-		return fmt.Errorf("websocket connection failed: %v", err)
+		return nil, fmt.Errorf("websocket connection failed: %v", err)
 	}
 
 	// Set up ping handler
@@ -168,8 +165,7 @@ func (c *TradingViewClient) createConnection() error {
 		return ws.WriteControl(websocket.PongMessage, []byte(appData), time.Now().Add(10*time.Second))
 	})
 
-	c.ws = ws
-	return nil
+	return ws, nil
 }
 
 func (c *TradingViewClient) GetHistoricalData(
@@ -183,109 +179,36 @@ func (c *TradingViewClient) GetHistoricalData(
 	// Format symbol
 	formattedSymbol := formatSymbol(symbol, exchange, futContract)
 
-	// Try up to 3 times
+	// Try up to 3 times, a dropped connection is replaced on the next attempt
 	var lastErr error
-	for attempt := 0; attempt < 3; attempt++ {
-		// Create connection
-		if err := c.createConnection(); err != nil {
-			lastErr = err
-			time.Sleep(time.Second)
-			continue
+	attempts := 0
+	for attempts < 3 {
+		attempts++
+		ctx, cancel := context.WithTimeout(context.Background(), chartRequestTimeout)
+		data, err := c.sessions.GetHistoricalData(ctx, formattedSymbol, interval, bars, extendedSession)
+		cancel()
+		if err == nil {
+			return data, nil
 		}
-
-		data, err := c.getHistoricalDataWithConnection(formattedSymbol, interval, bars, extendedSession)
-		if err != nil {
-			c.ws.Close()
-			lastErr = err
-			time.Sleep(time.Second)
-			continue
+		lastErr = err
+		if errors.Is(err, ErrInvalidSymbol) {
+			break
 		}
-
-		return data, nil
+		time.Sleep(time.Second)
 	}
 
-	return nil, fmt.Errorf("failed after 1 attempts: %v", lastErr)
+	return nil, fmt.Errorf("failed after %d attempts: %v", attempts, lastErr)
 }
 
-func (c *TradingViewClient) getHistoricalDataWithConnection(
+// SubscribeBars streams live bars of the symbol until ctx is done, see SessionManager.SubscribeBars
+func (c *TradingViewClient) SubscribeBars(
+	ctx context.Context,
 	symbol string,
+	exchange string,
 	interval string,
-	bars int,
-	extendedSession bool,
-) ([]HistoricalData, error) {
-	defer c.ws.Close()
-
-	// Send messages
-	messages := []struct {
-		function string
-		args     []interface{}
-	}{
-		{"set_auth_token", []interface{}{c.token}},
-		{"chart_create_session", []interface{}{c.chartSession, ""}},
-		{"resolve_symbol", []interface{}{c.chartSession, "symbol_1", fmt.Sprintf(`={"symbol":"%s","adjustment":"splits","session":"%s"}`,
-			symbol, map[bool]string{false: "regular", true: "extended"}[extendedSession])}},
-		{"create_series", []interface{}{c.chartSession, "s1", "s1", "symbol_1", interval, bars}},
-	}
-
-	// Send messages with delay
-	for _, msg := range messages {
-		if err := c.sendMessage(msg.function, msg.args); err != nil {
-			return nil, fmt.Errorf("failed to send message %s: %v", msg.function, err)
-		}
-		time.Sleep(500 * time.Millisecond)
-	}
-
-	// Read response
-	var rawData strings.Builder
-	done := make(chan bool)
-	errChan := make(chan error)
-
-	go func() {
-		for {
-			_, message, err := c.ws.ReadMessage()
-			if err != nil {
-				if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
-					if rawData.Len() > 0 {
-						done <- true
-						return
-					}
-				}
-				errChan <- err
-				return
-			}
-
-			rawData.Write(message)
-			rawData.WriteString("\n")
-
-			if strings.Contains(string(message), "series_completed") ||
-				strings.Contains(string(message), "timescale_update") {
-				done <- true
-				return
-			}
-		}
-	}()
-
-	select {
-	case <-done:
-		return createDataFrame(rawData.String(), symbol)
-	case err := <-errChan:
-		return nil, fmt.Errorf("websocket error: %v", err)
-	case <-time.After(10 * time.Second):
-		return nil, fmt.Errorf("timeout waiting for data")
-	}
-}
-
-func (c *TradingViewClient) sendMessage(function string, args []interface{}) error {
-	msg := createMessage(function, args)
-	if c.wsDebug {
-		fmt.Println(msg)
-	}
-
-	// Set write deadline
-	c.ws.SetWriteDeadline(time.Now().Add(5 * time.Second))
-	err := c.ws.WriteMessage(websocket.TextMessage, []byte(prependHeader(msg)))
-	c.ws.SetWriteDeadline(time.Time{}) // Reset deadline
-	return err
+	onBar func(HistoricalData),
+) error {
+	return c.sessions.SubscribeBars(ctx, formatSymbol(symbol, exchange, nil), interval, onBar)
 }
 
 // Helper functions
@@ -329,60 +252,6 @@ func prependHeader(msg string) string {
 	return fmt.Sprintf("~m~%d~m~%s", len(msg), msg)
 }
 
-func createDataFrame(rawData, symbol string) ([]HistoricalData, error) {
-	re := regexp.MustCompile(`"s":\[(.+?)\}\]`)
-	matches := re.FindStringSubmatch(rawData)
-	if len(matches) < 2 {
-		return nil, fmt.Errorf("no data found")
-	}
-
-	dataPoints := strings.Split(matches[1], ",{")
-	var result []HistoricalData
-	for _, point := range dataPoints {
-		fields := strings.FieldsFunc(strings.Trim(point, "{}[]"), func(r rune) bool {
-			return r == '[' || r == ':' || r == ',' || r == ']'
-		})
-		if len(fields) < 9 {
-			continue
-		}
-
-		// Parse and convert timestamp
-		ts, err := strconv.ParseFloat(fields[3], 64)
-		if err != nil {
-			continue
-		}
-		if ts > 10000000000 { // Convert milliseconds to seconds if needed
-			ts = ts / 1000
-		}
-		datetime := time.Unix(int64(ts), 0).UTC() // Convert to UTC
-
-		// Parse OHLCV values
-		open, _ := strconv.ParseFloat(fields[4], 64)
-		high, _ := strconv.ParseFloat(fields[5], 64)
-		low, _ := strconv.ParseFloat(fields[6], 64)
-		close, _ := strconv.ParseFloat(fields[7], 64)
-		volume, _ := strconv.ParseFloat(fields[8], 64)
-
-		// Store data with the DateTime in the desired format
-		data := HistoricalData{
-			DateTime: datetime,
-			Symbol:   symbol,
-			Open:     open,
-			High:     high,
-			Low:      low,
-			Close:    close,
-			Volume:   volume,
-		}
-		result = append(result, data)
-	}
-
-	if len(result) == 0 {
-		return nil, fmt.Errorf("no valid data points found")
-	}
-
-	return result, nil
-}
-
 // Modify SearchSymbol to support pagination
 func (c *TradingViewClient) SearchSymbol(params SearchParams) (*SearchResponse, error) {
 	if params.Limit == 0 {
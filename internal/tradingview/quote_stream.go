@@ -0,0 +1,269 @@
+package tradingview
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/quantsmithapp/datastation-backend/internal/model"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
+)
+
+const (
+	// TradingView sends a heartbeat about every 10 seconds, a connection silent for longer than this is dead
+	quoteStreamReadTimeout = 30 * time.Second
+	quoteStreamMaxBackoff  = time.Minute
+)
+
+var (
+	packetSeparator = regexp.MustCompile(`~m~\d+~m~`)
+	quoteFields     = []interface{}{"lp", "lp_time", "ch", "chp", "volume", "bid", "ask"}
+)
+
+// QuoteStream keeps one quote session open on TradingView and reports every update of the symbols it is subscribed
+// to. Symbols are re-added when the connection is restored.
+type QuoteStream struct {
+	token   string
+	onQuote func(model.PriceQuote)
+	wake    chan struct{}
+
+	mu      sync.Mutex // Guards the fields below and writes to ws
+	ws      *websocket.Conn
+	session string
+	symbols map[string]bool
+	quotes  map[string]*model.PriceQuote
+}
+
+// quoteValues are the fields of a quote update, TradingView only sends the ones that changed
+type quoteValues struct {
+	Price         *float64 `json:"lp"`
+	PriceTime     *int64   `json:"lp_time"`
+	Change        *float64 `json:"ch"`
+	ChangePercent *float64 `json:"chp"`
+	Volume        *float64 `json:"volume"`
+	Bid           *float64 `json:"bid"`
+	Ask           *float64 `json:"ask"`
+}
+
+// NewQuoteStream creates a quote stream with the client's token, onQuote is called from the stream's goroutine
+func (c *TradingViewClient) NewQuoteStream(onQuote func(model.PriceQuote)) *QuoteStream {
+	return &QuoteStream{
+		token:   c.token,
+		onQuote: onQuote,
+		wake:    make(chan struct{}, 1),
+		symbols: make(map[string]bool),
+		quotes:  make(map[string]*model.PriceQuote),
+	}
+}
+
+// Run connects once a symbol is subscribed and reads quotes until ctx is done, reconnecting with backoff when the
+// connection drops
+func (s *QuoteStream) Run(ctx context.Context) {
+	backoff := time.Second
+	for {
+		// No connection until someone listens
+		for !s.hasSymbols() {
+			select {
+			case <-ctx.Done():
+				return
+			case <-s.wake:
+			}
+		}
+
+		connectedAt := time.Now()
+		err := s.runSession(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if time.Since(connectedAt) > quoteStreamMaxBackoff {
+			backoff = time.Second
+		}
+		logger.Errorf("tradingview: quote stream disconnected, reconnecting in %s: %v", backoff, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, quoteStreamMaxBackoff)
+	}
+}
+
+// Subscribe adds the symbol, such as BINANCE:BTCUSDT, to the quote session
+func (s *QuoteStream) Subscribe(symbol string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.symbols[symbol] {
+		return nil
+	}
+	s.symbols[symbol] = true
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	if s.ws == nil {
+		return nil
+	}
+	return s.sendLocked("quote_add_symbols", s.session, symbol)
+}
+
+// Unsubscribe removes the symbol from the quote session
+func (s *QuoteStream) Unsubscribe(symbol string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.symbols[symbol] {
+		return nil
+	}
+	delete(s.symbols, symbol)
+	delete(s.quotes, symbol)
+	if s.ws == nil {
+		return nil
+	}
+	return s.sendLocked("quote_remove_symbols", s.session, symbol)
+}
+
+func (s *QuoteStream) hasSymbols() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.symbols) > 0
+}
+
+func (s *QuoteStream) runSession(ctx context.Context) error {
+	ws, err := dial(wsURL)
+	if err != nil {
+		return err
+	}
+	// Closing the connection unblocks the read when ctx is done
+	stop := context.AfterFunc(ctx, func() { ws.Close() })
+	defer stop()
+	defer func() {
+		s.mu.Lock()
+		s.ws = nil
+		s.mu.Unlock()
+		ws.Close()
+	}()
+
+	if err := s.openSession(ws); err != nil {
+		return err
+	}
+
+	for {
+		ws.SetReadDeadline(time.Now().Add(quoteStreamReadTimeout))
+		_, message, err := ws.ReadMessage()
+		if err != nil {
+			return err
+		}
+		for _, packet := range packetSeparator.Split(string(message), -1) {
+			if packet == "" {
+				continue
+			}
+			// Heartbeats are echoed back or TradingView closes the connection
+			if strings.HasPrefix(packet, "~h~") {
+				s.mu.Lock()
+				err := s.writeLocked(prependHeader(packet))
+				s.mu.Unlock()
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			s.handlePacket(packet)
+		}
+	}
+}
+
+// openSession creates the quote session on the connection and adds the subscribed symbols
+func (s *QuoteStream) openSession(ws *websocket.Conn) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ws = ws
+	s.session = generateSession()
+	clear(s.quotes)
+
+	if err := s.sendLocked("set_auth_token", s.token); err != nil {
+		return err
+	}
+	if err := s.sendLocked("quote_create_session", s.session); err != nil {
+		return err
+	}
+	if err := s.sendLocked("quote_set_fields", append([]interface{}{s.session}, quoteFields...)...); err != nil {
+		return err
+	}
+	if len(s.symbols) == 0 {
+		return nil
+	}
+	args := []interface{}{s.session}
+	for symbol := range s.symbols {
+		args = append(args, symbol)
+	}
+	return s.sendLocked("quote_add_symbols", args...)
+}
+
+// handlePacket merges a quote update into the symbol's last quote and reports it once it has a price
+func (s *QuoteStream) handlePacket(packet string) {
+	var msg struct {
+		Method string            `json:"m"`
+		Params []json.RawMessage `json:"p"`
+	}
+	if err := json.Unmarshal([]byte(packet), &msg); err != nil || msg.Method != "qsd" || len(msg.Params) < 2 {
+		return
+	}
+	var data struct {
+		Name   string      `json:"n"`
+		Status string      `json:"s"`
+		Values quoteValues `json:"v"`
+	}
+	if err := json.Unmarshal(msg.Params[1], &data); err != nil {
+		return
+	}
+	if data.Status != "ok" {
+		logger.Errorf("tradingview: quote of %s failed with status %s", data.Name, data.Status)
+		return
+	}
+
+	s.mu.Lock()
+	if !s.symbols[data.Name] {
+		s.mu.Unlock()
+		return
+	}
+	quote, ok := s.quotes[data.Name]
+	if !ok {
+		quote = &model.PriceQuote{Symbol: data.Name}
+		s.quotes[data.Name] = quote
+	}
+	v := data.Values
+	setFloat := func(dst *float64, src *float64) {
+		if src != nil {
+			*dst = *src
+		}
+	}
+	setFloat(&quote.Price, v.Price)
+	setFloat(&quote.Change, v.Change)
+	setFloat(&quote.ChangePercent, v.ChangePercent)
+	setFloat(&quote.Volume, v.Volume)
+	setFloat(&quote.Bid, v.Bid)
+	setFloat(&quote.Ask, v.Ask)
+	if v.PriceTime != nil {
+		quote.Time = time.Unix(*v.PriceTime, 0).UTC()
+	} else if v.Price != nil {
+		quote.Time = time.Now().UTC()
+	}
+	update := *quote
+	s.mu.Unlock()
+
+	if update.Price != 0 {
+		s.onQuote(update)
+	}
+}
+
+func (s *QuoteStream) sendLocked(function string, args ...interface{}) error {
+	return s.writeLocked(prependHeader(createMessage(function, args)))
+}
+
+func (s *QuoteStream) writeLocked(message string) error {
+	s.ws.SetWriteDeadline(time.Now().Add(wsTimeout))
+	return s.ws.WriteMessage(websocket.TextMessage, []byte(message))
+}
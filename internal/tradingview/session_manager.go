@@ -0,0 +1,468 @@
+package tradingview
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
+)
+
+const (
+	DefaultPoolSize = 4
+	// connReadTimeout is how long a connection may stay silent, TradingView sends a heartbeat about every 10 seconds
+	connReadTimeout     = 30 * time.Second
+	chartRequestTimeout = 15 * time.Second
+	barStreamMaxBackoff = time.Minute
+	seriesID            = "s1"
+)
+
+var (
+	ErrInvalidSymbol    = errors.New("tradingview: invalid symbol")
+	ErrSeriesFailed     = errors.New("tradingview: series failed")
+	ErrConnectionClosed = errors.New("tradingview: connection closed")
+)
+
+// SessionManager keeps a pool of authenticated TradingView connections and multiplexes chart sessions over them, so
+// many symbols can be fetched at the same time. A dropped connection is dialed again on its next use. It is safe for
+// concurrent use.
+type SessionManager struct {
+	url   string
+	token string
+	slots []*connSlot
+	next  atomic.Uint64
+}
+
+type connSlot struct {
+	mu   sync.Mutex
+	conn *chartConn
+}
+
+// chartConn is one pooled connection. Its reader routes the chart session messages to their session.
+type chartConn struct {
+	ws      *websocket.Conn
+	writeMu sync.Mutex
+
+	mu       sync.Mutex // Guards the fields below
+	sessions map[string]*chartSession
+	err      error
+	done     chan struct{}
+}
+
+// chartSession is a series of one symbol. Bars go to onBar when it is set, or are collected until the series completes.
+type chartSession struct {
+	id        string
+	symbol    string
+	conn      *chartConn
+	onBar     func(HistoricalData)
+	completed chan struct{}
+	failed    chan error
+
+	mu   sync.Mutex
+	bars map[int64]HistoricalData
+	done bool
+}
+
+// NewSessionManager creates a pool of poolSize connections to the TradingView WebSocket at url, authenticated with
+// token. Connections are dialed on first use.
+func NewSessionManager(url, token string, poolSize int) *SessionManager {
+	if poolSize <= 0 {
+		poolSize = DefaultPoolSize
+	}
+	m := &SessionManager{
+		url:   url,
+		token: token,
+		slots: make([]*connSlot, poolSize),
+	}
+	for i := range m.slots {
+		m.slots[i] = &connSlot{}
+	}
+	return m
+}
+
+// GetHistoricalData fetches the last bars of the symbol, such as BINANCE:BTCUSDT, sorted by time
+func (m *SessionManager) GetHistoricalData(
+	ctx context.Context,
+	symbol string,
+	interval string,
+	bars int,
+	extendedSession bool,
+) ([]HistoricalData, error) {
+	session, err := m.openSeries(ctx, symbol, interval, bars, extendedSession, nil)
+	if err != nil {
+		return nil, err
+	}
+	session.conn.closeSession(session)
+
+	data := session.collectedBars()
+	if len(data) == 0 {
+		return nil, fmt.Errorf("no data found for %s", symbol)
+	}
+	return data, nil
+}
+
+// SubscribeBars streams the bars of the symbol to onBar until ctx is done, starting with the current bar. Updates of
+// the current bar are sent again with the same time. The series is created again when its connection drops. It returns
+// once the series is created, or with the error that prevented it. onBar is called from the connection's reader and
+// must not block.
+func (m *SessionManager) SubscribeBars(
+	ctx context.Context,
+	symbol string,
+	interval string,
+	onBar func(HistoricalData),
+) error {
+	session, err := m.openSeries(ctx, symbol, interval, 1, false, onBar)
+	if err != nil {
+		return err
+	}
+	go m.keepSubscribed(ctx, session, interval)
+	return nil
+}
+
+// Close closes the pooled connections, sessions on them fail with ErrConnectionClosed
+func (m *SessionManager) Close() {
+	for _, slot := range m.slots {
+		slot.mu.Lock()
+		if slot.conn != nil {
+			slot.conn.shutdown(ErrConnectionClosed)
+			slot.conn = nil
+		}
+		slot.mu.Unlock()
+	}
+}
+
+func (m *SessionManager) keepSubscribed(ctx context.Context, session *chartSession, interval string) {
+	backoff := time.Second
+	for {
+		select {
+		case <-ctx.Done():
+			session.conn.closeSession(session)
+			return
+		case err := <-session.failed:
+			session.conn.closeSession(session)
+			logger.Errorf("tradingview: bar stream of %s stopped, subscribing again: %v", session.symbol, err)
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			next, err := m.openSeries(ctx, session.symbol, interval, 1, false, session.onBar)
+			if err == nil {
+				session = next
+				backoff = time.Second
+				break
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			backoff = min(backoff*2, barStreamMaxBackoff)
+			logger.Errorf("tradingview: failed to subscribe to bars of %s, retrying in %s: %v",
+				session.symbol, backoff, err)
+		}
+	}
+}
+
+// openSeries creates a series of the symbol on a pooled connection and waits until its history is loaded
+func (m *SessionManager) openSeries(
+	ctx context.Context,
+	symbol string,
+	interval string,
+	bars int,
+	extendedSession bool,
+	onBar func(HistoricalData),
+) (*chartSession, error) {
+	conn, err := m.acquire()
+	if err != nil {
+		return nil, err
+	}
+	session, err := conn.openSession(symbol, interval, bars, extendedSession, onBar)
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-session.completed:
+		return session, nil
+	case err := <-session.failed:
+		conn.closeSession(session)
+		return nil, err
+	case <-ctx.Done():
+		conn.closeSession(session)
+		return nil, ctx.Err()
+	}
+}
+
+// acquire returns the connection of the next slot, dialing it when the slot has none or it dropped
+func (m *SessionManager) acquire() (*chartConn, error) {
+	slot := m.slots[m.next.Add(1)%uint64(len(m.slots))]
+	slot.mu.Lock()
+	defer slot.mu.Unlock()
+	if slot.conn != nil && !slot.conn.closed() {
+		return slot.conn, nil
+	}
+
+	ws, err := dial(m.url)
+	if err != nil {
+		return nil, err
+	}
+	conn := &chartConn{
+		ws:       ws,
+		sessions: make(map[string]*chartSession),
+		done:     make(chan struct{}),
+	}
+	if err := conn.send("set_auth_token", m.token); err != nil {
+		ws.Close()
+		return nil, err
+	}
+	go conn.readLoop()
+	slot.conn = conn
+	return conn, nil
+}
+
+func (c *chartConn) openSession(
+	symbol string,
+	interval string,
+	bars int,
+	extendedSession bool,
+	onBar func(HistoricalData),
+) (*chartSession, error) {
+	session := &chartSession{
+		id:        generateChartSession(),
+		symbol:    symbol,
+		conn:      c,
+		onBar:     onBar,
+		completed: make(chan struct{}),
+		failed:    make(chan error, 1),
+		bars:      make(map[int64]HistoricalData),
+	}
+	c.mu.Lock()
+	if c.err != nil {
+		c.mu.Unlock()
+		return nil, c.err
+	}
+	c.sessions[session.id] = session
+	c.mu.Unlock()
+
+	resolve, _ := json.Marshal(map[string]string{
+		"symbol":     symbol,
+		"adjustment": "splits",
+		"session":    map[bool]string{false: "regular", true: "extended"}[extendedSession],
+	})
+	messages := []struct {
+		function string
+		args     []interface{}
+	}{
+		{"chart_create_session", []interface{}{session.id, ""}},
+		{"resolve_symbol", []interface{}{session.id, "symbol_1", "=" + string(resolve)}},
+		{"create_series", []interface{}{session.id, seriesID, seriesID, "symbol_1", interval, bars}},
+	}
+	for _, msg := range messages {
+		if err := c.send(msg.function, msg.args...); err != nil {
+			// The connection cannot be written to after a failed write
+			c.shutdown(err)
+			return nil, fmt.Errorf("failed to send message %s: %v", msg.function, err)
+		}
+	}
+	return session, nil
+}
+
+// closeSession stops routing messages to the session and deletes it on TradingView
+func (c *chartConn) closeSession(session *chartSession) {
+	c.mu.Lock()
+	_, ok := c.sessions[session.id]
+	delete(c.sessions, session.id)
+	c.mu.Unlock()
+	if ok {
+		c.send("chart_delete_session", session.id)
+	}
+}
+
+func (c *chartConn) closed() bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// shutdown closes the connection and fails its sessions, only the first call has an effect
+func (c *chartConn) shutdown(err error) {
+	c.mu.Lock()
+	if c.err != nil {
+		c.mu.Unlock()
+		return
+	}
+	c.err = err
+	sessions := c.sessions
+	c.sessions = nil
+	close(c.done)
+	c.mu.Unlock()
+
+	c.ws.Close()
+	for _, session := range sessions {
+		session.fail(err)
+	}
+}
+
+func (c *chartConn) readLoop() {
+	for {
+		c.ws.SetReadDeadline(time.Now().Add(connReadTimeout))
+		_, message, err := c.ws.ReadMessage()
+		if err != nil {
+			c.shutdown(err)
+			return
+		}
+		for _, packet := range packetSeparator.Split(string(message), -1) {
+			if packet == "" {
+				continue
+			}
+			// Heartbeats are echoed back or TradingView closes the connection
+			if strings.HasPrefix(packet, "~h~") {
+				if err := c.write(prependHeader(packet)); err != nil {
+					c.shutdown(err)
+					return
+				}
+				continue
+			}
+			if err := c.dispatch(packet); err != nil {
+				c.shutdown(err)
+				return
+			}
+		}
+	}
+}
+
+// dispatch routes a packet to the chart session it names, it fails on protocol errors that end the connection
+func (c *chartConn) dispatch(packet string) error {
+	var msg struct {
+		Method string            `json:"m"`
+		Params []json.RawMessage `json:"p"`
+	}
+	// The first packet describes the server and has no method
+	if err := json.Unmarshal([]byte(packet), &msg); err != nil || msg.Method == "" || len(msg.Params) == 0 {
+		return nil
+	}
+	if msg.Method == "protocol_error" {
+		return fmt.Errorf("tradingview: protocol error: %s", msg.Params[0])
+	}
+
+	var sessionID string
+	if err := json.Unmarshal(msg.Params[0], &sessionID); err != nil {
+		return nil
+	}
+	c.mu.Lock()
+	session := c.sessions[sessionID]
+	c.mu.Unlock()
+	if session != nil {
+		session.handle(msg.Method, msg.Params[1:])
+	}
+	return nil
+}
+
+func (c *chartConn) send(function string, args ...interface{}) error {
+	return c.write(prependHeader(createMessage(function, args)))
+}
+
+func (c *chartConn) write(message string) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	c.ws.SetWriteDeadline(time.Now().Add(wsTimeout))
+	return c.ws.WriteMessage(websocket.TextMessage, []byte(message))
+}
+
+func (s *chartSession) handle(method string, params []json.RawMessage) {
+	switch method {
+	case "timescale_update", "du":
+		if len(params) > 0 {
+			s.addBars(params[0])
+		}
+	case "series_completed":
+		s.mu.Lock()
+		if !s.done {
+			s.done = true
+			close(s.completed)
+		}
+		s.mu.Unlock()
+	case "symbol_error":
+		s.fail(fmt.Errorf("%w %s: %s", ErrInvalidSymbol, s.symbol, joinParams(params)))
+	case "series_error", "critical_error":
+		s.fail(fmt.Errorf("%w for %s: %s", ErrSeriesFailed, s.symbol, joinParams(params)))
+	}
+}
+
+// addBars reads the bars of an update, {"s1":{"s":[{"i":0,"v":[time,open,high,low,close,volume]}]}}
+func (s *chartSession) addBars(raw json.RawMessage) {
+	var update map[string]struct {
+		Bars []struct {
+			Values []float64 `json:"v"`
+		} `json:"s"`
+	}
+	if err := json.Unmarshal(raw, &update); err != nil {
+		return
+	}
+	for _, bar := range update[seriesID].Bars {
+		v := bar.Values
+		if len(v) < 5 {
+			continue
+		}
+		data := HistoricalData{
+			DateTime: time.Unix(int64(v[0]), 0).UTC(),
+			Symbol:   s.symbol,
+			Open:     v[1],
+			High:     v[2],
+			Low:      v[3],
+			Close:    v[4],
+		}
+		if len(v) > 5 {
+			data.Volume = v[5]
+		}
+
+		if s.onBar != nil {
+			s.onBar(data)
+			continue
+		}
+		s.mu.Lock()
+		s.bars[data.DateTime.Unix()] = data
+		s.mu.Unlock()
+	}
+}
+
+func (s *chartSession) fail(err error) {
+	select {
+	case s.failed <- err:
+	default:
+	}
+}
+
+func (s *chartSession) collectedBars() []HistoricalData {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := make([]HistoricalData, 0, len(s.bars))
+	for _, bar := range s.bars {
+		result = append(result, bar)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].DateTime.Before(result[j].DateTime)
+	})
+	return result
+}
+
+// joinParams formats the params of an error message for logs
+func joinParams(params []json.RawMessage) string {
+	parts := make([]string, len(params))
+	for i, param := range params {
+		parts[i] = string(param)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,220 @@
+package tradingview_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/quantsmithapp/datastation-backend/internal/tradingview"
+	"github.com/quantsmithapp/datastation-backend/internal/tradingview/tvtest"
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
+)
+
+const testToken = "test_token"
+
+func TestMain(m *testing.M) {
+	logger.SetGlobalLogger(logger.NewLoggerMock())
+	os.Exit(m.Run())
+}
+
+// testBars returns n daily bars of the symbol, the close of bar i is base+i
+func testBars(symbol string, n int, base float64) []tradingview.HistoricalData {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	bars := make([]tradingview.HistoricalData, n)
+	for i := range bars {
+		price := base + float64(i)
+		bars[i] = tradingview.HistoricalData{
+			DateTime: start.AddDate(0, 0, i),
+			Symbol:   symbol,
+			Open:     price,
+			High:     price + 1,
+			Low:      price - 1,
+			Close:    price,
+			Volume:   10,
+		}
+	}
+	return bars
+}
+
+// eventually fails the test when condition does not hold within 5 seconds
+func eventually(t *testing.T, what string, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestSessionManagerConcurrentFetches(t *testing.T) {
+	server := tvtest.NewServer()
+	defer server.Close()
+
+	const symbols = 8
+	for i := 0; i < symbols; i++ {
+		symbol := fmt.Sprintf("BINANCE:COIN%dUSDT", i)
+		server.SetBars(symbol, testBars(symbol, 20, float64(i*100)))
+	}
+
+	manager := tradingview.NewSessionManager(server.URL, testToken, 2)
+	defer manager.Close()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, symbols*3)
+	for n := 0; n < symbols*3; n++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			symbol := fmt.Sprintf("BINANCE:COIN%dUSDT", i)
+			data, err := manager.GetHistoricalData(context.Background(), symbol, "1D", 20, false)
+			if err != nil {
+				errs <- fmt.Errorf("%s: %w", symbol, err)
+				return
+			}
+			if len(data) != 20 {
+				errs <- fmt.Errorf("%s: got %d bars, want 20", symbol, len(data))
+				return
+			}
+			for j, bar := range data {
+				if bar.Symbol != symbol || bar.Close != float64(i*100+j) {
+					errs <- fmt.Errorf("%s: bar %d is %+v, another session's bars were mixed in", symbol, j, bar)
+					return
+				}
+			}
+		}(n % symbols)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	if dials := server.Dials(); dials > 2 {
+		t.Errorf("dialed %d connections, want at most the pool size 2", dials)
+	}
+	for _, token := range server.Tokens() {
+		if token != testToken {
+			t.Errorf("connection authenticated with %q, want %q", token, testToken)
+		}
+	}
+}
+
+func TestSessionManagerWaitsForSeriesCompleted(t *testing.T) {
+	server := tvtest.NewServer()
+	defer server.Close()
+	server.SetBars("BINANCE:BTCUSDT", testBars("BINANCE:BTCUSDT", 10, 100))
+
+	manager := tradingview.NewSessionManager(server.URL, testToken, 1)
+	defer manager.Close()
+
+	data, err := manager.GetHistoricalData(context.Background(), "BINANCE:BTCUSDT", "1D", 4, false)
+	if err != nil {
+		t.Fatalf("GetHistoricalData: %v", err)
+	}
+	if len(data) != 4 {
+		t.Fatalf("got %d bars, want the last 4", len(data))
+	}
+	for i, bar := range data {
+		if want := float64(106 + i); bar.Close != want {
+			t.Errorf("bar %d closes at %v, want %v", i, bar.Close, want)
+		}
+	}
+
+	_, err = manager.GetHistoricalData(context.Background(), "BINANCE:NOPEUSDT", "1D", 4, false)
+	if !errors.Is(err, tradingview.ErrInvalidSymbol) {
+		t.Errorf("unknown symbol returned %v, want ErrInvalidSymbol", err)
+	}
+}
+
+func TestSessionManagerReconnects(t *testing.T) {
+	server := tvtest.NewServer()
+	defer server.Close()
+	server.SetBars("BINANCE:ETHUSDT", testBars("BINANCE:ETHUSDT", 5, 2000))
+
+	manager := tradingview.NewSessionManager(server.URL, testToken, 1)
+	defer manager.Close()
+
+	if _, err := manager.GetHistoricalData(context.Background(), "BINANCE:ETHUSDT", "1D", 5, false); err != nil {
+		t.Fatalf("GetHistoricalData: %v", err)
+	}
+
+	server.DropConnections()
+	eventually(t, "the connection to drop", func() bool { return server.Connections() == 0 })
+
+	// A fetch racing the drop may fail with the dropped connection, the next one dials again
+	eventually(t, "a fetch over a new connection", func() bool {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		data, err := manager.GetHistoricalData(ctx, "BINANCE:ETHUSDT", "1D", 5, false)
+		return err == nil && len(data) == 5
+	})
+	if dials := server.Dials(); dials != 2 {
+		t.Errorf("dialed %d connections, want 2", dials)
+	}
+}
+
+func TestSessionManagerSubscribeBars(t *testing.T) {
+	server := tvtest.NewServer()
+	defer server.Close()
+	server.SetBars("BINANCE:SOLUSDT", testBars("BINANCE:SOLUSDT", 5, 150))
+
+	manager := tradingview.NewSessionManager(server.URL, testToken, 1)
+	defer manager.Close()
+
+	received := make(chan tradingview.HistoricalData, 16)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err := manager.SubscribeBars(ctx, "BINANCE:SOLUSDT", "1", func(bar tradingview.HistoricalData) {
+		select {
+		case received <- bar:
+		default:
+		}
+	})
+	if err != nil {
+		t.Fatalf("SubscribeBars: %v", err)
+	}
+
+	next := func(what string) tradingview.HistoricalData {
+		t.Helper()
+		select {
+		case bar := <-received:
+			return bar
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %s", what)
+			return tradingview.HistoricalData{}
+		}
+	}
+
+	if bar := next("the current bar"); bar.Close != 154 {
+		t.Errorf("current bar closes at %v, want 154", bar.Close)
+	}
+
+	update := testBars("BINANCE:SOLUSDT", 6, 150)[5]
+	if sent := server.PushBar("BINANCE:SOLUSDT", update); sent != 1 {
+		t.Fatalf("du sent to %d series, want 1", sent)
+	}
+	if bar := next("the du update"); bar.Close != update.Close || !bar.DateTime.Equal(update.DateTime) {
+		t.Errorf("du update is %+v, want %+v", bar, update)
+	}
+
+	// The series is created again on a new connection after a drop, starting with the current bar
+	server.DropConnections()
+	if bar := next("the current bar after reconnecting"); bar.Close != 154 {
+		t.Errorf("current bar after reconnecting closes at %v, want 154", bar.Close)
+	}
+	update.Close = 160
+	eventually(t, "the resubscribed series", func() bool { return server.PushBar("BINANCE:SOLUSDT", update) == 1 })
+	if bar := next("the du update after reconnecting"); bar.Close != 160 {
+		t.Errorf("du update after reconnecting closes at %v, want 160", bar.Close)
+	}
+
+	// Cancelling ctx deletes the chart session
+	cancel()
+	eventually(t, "the chart session to be deleted", func() bool { return server.PushBar("BINANCE:SOLUSDT", update) == 0 })
+}
@@ -0,0 +1,266 @@
+// Package tvtest runs a fake TradingView WebSocket server for tests of the tradingview package and its users. It speaks
+// the framed ~m~ protocol and answers chart sessions with the bars set on it.
+package tvtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"github.com/quantsmithapp/datastation-backend/internal/tradingview"
+)
+
+var packetSeparator = regexp.MustCompile(`~m~\d+~m~`)
+
+// Server is a fake TradingView WebSocket, pass its URL to tradingview.NewSessionManager
+type Server struct {
+	URL string
+
+	server   *httptest.Server
+	upgrader websocket.Upgrader
+
+	mu     sync.Mutex // Guards the fields below
+	bars   map[string][]tradingview.HistoricalData
+	conns  map[*serverConn]bool
+	tokens []string
+	dials  int
+}
+
+type serverConn struct {
+	ws      *websocket.Conn
+	writeMu sync.Mutex
+
+	mu sync.Mutex // Guards the fields below
+	// resolved is the symbol of each chart session, series are the chart sessions with a series
+	resolved map[string]string
+	series   map[string]bool
+}
+
+// NewServer starts a server without symbols, close it when the test ends
+func NewServer() *Server {
+	s := &Server{
+		bars:     make(map[string][]tradingview.HistoricalData),
+		conns:    make(map[*serverConn]bool),
+		upgrader: websocket.Upgrader{CheckOrigin: func(*http.Request) bool { return true }},
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serve))
+	s.URL = "ws" + strings.TrimPrefix(s.server.URL, "http")
+	return s
+}
+
+func (s *Server) Close() {
+	s.DropConnections()
+	s.server.Close()
+}
+
+// SetBars sets the bars of the symbol, such as BINANCE:BTCUSDT, oldest first. Unknown symbols fail to resolve.
+func (s *Server) SetBars(symbol string, bars []tradingview.HistoricalData) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bars[symbol] = bars
+}
+
+// PushBar sends the bar as a live update to every series of the symbol and returns how many there are
+func (s *Server) PushBar(symbol string, bar tradingview.HistoricalData) int {
+	s.mu.Lock()
+	conns := make([]*serverConn, 0, len(s.conns))
+	for conn := range s.conns {
+		conns = append(conns, conn)
+	}
+	s.mu.Unlock()
+
+	sent := 0
+	for _, conn := range conns {
+		conn.mu.Lock()
+		var sessions []string
+		for session := range conn.series {
+			if conn.resolved[session] == symbol {
+				sessions = append(sessions, session)
+			}
+		}
+		conn.mu.Unlock()
+		for _, session := range sessions {
+			if conn.send("du", session, seriesUpdate([]tradingview.HistoricalData{bar})) == nil {
+				sent++
+			}
+		}
+	}
+	return sent
+}
+
+// DropConnections closes every open connection, as TradingView does when it restarts
+func (s *Server) DropConnections() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for conn := range s.conns {
+		conn.ws.Close()
+	}
+}
+
+// Connections is the number of open connections
+func (s *Server) Connections() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.conns)
+}
+
+// Dials is the number of connections opened since the server started
+func (s *Server) Dials() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dials
+}
+
+// Tokens are the auth tokens the connections were opened with
+func (s *Server) Tokens() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.tokens...)
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	ws, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	conn := &serverConn{
+		ws:       ws,
+		resolved: make(map[string]string),
+		series:   make(map[string]bool),
+	}
+	s.mu.Lock()
+	s.conns[conn] = true
+	s.dials++
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		ws.Close()
+	}()
+
+	conn.write(`{"session_id":"fake","timestamp":0,"release":"fake"}`)
+	conn.write("~h~1")
+
+	authenticated := false
+	for {
+		_, message, err := ws.ReadMessage()
+		if err != nil {
+			return
+		}
+		for _, packet := range packetSeparator.Split(string(message), -1) {
+			if packet == "" || strings.HasPrefix(packet, "~h~") {
+				continue
+			}
+			var msg struct {
+				Method string        `json:"m"`
+				Params []interface{} `json:"p"`
+			}
+			if err := json.Unmarshal([]byte(packet), &msg); err != nil || len(msg.Params) == 0 {
+				conn.send("protocol_error", "wrong data")
+				return
+			}
+
+			if msg.Method == "set_auth_token" {
+				token, _ := msg.Params[0].(string)
+				s.mu.Lock()
+				s.tokens = append(s.tokens, token)
+				s.mu.Unlock()
+				authenticated = true
+				continue
+			}
+			if !authenticated {
+				conn.send("protocol_error", "auth token required")
+				return
+			}
+			s.handle(conn, msg.Method, msg.Params)
+		}
+	}
+}
+
+func (s *Server) handle(conn *serverConn, method string, params []interface{}) {
+	session, _ := params[0].(string)
+	switch method {
+	case "resolve_symbol":
+		if len(params) < 3 {
+			return
+		}
+		resolve, _ := params[2].(string)
+		var symbol struct {
+			Symbol string `json:"symbol"`
+		}
+		json.Unmarshal([]byte(strings.TrimPrefix(resolve, "=")), &symbol)
+		s.mu.Lock()
+		_, ok := s.bars[symbol.Symbol]
+		s.mu.Unlock()
+		if !ok {
+			conn.send("symbol_error", session, "symbol_1", "invalid symbol")
+			return
+		}
+		conn.mu.Lock()
+		conn.resolved[session] = symbol.Symbol
+		conn.mu.Unlock()
+		conn.send("symbol_resolved", session, "symbol_1", map[string]string{"name": symbol.Symbol})
+
+	case "create_series":
+		if len(params) < 6 {
+			return
+		}
+		count, _ := params[5].(float64)
+		conn.mu.Lock()
+		symbol, ok := conn.resolved[session]
+		if ok {
+			conn.series[session] = true
+		}
+		conn.mu.Unlock()
+		if !ok {
+			conn.send("series_error", session, "s1", "resolve error")
+			return
+		}
+		s.mu.Lock()
+		bars := s.bars[symbol]
+		s.mu.Unlock()
+		if len(bars) > int(count) {
+			bars = bars[len(bars)-int(count):]
+		}
+		conn.send("timescale_update", session, seriesUpdate(bars))
+		conn.send("series_completed", session, "s1", "streaming", "s1_1")
+
+	case "chart_delete_session":
+		conn.mu.Lock()
+		delete(conn.resolved, session)
+		delete(conn.series, session)
+		conn.mu.Unlock()
+	}
+}
+
+func (c *serverConn) send(method string, params ...interface{}) error {
+	message, err := json.Marshal(map[string]interface{}{"m": method, "p": params})
+	if err != nil {
+		return err
+	}
+	return c.write(string(message))
+}
+
+func (c *serverConn) write(packet string) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return c.ws.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf("~m~%d~m~%s", len(packet), packet)))
+}
+
+// seriesUpdate formats bars as the s1 series of a timescale_update or du message
+func seriesUpdate(bars []tradingview.HistoricalData) map[string]interface{} {
+	points := make([]map[string]interface{}, len(bars))
+	for i, bar := range bars {
+		points[i] = map[string]interface{}{
+			"i": i,
+			"v": []float64{float64(bar.DateTime.Unix()), bar.Open, bar.High, bar.Low, bar.Close, bar.Volume},
+		}
+	}
+	return map[string]interface{}{"s1": map[string]interface{}{"s": points}}
+}
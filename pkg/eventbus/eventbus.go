@@ -0,0 +1,63 @@
+package eventbus
+
+import (
+	"sync"
+
+	"github.com/quantsmithapp/datastation-backend/pkg/logger"
+)
+
+type subscriber[E any] struct {
+	name   string
+	events chan E
+}
+
+// Bus is an in-process publish/subscribe bus. Every subscriber gets its own buffered queue and goroutine, so a slow
+// subscriber never blocks publishers; events that do not fit in its queue are dropped and logged.
+type Bus[E any] struct {
+	mu          sync.RWMutex
+	buffer      int
+	subscribers []*subscriber[E]
+}
+
+func New[E any](buffer int) *Bus[E] {
+	if buffer <= 0 {
+		buffer = 1
+	}
+	return &Bus[E]{buffer: buffer}
+}
+
+// Subscribe calls handler for every event published from now on, one event at a time in publish order
+func (b *Bus[E]) Subscribe(name string, handler func(E)) {
+	sub := &subscriber[E]{name: name, events: make(chan E, b.buffer)}
+	go func() {
+		for event := range sub.events {
+			b.handle(sub.name, handler, event)
+		}
+	}()
+
+	b.mu.Lock()
+	b.subscribers = append(b.subscribers, sub)
+	b.mu.Unlock()
+}
+
+func (b *Bus[E]) Publish(event E) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, sub := range b.subscribers {
+		select {
+		case sub.events <- event:
+		default:
+			logger.Warnf("eventbus: subscriber %s is full, dropping event", sub.name)
+		}
+	}
+}
+
+func (b *Bus[E]) handle(name string, handler func(E), event E) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Errorf("eventbus: subscriber %s panicked: %v", name, r)
+		}
+	}()
+	handler(event)
+}
@@ -16,6 +16,13 @@ func InitGlobalLogger() {
 	}
 }
 
+// SetGlobalLogger replaces the global logger, tests set NewLoggerMock to run without a config file
+func SetGlobalLogger(l Logger) {
+	mu.Lock()
+	defer mu.Unlock()
+	loggerObj = l
+}
+
 func Debug(msg string, fields ...zap.Field) {
 	mu.Lock()
 	defer mu.Unlock()